@@ -0,0 +1,68 @@
+package postgis
+
+import "math"
+
+// Area returns the total area of the faces. Faces are measured in 3D when the
+// surface has Z, like ST_3DArea, and in the XY plane otherwise.
+func (s PolyhedralSurfaceOf[P]) Area() float64 { return facesArea(s.Polygons) }
+
+// Area returns the total area of the faces. Faces are measured in 3D when the
+// surface has Z, like ST_3DArea, and in the XY plane otherwise.
+func (s PolyhedralSurfaceSOf[P]) Area() float64 { return facesArea(s.Polygons) }
+
+// Area returns the total area of the triangles, in 3D when the TIN has Z
+func (m TINOf[P]) Area() float64 { return trianglesArea(m.Triangles) }
+
+// Area returns the total area of the triangles, in 3D when the TIN has Z
+func (m TINSOf[P]) Area() float64 { return trianglesArea(m.Triangles) }
+
+// Area returns the area of the triangle, in 3D when it has Z
+func (t TriangleOf[P]) Area() float64 { return faceArea(triangleRings(t.Points)) }
+
+// Area returns the area of the triangle, in 3D when it has Z
+func (t TriangleSOf[P]) Area() float64 { return faceArea(triangleRings(t.Points)) }
+
+func facesArea[P PointLike](polygons []PolygonOf[P]) float64 {
+	area := 0.0
+	for _, p := range polygons {
+		area += faceArea(p.Rings)
+	}
+	return area
+}
+
+func trianglesArea[P PointLike](triangles []TriangleOf[P]) float64 {
+	area := 0.0
+	for _, t := range triangles {
+		area += faceArea(triangleRings(t.Points))
+	}
+	return area
+}
+
+// faceArea returns the area of a planar face in space, its shell minus its
+// holes. M is ignored and Z is zero for types without it.
+func faceArea[P PointLike](rings [][]P) float64 {
+	area := 0.0
+	for i, ring := range rings {
+		if i == 0 {
+			area += vectorArea(ring)
+		} else {
+			area -= vectorArea(ring)
+		}
+	}
+	return area
+}
+
+// vectorArea returns the area enclosed by a closed ring in space, as half
+// the length of the sum of the cross products of its vertices (Newell's
+// method), which measures the ring in its own plane
+func vectorArea[P PointLike](ring []P) float64 {
+	var nx, ny, nz float64
+	for i := 0; i+1 < len(ring); i++ {
+		x1, y1, z1, _ := pointValues(ring[i])
+		x2, y2, z2, _ := pointValues(ring[i+1])
+		nx += (y1 - y2) * (z1 + z2)
+		ny += (z1 - z2) * (x1 + x2)
+		nz += (x1 - x2) * (y1 + y2)
+	}
+	return math.Sqrt(nx*nx+ny*ny+nz*nz) / 2
+}
@@ -0,0 +1,25 @@
+// Package bridge converts the geometries of go-postgis to and from those of
+// github.com/paulmach/orb and github.com/twpayne/go-geom, so that they can
+// be used with the algorithms and encodings of these libraries. It is a
+// module of its own so that the postgis package does not depend on them.
+//
+// The conversions are lossless: converting back gives the original
+// geometry. When the other library cannot hold a geometry, because it lacks
+// its type or a dimension, the conversion fails instead.
+package bridge
+
+import (
+	"errors"
+	"fmt"
+
+	postgis "github.com/cridenour/go-postgis"
+)
+
+// ErrMissingDimension is returned when converting a geometry with Z or M to
+// a library that only holds X and Y
+var ErrMissingDimension = errors.New("target has no such dimension")
+
+// unsupported returns the error for a geometry the other library lacks
+func unsupported(g any) error {
+	return fmt.Errorf("%w: %T", postgis.ErrUnsupportedType, g)
+}
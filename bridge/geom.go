@@ -0,0 +1,52 @@
+package bridge
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	postgis "github.com/cridenour/go-postgis"
+	"github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/ewkb"
+	"github.com/twpayne/go-geom/encoding/wkbcommon"
+)
+
+// ToGeom converts g to the go-geom geometry of the same type and layout,
+// such as a *geom.Point of layout XYZ for a *postgis.PointZS, with the SRID
+// of g. go-geom has no curves nor surfaces, for which it fails. Empty points
+// keep their NaN coordinates.
+func ToGeom(g postgis.Geometry) (geom.T, error) {
+	data, err := postgis.AppendEWKB(nil, g)
+	if err != nil {
+		return nil, err
+	}
+	t, err := ewkb.Unmarshal(data)
+	var unsupportedType wkbcommon.ErrUnsupportedType
+	if errors.As(err, &unsupportedType) {
+		return nil, fmt.Errorf("%w: go-geom cannot hold %T: %w", postgis.ErrUnsupportedType, g, err)
+	}
+	return t, err
+}
+
+// FromGeom converts t to the geometry of the same type and layout, with an
+// SRID when t has one other than 0: a *geom.Point of layout XYZ gives a
+// *postgis.PointZ, or a *postgis.PointZS with an SRID. A *geom.LinearRing
+// becomes a LineString. The SRIDs of the members of a collection are left
+// out, as in EWKB.
+func FromGeom(t geom.T) (postgis.Geometry, error) {
+	if ring, ok := t.(*geom.LinearRing); ok {
+		t = geom.NewLineStringFlat(ring.Layout(), ring.FlatCoords()).SetSRID(ring.SRID())
+	}
+	data, err := ewkb.Marshal(t, ewkb.NDR)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", postgis.ErrUnsupportedType, err)
+	}
+	g, err := postgis.NewGeometry(binary.LittleEndian.Uint32(data[1:]))
+	if err != nil {
+		return nil, err
+	}
+	if err := postgis.ReadEWKBBytes(data, g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
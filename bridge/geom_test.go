@@ -0,0 +1,105 @@
+package bridge
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	postgis "github.com/cridenour/go-postgis"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeomRoundTrip(t *testing.T) {
+	tests := []struct {
+		g        postgis.Geometry
+		expected geom.T
+	}{
+		{&postgis.Point{X: 1, Y: 2}, geom.NewPointFlat(geom.XY, []float64{1, 2})},
+		{&postgis.PointZS{SRID: 4326, X: 1, Y: 2, Z: 3}, geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}).SetSRID(4326)},
+		{&postgis.LineStringM{Points: []postgis.PointM{{X: 1, Y: 2, M: 3}, {X: 4, Y: 5, M: 6}}},
+			geom.NewLineStringFlat(geom.XYM, []float64{1, 2, 3, 4, 5, 6})},
+		{&postgis.PolygonS{SRID: 3857, Rings: square},
+			geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 1, 0, 0}, []int{10}).SetSRID(3857)},
+		{&postgis.MultiPointZM{Points: []postgis.PointZM{{X: 1, Y: 2, Z: 3, M: 4}}},
+			geom.NewMultiPointFlat(geom.XYZM, []float64{1, 2, 3, 4})},
+		{&postgis.MultiLineString{LineStrings: []postgis.LineString{{Points: []postgis.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}}},
+			geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4}, []int{4})},
+		{&postgis.MultiPolygon{Polygons: []postgis.Polygon{{Rings: square}}},
+			geom.NewMultiPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 1, 0, 0}, [][]int{{10}})},
+	}
+	for _, test := range tests {
+		converted, err := ToGeom(test.g)
+		if err != nil {
+			t.Fatalf("ToGeom(%T) failed: %v", test.g, err)
+		}
+		if !reflect.DeepEqual(converted, test.expected) {
+			t.Errorf("Expected %#v, got %#v", test.expected, converted)
+		}
+		back, err := FromGeom(converted)
+		if err != nil {
+			t.Fatalf("FromGeom(%T) failed: %v", converted, err)
+		}
+		if !reflect.DeepEqual(back, test.g) {
+			t.Errorf("Expected %#v, got %#v", test.g, back)
+		}
+	}
+}
+
+func TestGeomCollection(t *testing.T) {
+	gc := &postgis.GeometryCollectionS{SRID: 4326, Geometries: []postgis.Geometry{
+		&postgis.PointZ{X: 1, Y: 2, Z: 3},
+		&postgis.LineStringZ{Points: []postgis.PointZ{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}},
+	}}
+	converted, err := ToGeom(gc)
+	if err != nil {
+		t.Fatalf("ToGeom failed: %v", err)
+	}
+	if converted.SRID() != 4326 || converted.Layout() != geom.XYZ {
+		t.Errorf("Expected SRID 4326 and layout XYZ, got %d and %v", converted.SRID(), converted.Layout())
+	}
+	back, err := FromGeom(converted)
+	if err != nil {
+		t.Fatalf("FromGeom failed: %v", err)
+	}
+	if !reflect.DeepEqual(back, gc) {
+		t.Errorf("Expected %#v, got %#v", gc, back)
+	}
+}
+
+func TestGeomEmptyPoint(t *testing.T) {
+	converted, err := ToGeom(&postgis.PointS{SRID: 4326, X: math.NaN(), Y: math.NaN()})
+	if err != nil {
+		t.Fatalf("ToGeom failed: %v", err)
+	}
+	back, err := FromGeom(converted)
+	if err != nil {
+		t.Fatalf("FromGeom failed: %v", err)
+	}
+	if p := back.(*postgis.PointS); p.SRID != 4326 || !math.IsNaN(p.X) || !math.IsNaN(p.Y) {
+		t.Errorf("Expected an empty point, got %v", p)
+	}
+}
+
+func TestGeomLinearRing(t *testing.T) {
+	ring := geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}).SetSRID(4326)
+	converted, err := FromGeom(ring)
+	if err != nil {
+		t.Fatalf("FromGeom failed: %v", err)
+	}
+	expected := &postgis.LineStringS{SRID: 4326, Points: []postgis.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}
+	if !reflect.DeepEqual(converted, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, converted)
+	}
+}
+
+func TestGeomUnsupported(t *testing.T) {
+	for _, g := range []postgis.Geometry{
+		&postgis.CircularString{Points: []postgis.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}},
+		&postgis.GeometryCollection{Geometries: []postgis.Geometry{&postgis.Triangle{Points: []postgis.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}},
+	} {
+		if _, err := ToGeom(g); !errors.Is(err, postgis.ErrUnsupportedType) {
+			t.Errorf("Expected ErrUnsupportedType for %T, got %v", g, err)
+		}
+	}
+}
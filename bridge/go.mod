@@ -0,0 +1,11 @@
+module github.com/cridenour/go-postgis/bridge
+
+go 1.22
+
+require (
+	github.com/cridenour/go-postgis v0.0.0
+	github.com/paulmach/orb v0.13.0
+	github.com/twpayne/go-geom v1.6.1
+)
+
+replace github.com/cridenour/go-postgis => ..
@@ -0,0 +1,167 @@
+package bridge
+
+import (
+	"fmt"
+
+	postgis "github.com/cridenour/go-postgis"
+	"github.com/paulmach/orb"
+)
+
+// ToOrb converts g to the orb geometry of the same type. orb geometries are
+// two dimensional, so g must not have Z or M, and cannot be a curve or
+// surface. They have no SRID either: the SRID of g is left out, to be passed
+// back to FromOrbSRID. Empty points become points of NaN coordinates, as in
+// EWKB.
+func ToOrb(g postgis.Geometry) (orb.Geometry, error) {
+	if info := postgis.GetGeometryInfo(g.GetType()); info.CoordType != postgis.CoordXY {
+		return nil, fmt.Errorf("%w: orb cannot hold %v coordinates of %T", ErrMissingDimension, info.CoordType, g)
+	}
+	switch g := g.(type) {
+	case *postgis.Point:
+		return orbPoint(*g), nil
+	case *postgis.PointS:
+		return orb.Point{g.X, g.Y}, nil
+	case *postgis.LineString:
+		return orbLineString(g.Points), nil
+	case *postgis.LineStringS:
+		return orbLineString(g.Points), nil
+	case *postgis.Polygon:
+		return orbPolygon(g.Rings), nil
+	case *postgis.PolygonS:
+		return orbPolygon(g.Rings), nil
+	case *postgis.MultiPoint:
+		return orb.MultiPoint(orbLineString(g.Points)), nil
+	case *postgis.MultiPointS:
+		return orb.MultiPoint(orbLineString(g.Points)), nil
+	case *postgis.MultiLineString:
+		return orbMultiLineString(g.LineStrings), nil
+	case *postgis.MultiLineStringS:
+		return orbMultiLineString(g.LineStrings), nil
+	case *postgis.MultiPolygon:
+		return orbMultiPolygon(g.Polygons), nil
+	case *postgis.MultiPolygonS:
+		return orbMultiPolygon(g.Polygons), nil
+	case *postgis.GeometryCollection:
+		return orbCollection(g.Geometries)
+	case *postgis.GeometryCollectionS:
+		return orbCollection(g.Geometries)
+	}
+	return nil, unsupported(g)
+}
+
+// FromOrb converts g to the geometry of the same type without SRID, such as
+// a *postgis.LineString for an orb.LineString. orb.Ring and orb.Bound become
+// a *postgis.Polygon, like their GeoJSON.
+func FromOrb(g orb.Geometry) (postgis.Geometry, error) {
+	switch g := g.(type) {
+	case orb.Point:
+		return &postgis.Point{X: g[0], Y: g[1]}, nil
+	case orb.LineString:
+		return &postgis.LineString{Points: fromOrbPoints(g)}, nil
+	case orb.Ring:
+		return &postgis.Polygon{Rings: fromOrbRings([]orb.Ring{g})}, nil
+	case orb.Bound:
+		return &postgis.Polygon{Rings: fromOrbRings(g.ToPolygon())}, nil
+	case orb.Polygon:
+		return &postgis.Polygon{Rings: fromOrbRings(g)}, nil
+	case orb.MultiPoint:
+		return &postgis.MultiPoint{Points: fromOrbPoints(g)}, nil
+	case orb.MultiLineString:
+		lines := make([]postgis.LineString, len(g))
+		for i, line := range g {
+			lines[i].Points = fromOrbPoints(line)
+		}
+		return &postgis.MultiLineString{LineStrings: lines}, nil
+	case orb.MultiPolygon:
+		polygons := make([]postgis.Polygon, len(g))
+		for i, polygon := range g {
+			polygons[i].Rings = fromOrbRings(polygon)
+		}
+		return &postgis.MultiPolygon{Polygons: polygons}, nil
+	case orb.Collection:
+		geometries := make([]postgis.Geometry, len(g))
+		for i, member := range g {
+			converted, err := FromOrb(member)
+			if err != nil {
+				return nil, err
+			}
+			geometries[i] = converted
+		}
+		return &postgis.GeometryCollection{Geometries: geometries}, nil
+	}
+	return nil, unsupported(g)
+}
+
+// FromOrbSRID is like FromOrb, returning the geometry type with an SRID,
+// such as a *postgis.LineStringS for an orb.LineString
+func FromOrbSRID(g orb.Geometry, srid int32) (postgis.Geometry, error) {
+	converted, err := FromOrb(g)
+	if err != nil {
+		return nil, err
+	}
+	return postgis.WithSRID(converted, srid)
+}
+
+func orbPoint(p postgis.Point) orb.Point {
+	return orb.Point{p.X, p.Y}
+}
+
+func orbLineString(points []postgis.Point) orb.LineString {
+	line := make(orb.LineString, len(points))
+	for i, p := range points {
+		line[i] = orbPoint(p)
+	}
+	return line
+}
+
+func orbPolygon(rings [][]postgis.Point) orb.Polygon {
+	polygon := make(orb.Polygon, len(rings))
+	for i, ring := range rings {
+		polygon[i] = orb.Ring(orbLineString(ring))
+	}
+	return polygon
+}
+
+func orbMultiLineString(lines []postgis.LineString) orb.MultiLineString {
+	multi := make(orb.MultiLineString, len(lines))
+	for i, line := range lines {
+		multi[i] = orbLineString(line.Points)
+	}
+	return multi
+}
+
+func orbMultiPolygon(polygons []postgis.Polygon) orb.MultiPolygon {
+	multi := make(orb.MultiPolygon, len(polygons))
+	for i, polygon := range polygons {
+		multi[i] = orbPolygon(polygon.Rings)
+	}
+	return multi
+}
+
+func orbCollection(geometries []postgis.Geometry) (orb.Collection, error) {
+	collection := make(orb.Collection, len(geometries))
+	for i, member := range geometries {
+		converted, err := ToOrb(member)
+		if err != nil {
+			return nil, err
+		}
+		collection[i] = converted
+	}
+	return collection, nil
+}
+
+func fromOrbPoints[L ~[]orb.Point](line L) []postgis.Point {
+	points := make([]postgis.Point, len(line))
+	for i, p := range line {
+		points[i] = postgis.Point{X: p[0], Y: p[1]}
+	}
+	return points
+}
+
+func fromOrbRings(rings []orb.Ring) [][]postgis.Point {
+	converted := make([][]postgis.Point, len(rings))
+	for i, ring := range rings {
+		converted[i] = fromOrbPoints(ring)
+	}
+	return converted
+}
@@ -0,0 +1,106 @@
+package bridge
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	postgis "github.com/cridenour/go-postgis"
+	"github.com/paulmach/orb"
+)
+
+var square = [][]postgis.Point{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0}}}
+
+func TestOrbRoundTrip(t *testing.T) {
+	line := []postgis.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}
+	tests := []struct {
+		g        postgis.Geometry
+		expected orb.Geometry
+	}{
+		{&postgis.Point{X: 1, Y: 2}, orb.Point{1, 2}},
+		{&postgis.LineString{Points: line}, orb.LineString{{1, 2}, {3, 4}}},
+		{&postgis.Polygon{Rings: square}, orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}},
+		{&postgis.MultiPoint{Points: line}, orb.MultiPoint{{1, 2}, {3, 4}}},
+		{&postgis.MultiLineString{LineStrings: []postgis.LineString{{Points: line}}}, orb.MultiLineString{{{1, 2}, {3, 4}}}},
+		{&postgis.MultiPolygon{Polygons: []postgis.Polygon{{Rings: square}}}, orb.MultiPolygon{{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}}},
+		{&postgis.GeometryCollection{Geometries: []postgis.Geometry{&postgis.Point{X: 1, Y: 2}, &postgis.LineString{Points: line}}},
+			orb.Collection{orb.Point{1, 2}, orb.LineString{{1, 2}, {3, 4}}}},
+	}
+	for _, test := range tests {
+		converted, err := ToOrb(test.g)
+		if err != nil {
+			t.Fatalf("ToOrb(%T) failed: %v", test.g, err)
+		}
+		if !reflect.DeepEqual(converted, test.expected) {
+			t.Errorf("Expected %#v, got %#v", test.expected, converted)
+		}
+		back, err := FromOrb(converted)
+		if err != nil {
+			t.Fatalf("FromOrb(%T) failed: %v", converted, err)
+		}
+		if !reflect.DeepEqual(back, test.g) {
+			t.Errorf("Expected %#v, got %#v", test.g, back)
+		}
+	}
+}
+
+func TestOrbSRID(t *testing.T) {
+	ls := &postgis.LineStringS{SRID: 4326, Points: []postgis.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+	converted, err := ToOrb(ls)
+	if err != nil {
+		t.Fatalf("ToOrb failed: %v", err)
+	}
+	back, err := FromOrbSRID(converted, ls.SRID)
+	if err != nil {
+		t.Fatalf("FromOrbSRID failed: %v", err)
+	}
+	if !reflect.DeepEqual(back, ls) {
+		t.Errorf("Expected %#v, got %#v", ls, back)
+	}
+}
+
+func TestOrbRingAndBound(t *testing.T) {
+	expected := &postgis.Polygon{Rings: square}
+	for _, g := range []orb.Geometry{orb.Ring{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}, orb.Bound{Min: orb.Point{0, 0}, Max: orb.Point{1, 1}}} {
+		converted, err := FromOrb(g)
+		if err != nil {
+			t.Fatalf("FromOrb(%T) failed: %v", g, err)
+		}
+		if !reflect.DeepEqual(converted, expected) {
+			t.Errorf("Expected %#v for %T, got %#v", expected, g, converted)
+		}
+	}
+}
+
+func TestOrbEmptyPoint(t *testing.T) {
+	converted, err := ToOrb(&postgis.Point{X: math.NaN(), Y: math.NaN()})
+	if err != nil {
+		t.Fatalf("ToOrb failed: %v", err)
+	}
+	back, err := FromOrb(converted)
+	if err != nil {
+		t.Fatalf("FromOrb failed: %v", err)
+	}
+	if p := back.(*postgis.Point); !math.IsNaN(p.X) || !math.IsNaN(p.Y) {
+		t.Errorf("Expected an empty point, got %v", p)
+	}
+}
+
+func TestOrbErrors(t *testing.T) {
+	for _, g := range []postgis.Geometry{
+		&postgis.PointZ{X: 1, Y: 2, Z: 3},
+		&postgis.LineStringMS{SRID: 4326},
+		&postgis.GeometryCollection{Geometries: []postgis.Geometry{&postgis.PointZM{}}},
+	} {
+		if _, err := ToOrb(g); !errors.Is(err, ErrMissingDimension) {
+			t.Errorf("Expected ErrMissingDimension for %T, got %v", g, err)
+		}
+	}
+	if _, err := ToOrb(&postgis.CircularString{}); !errors.Is(err, postgis.ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := FromOrb(orb.Collection{nil}); !errors.Is(err, postgis.ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType, got %v", err)
+	}
+}
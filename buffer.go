@@ -0,0 +1,357 @@
+package postgis
+
+import "math"
+
+// JoinStyle controls how the outside of a corner is filled when buffering a
+// LineString, matching the join parameter of ST_Buffer
+type JoinStyle int
+
+const (
+	JoinRound JoinStyle = iota
+	JoinMitre
+	JoinBevel
+)
+
+// EndCapStyle controls how the ends of a LineString (or a single Point) are
+// buffered, matching the endcap parameter of ST_Buffer
+type EndCapStyle int
+
+const (
+	CapRound EndCapStyle = iota
+	CapFlat
+	CapSquare
+)
+
+// Defaults used by ST_Buffer when a parameter is not given
+const (
+	DefaultQuadrantSegments = 8
+	DefaultMitreLimit       = 5.0
+)
+
+// BufferOptions holds the buffer style parameters. The zero value gives the
+// same result as ST_Buffer without a style string: round joins and caps with
+// 8 segments per quarter circle.
+type BufferOptions struct {
+	// QuadrantSegments is the number of segments used to approximate a
+	// quarter circle. Values below 1 use DefaultQuadrantSegments.
+	QuadrantSegments int
+	EndCap           EndCapStyle
+	Join             JoinStyle
+	// MitreLimit bounds how far a mitre join may extend, as a ratio of the
+	// buffer distance. Values of 0 or below use DefaultMitreLimit.
+	MitreLimit float64
+}
+
+func (o BufferOptions) quadrantSegments() int {
+	if o.QuadrantSegments < 1 {
+		return DefaultQuadrantSegments
+	}
+	return o.QuadrantSegments
+}
+
+func (o BufferOptions) mitreLimit() float64 {
+	if o.MitreLimit <= 0 {
+		return DefaultMitreLimit
+	}
+	return o.MitreLimit
+}
+
+/** Buffer functions **/
+
+// Buffers are computed in the plane from X and Y only: the Z and M variants
+// of each type have the same Buffer, whose result is a 2D polygon.
+
+// Buffer returns the planar buffer of the point. Like ST_Buffer, a distance
+// of zero or less, or a flat end cap, gives an empty polygon.
+func (p Point) Buffer(distance float64, opts BufferOptions) Polygon {
+	return Polygon{Rings: bufferPoint(p, distance, opts)}
+}
+
+func (p PointZ) Buffer(distance float64, opts BufferOptions) Polygon {
+	return Polygon{Rings: bufferPoint(p.xy(), distance, opts)}
+}
+
+func (p PointM) Buffer(distance float64, opts BufferOptions) Polygon {
+	return Polygon{Rings: bufferPoint(p.xy(), distance, opts)}
+}
+
+func (p PointZM) Buffer(distance float64, opts BufferOptions) Polygon {
+	return Polygon{Rings: bufferPoint(p.xy(), distance, opts)}
+}
+
+// Buffer returns the planar buffer of the point, keeping its SRID
+func (p PointS) Buffer(distance float64, opts BufferOptions) PolygonS {
+	return PolygonS{SRID: p.SRID, Rings: bufferPoint(p.xy(), distance, opts)}
+}
+
+func (p PointZS) Buffer(distance float64, opts BufferOptions) PolygonS {
+	return PolygonS{SRID: p.SRID, Rings: bufferPoint(p.xy(), distance, opts)}
+}
+
+func (p PointMS) Buffer(distance float64, opts BufferOptions) PolygonS {
+	return PolygonS{SRID: p.SRID, Rings: bufferPoint(p.xy(), distance, opts)}
+}
+
+func (p PointZMS) Buffer(distance float64, opts BufferOptions) PolygonS {
+	return PolygonS{SRID: p.SRID, Rings: bufferPoint(p.xy(), distance, opts)}
+}
+
+// Buffer returns the planar buffer of the line. Like ST_Buffer, a distance of
+// zero or less gives an empty polygon.
+func (ls LineStringOf[P]) Buffer(distance float64, opts BufferOptions) Polygon {
+	return Polygon{Rings: bufferLine(xyPoints(ls.Points), distance, opts)}
+}
+
+// Buffer returns the planar buffer of the line, keeping its SRID
+func (ls LineStringSOf[P]) Buffer(distance float64, opts BufferOptions) PolygonS {
+	return PolygonS{SRID: ls.SRID, Rings: bufferLine(xyPoints(ls.Points), distance, opts)}
+}
+
+// Buffer returns the planar buffer of the polygon: its exterior ring offset
+// outwards and its holes shrunk by distance. A negative distance shrinks the
+// polygon instead, which may split it into several polygons or leave none;
+// zero gives the polygon itself, with its rings traced anew. The end cap of
+// opts does not apply to rings.
+func (p PolygonOf[P]) Buffer(distance float64, opts BufferOptions) MultiPolygon {
+	return MultiPolygon{Polygons: bufferPolygon(xyRings(p.Rings), distance, opts)}
+}
+
+// Buffer returns the planar buffer of the polygon, keeping its SRID
+func (p PolygonSOf[P]) Buffer(distance float64, opts BufferOptions) MultiPolygonS {
+	return MultiPolygonS{SRID: p.SRID, Polygons: bufferPolygon(xyRings(p.Rings), distance, opts)}
+}
+
+func bufferPoint(p Point, distance float64, opts BufferOptions) [][]Point {
+	if distance <= 0 || math.IsNaN(distance) {
+		return nil
+	}
+	switch opts.EndCap {
+	case CapFlat:
+		return nil
+	case CapSquare:
+		return [][]Point{{
+			{X: p.X - distance, Y: p.Y - distance},
+			{X: p.X + distance, Y: p.Y - distance},
+			{X: p.X + distance, Y: p.Y + distance},
+			{X: p.X - distance, Y: p.Y + distance},
+			{X: p.X - distance, Y: p.Y - distance},
+		}}
+	default:
+		ring := arc(p, distance, 0, 2*math.Pi, opts.quadrantSegments())
+		ring[len(ring)-1] = ring[0]
+		return [][]Point{ring}
+	}
+}
+
+// bufferLine builds the buffer as the union of the pieces of linePieces
+func bufferLine(points []Point, distance float64, opts BufferOptions) [][]Point {
+	if distance <= 0 || math.IsNaN(distance) {
+		return nil
+	}
+
+	// A buffer with a positive distance is connected, so anything besides the
+	// largest polygon can only be a numerical sliver
+	var result [][]Point
+	var largest float64
+	for _, polygon := range unionRings(linePieces(points, distance, opts, false)) {
+		if area := ringArea(polygon.Rings[0]); area > largest {
+			result, largest = polygon.Rings, area
+		}
+	}
+	return result
+}
+
+// bufferPolygon adds to the polygon of rings, or removes from it for a
+// negative distance, the buffer of its rings. Rings are read with the
+// even-odd rule, so their orientation does not matter.
+func bufferPolygon(rings [][]Point, distance float64, opts BufferOptions) []Polygon {
+	if math.IsNaN(distance) {
+		return nil
+	}
+	var pieces [][]Point
+	if distance != 0 {
+		for _, ring := range rings {
+			pieces = append(pieces, linePieces(ring, math.Abs(distance), opts, true)...)
+		}
+	}
+
+	g := newPlanarGraph(rings, pieces)
+	g.addRings(0, rings)
+	g.addRings(1, pieces)
+	g.node()
+	g.buildEdges()
+	g.label()
+	return g.polygons(func(left [maxGraphSources]int) bool {
+		inside, near := fillEvenOdd.inside(left[0]), fillNonZero.inside(left[1])
+		if distance < 0 {
+			return inside && !near
+		}
+		return inside || near
+	})
+}
+
+// linePieces returns counter-clockwise pieces, one per segment, join and
+// cap, that together cover the buffer of a line. Every piece lies within the
+// buffer, so their union is exact and stays valid when the line crosses or
+// doubles back on itself. A closed line, a ring, gets a join at its first
+// point instead of end caps.
+func linePieces(points []Point, distance float64, opts BufferOptions, closed bool) [][]Point {
+	line := make([]Point, 0, len(points))
+	for _, p := range points {
+		if len(line) == 0 || line[len(line)-1] != p {
+			line = append(line, p)
+		}
+	}
+	if closed && len(line) > 1 && line[0] != line[len(line)-1] {
+		line = append(line, line[0])
+	}
+	switch len(line) {
+	case 0:
+		return nil
+	case 1:
+		return bufferPoint(line[0], distance, opts)
+	}
+
+	segments := opts.quadrantSegments()
+	var pieces [][]Point
+	for i := 0; i+1 < len(line); i++ {
+		a, b := line[i], line[i+1]
+		dir := unit(a, b)
+		if !closed && i == 0 && opts.EndCap == CapSquare {
+			a = translate(a, dir, -distance)
+		}
+		if !closed && i == len(line)-2 && opts.EndCap == CapSquare {
+			b = translate(b, dir, distance)
+		}
+		normal := Point{X: -dir.Y, Y: dir.X}
+		pieces = append(pieces, []Point{
+			translate(a, normal, -distance),
+			translate(b, normal, -distance),
+			translate(b, normal, distance),
+			translate(a, normal, distance),
+		})
+	}
+
+	if !closed && opts.EndCap == CapRound {
+		start, end := unit(line[0], line[1]), unit(line[len(line)-2], line[len(line)-1])
+		startAngle := math.Atan2(start.X, -start.Y)
+		endAngle := math.Atan2(-end.X, end.Y)
+		pieces = append(pieces,
+			arc(line[0], distance, startAngle, startAngle+math.Pi, segments),
+			arc(line[len(line)-1], distance, endAngle, endAngle+math.Pi, segments))
+	}
+
+	for i := 1; i+1 < len(line); i++ {
+		if join := bufferJoin(line[i-1], line[i], line[i+1], distance, opts); join != nil {
+			pieces = append(pieces, join)
+		}
+	}
+	if closed && len(line) > 2 {
+		if join := bufferJoin(line[len(line)-2], line[0], line[1], distance, opts); join != nil {
+			pieces = append(pieces, join)
+		}
+	}
+
+	for i, piece := range pieces {
+		if ringArea(append(piece, piece[0])) < 0 {
+			reversed := make([]Point, len(piece))
+			for j, p := range piece {
+				reversed[len(piece)-1-j] = p
+			}
+			pieces[i] = reversed
+		}
+	}
+	return pieces
+}
+
+// bufferJoin returns the piece filling the outside of the corner at v, or nil
+// when the line continues straight through it
+func bufferJoin(prev, v, next Point, distance float64, opts BufferOptions) []Point {
+	in, out := unit(prev, v), unit(v, next)
+	turn := in.X*out.Y - in.Y*out.X
+	dot := in.X*out.X + in.Y*out.Y
+	if turn == 0 && dot > 0 {
+		return nil
+	}
+
+	// The outside of the corner is on the right of a left turn and on the left
+	// of a right turn; a full reversal is treated as a right turn
+	side := 1.0
+	if turn > 0 {
+		side = -1
+	}
+	n1 := Point{X: -in.Y * side, Y: in.X * side}
+	n2 := Point{X: -out.Y * side, Y: out.X * side}
+	a, b := translate(v, n1, distance), translate(v, n2, distance)
+
+	switch opts.Join {
+	case JoinBevel:
+		return []Point{v, a, b}
+	case JoinMitre:
+		bisector := Point{X: n1.X + n2.X, Y: n1.Y + n2.Y}
+		if l := math.Hypot(bisector.X, bisector.Y); l > 1e-12 {
+			bisector = Point{X: bisector.X / l, Y: bisector.Y / l}
+		} else {
+			bisector = in
+		}
+		limit := opts.mitreLimit() * distance
+		reach := (a.X-v.X)*bisector.X + (a.Y-v.Y)*bisector.Y
+		if limit <= reach {
+			return []Point{v, a, b}
+		}
+		// Extend both offset lines until they meet or reach the limit
+		along := in.X*bisector.X + in.Y*bisector.Y
+		back := -(out.X*bisector.X + out.Y*bisector.Y)
+		if along > 0 && back > 0 {
+			ta, tb := (limit-reach)/along, (limit-reach)/back
+			meet := (a.X-b.X)*out.Y - (a.Y-b.Y)*out.X
+			if cross := in.X*out.Y - in.Y*out.X; cross != 0 {
+				if t := -meet / cross; t >= 0 && t <= ta {
+					return []Point{v, a, translate(a, in, t), b}
+				}
+			}
+			return []Point{v, a, translate(a, in, ta), translate(b, out, -tb), b}
+		}
+		return []Point{v, a, b}
+	default:
+		start := math.Atan2(n1.Y, n1.X)
+		sweep := math.Atan2(n2.Y, n2.X) - start
+		if side > 0 {
+			// Right turn: the normals rotate clockwise
+			for sweep > 0 {
+				sweep -= 2 * math.Pi
+			}
+		} else {
+			for sweep < 0 {
+				sweep += 2 * math.Pi
+			}
+		}
+		return append([]Point{v}, arc(v, distance, start, start+sweep, opts.quadrantSegments())...)
+	}
+}
+
+// arc approximates the circular arc around center from angle start to end
+// (radians, counter-clockwise when end > start) with both end points included
+func arc(center Point, radius, start, end float64, quadrantSegments int) []Point {
+	step := math.Pi / 2 / float64(quadrantSegments)
+	n := int(math.Ceil(math.Abs(end-start)/step - 1e-9))
+	if n < 1 {
+		n = 1
+	}
+	points := make([]Point, n+1)
+	for i := 0; i <= n; i++ {
+		angle := start + (end-start)*float64(i)/float64(n)
+		points[i] = Point{X: center.X + radius*math.Cos(angle), Y: center.Y + radius*math.Sin(angle)}
+	}
+	return points
+}
+
+// unit returns the unit vector pointing from a to b
+func unit(a, b Point) Point {
+	l := distance(a, b)
+	return Point{X: (b.X - a.X) / l, Y: (b.Y - a.Y) / l}
+}
+
+func translate(p, dir Point, distance float64) Point {
+	return Point{X: p.X + dir.X*distance, Y: p.Y + dir.Y*distance}
+}
@@ -0,0 +1,194 @@
+package postgis
+
+import (
+	"math"
+	"testing"
+)
+
+// polygonArea returns the area of a polygon built by Buffer, whose holes are
+// oriented opposite to the exterior ring
+func polygonArea(rings [][]Point) float64 {
+	var area float64
+	for _, ring := range rings {
+		area += ringArea(ring)
+	}
+	return area
+}
+
+func TestPointBuffer(t *testing.T) {
+	p := PointS{SRID: 3857, X: 10, Y: 20}
+
+	tests := []struct {
+		name     string
+		opts     BufferOptions
+		expected float64
+	}{
+		// 32-gon inscribed in the circle
+		{"round", BufferOptions{}, 16 * math.Sin(2*math.Pi/32) * 4},
+		{"square", BufferOptions{EndCap: CapSquare}, 16},
+		{"flat", BufferOptions{EndCap: CapFlat}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := p.Buffer(2, test.opts)
+			if buffer.SRID != 3857 {
+				t.Errorf("Expected SRID 3857, got %d", buffer.SRID)
+			}
+			if area := polygonArea(buffer.Rings); math.Abs(area-test.expected) > 1e-9 {
+				t.Errorf("Expected area %f, got %f", test.expected, area)
+			}
+		})
+	}
+
+	if buffer := p.Buffer(0, BufferOptions{}); len(buffer.Rings) != 0 {
+		t.Errorf("Expected empty buffer for zero distance, got %v", buffer.Rings)
+	}
+}
+
+func TestLineStringBuffer(t *testing.T) {
+	corner := LineStringS{SRID: 4326, Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}}
+
+	tests := []struct {
+		name     string
+		line     LineStringS
+		opts     BufferOptions
+		expected float64
+	}{
+		{"straight flat", LineStringS{Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}}}, BufferOptions{EndCap: CapFlat}, 20},
+		{"straight round", LineStringS{Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}}}, BufferOptions{}, 20 + 16*math.Sin(2*math.Pi/32)},
+		{"straight square", LineStringS{Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}}}, BufferOptions{EndCap: CapSquare}, 24},
+		{"mitre", corner, BufferOptions{EndCap: CapFlat, Join: JoinMitre}, 40},
+		{"bevel", corner, BufferOptions{EndCap: CapFlat, Join: JoinBevel}, 39.5},
+		{"limited mitre", corner, BufferOptions{EndCap: CapFlat, Join: JoinMitre, MitreLimit: 1}, 40 - 0.5*(2-math.Sqrt2)*(2-math.Sqrt2)},
+		// Doubling back onto itself: both halves overlap completely
+		{"reversal", LineStringS{Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 0}}}, BufferOptions{EndCap: CapFlat, Join: JoinBevel}, 20},
+		// Self crossing: four bars plus three mitred corners, overlapping at
+		// the three corners and in a 2x2 square where the path crosses itself
+		{"crossing", LineStringS{Points: []Point{{X: 0, Y: 5}, {X: 10, Y: 5}, {X: 10, Y: 20}, {X: 5, Y: 20}, {X: 5, Y: 0}}}, BufferOptions{EndCap: CapFlat, Join: JoinMitre}, 20 + 30 + 10 + 40 + 3 - 3 - 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := test.line.Buffer(1, test.opts)
+			if buffer.SRID != test.line.SRID {
+				t.Errorf("Expected SRID %d, got %d", test.line.SRID, buffer.SRID)
+			}
+			if area := polygonArea(buffer.Rings); math.Abs(area-test.expected) > 1e-9 {
+				t.Errorf("Expected area %f, got %f", test.expected, area)
+			}
+		})
+	}
+}
+
+func TestLineStringBufferHole(t *testing.T) {
+	// A closed square path leaves a hole in the middle of its buffer
+	ls := LineString{Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: 0}}}
+	buffer := ls.Buffer(1, BufferOptions{EndCap: CapSquare, Join: JoinMitre})
+
+	if len(buffer.Rings) != 2 {
+		t.Fatalf("Expected exterior ring and one hole, got %d rings", len(buffer.Rings))
+	}
+	if area := polygonArea(buffer.Rings); math.Abs(area-(144-64)) > 1e-9 {
+		t.Errorf("Expected area 80, got %f", area)
+	}
+
+	// The buffer must round trip through EWKB like any other polygon
+	value, err := buffer.Value()
+	if err != nil {
+		t.Fatalf("Polygon.Value() failed: %v", err)
+	}
+	var scanned Polygon
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Polygon.Scan() failed: %v", err)
+	}
+	if len(scanned.Rings) != 2 {
+		t.Errorf("Expected 2 rings after round trip, got %d", len(scanned.Rings))
+	}
+}
+
+func TestPolygonBuffer(t *testing.T) {
+	// A 10x10 square with a 4x4 hole in its middle
+	p := PolygonS{SRID: 3857, Rings: [][]Point{
+		{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: 0}},
+		{{X: 3, Y: 3}, {X: 3, Y: 7}, {X: 7, Y: 7}, {X: 7, Y: 3}, {X: 3, Y: 3}},
+	}}
+	corners := 16 * math.Sin(2*math.Pi/32)
+
+	tests := []struct {
+		name     string
+		distance float64
+		opts     BufferOptions
+		expected float64
+	}{
+		// The exterior grows to 12x12 and the hole shrinks to 2x2
+		{"mitre", 1, BufferOptions{Join: JoinMitre}, 144 - 4},
+		// Round corners on the exterior; the hole keeps sharp corners
+		{"round", 1, BufferOptions{}, 140 + corners - 4},
+		// The exterior shrinks to 8x8 and the hole grows to 6x6
+		{"negative mitre", -1, BufferOptions{Join: JoinMitre}, 64 - 36},
+		{"negative round", -1, BufferOptions{}, 64 - (36 - 4 + corners)},
+		{"zero", 0, BufferOptions{}, 100 - 16},
+		{"vanishing", -3, BufferOptions{}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := p.Buffer(test.distance, test.opts)
+			if buffer.SRID != 3857 {
+				t.Errorf("Expected SRID 3857, got %d", buffer.SRID)
+			}
+			var area float64
+			for _, polygon := range buffer.Polygons {
+				area += polygonArea(polygon.Rings)
+			}
+			if math.Abs(area-test.expected) > 1e-9 {
+				t.Errorf("Expected area %f, got %f", test.expected, area)
+			}
+		})
+	}
+}
+
+func TestPolygonBufferSplits(t *testing.T) {
+	// Two 4x4 squares joined by a corridor 1 wide, which a negative buffer
+	// of 1 removes
+	dumbbell := Polygon{Rings: [][]Point{{
+		{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 1.5}, {X: 8, Y: 1.5}, {X: 8, Y: 0}, {X: 12, Y: 0},
+		{X: 12, Y: 4}, {X: 8, Y: 4}, {X: 8, Y: 2.5}, {X: 4, Y: 2.5}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0},
+	}}}
+	buffer := dumbbell.Buffer(-1, BufferOptions{})
+	if len(buffer.Polygons) != 2 {
+		t.Fatalf("Expected 2 polygons, got %d", len(buffer.Polygons))
+	}
+	for _, polygon := range buffer.Polygons {
+		// A 2x2 square, slightly widened towards the corridor
+		if area := polygonArea(polygon.Rings); area < 4 || area > 4.1 {
+			t.Errorf("Expected an area of about 4, got %f", area)
+		}
+	}
+	if err := ValidateDetail(&MultiPolygon{Polygons: buffer.Polygons}).Err(); err != nil {
+		t.Errorf("Expected a valid buffer, got %v", err)
+	}
+}
+
+func TestBufferIgnoresZM(t *testing.T) {
+	xy := Point{X: 1, Y: 2}.Buffer(1, BufferOptions{})
+	for _, buffer := range []Polygon{
+		PointZ{X: 1, Y: 2, Z: 3}.Buffer(1, BufferOptions{}),
+		PointM{X: 1, Y: 2, M: 3}.Buffer(1, BufferOptions{}),
+		PointZM{X: 1, Y: 2, Z: 3, M: 4}.Buffer(1, BufferOptions{}),
+	} {
+		if polygonArea(buffer.Rings) != polygonArea(xy.Rings) {
+			t.Errorf("Expected the buffer of the XY point, got %v", buffer.Rings)
+		}
+	}
+	if buffer := (PointZMS{SRID: 4326, X: 1, Y: 2}).Buffer(1, BufferOptions{}); buffer.SRID != 4326 || len(buffer.Rings) != 1 {
+		t.Errorf("Expected a buffer with SRID 4326, got %v", buffer)
+	}
+
+	square := PolygonZ{Rings: [][]PointZ{{{X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: 0, Y: 1, Z: 1}, {X: 0, Y: 0, Z: 1}}}}
+	buffer := square.Buffer(1, BufferOptions{Join: JoinMitre})
+	if len(buffer.Polygons) != 1 || polygonArea(buffer.Polygons[0].Rings) != 9 {
+		t.Errorf("Expected a 3x3 square, got %v", buffer.Polygons)
+	}
+}
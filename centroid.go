@@ -0,0 +1,394 @@
+package postgis
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// emptyPoint is the point returned for empty input. PostGIS encodes POINT
+// EMPTY as a point with NaN coordinates.
+var emptyPoint = Point{X: math.NaN(), Y: math.NaN()}
+
+// Centroid returns the length-weighted center of the line, like ST_Centroid.
+// A line of zero length gives the average of its points and an empty line a
+// point with NaN coordinates. Only X and Y are used.
+func (ls LineStringOf[P]) Centroid() Point {
+	return linesCentroid([][]Point{xyPoints(ls.Points)})
+}
+
+// Centroid returns the length-weighted center of the line, keeping its SRID
+func (ls LineStringSOf[P]) Centroid() PointS {
+	return pointWithSRID(linesCentroid([][]Point{xyPoints(ls.Points)}), ls.SRID)
+}
+
+// Centroid returns the length-weighted center of all lines, like ST_Centroid
+func (m MultiLineStringOf[P]) Centroid() Point {
+	return linesCentroid(lineStringParts(m.LineStrings))
+}
+
+// Centroid returns the length-weighted center of all lines, keeping the SRID
+func (m MultiLineStringSOf[P]) Centroid() PointS {
+	return pointWithSRID(linesCentroid(lineStringParts(m.LineStrings)), m.SRID)
+}
+
+// Centroid returns the center of mass of the polygon, like ST_Centroid. Holes
+// are subtracted whatever their orientation. A polygon of zero area falls
+// back to the centroid of its rings.
+func (p PolygonOf[P]) Centroid() Point {
+	return polygonsCentroid([][][]Point{xyRings(p.Rings)})
+}
+
+// Centroid returns the center of mass of the polygon, keeping its SRID
+func (p PolygonSOf[P]) Centroid() PointS {
+	return pointWithSRID(polygonsCentroid([][][]Point{xyRings(p.Rings)}), p.SRID)
+}
+
+// Centroid returns the center of mass of all polygons, like ST_Centroid
+func (m MultiPolygonOf[P]) Centroid() Point {
+	return polygonsCentroid(multiPolygonRings(m.Polygons))
+}
+
+// Centroid returns the center of mass of all polygons, keeping the SRID
+func (m MultiPolygonSOf[P]) Centroid() PointS {
+	return pointWithSRID(polygonsCentroid(multiPolygonRings(m.Polygons)), m.SRID)
+}
+
+// PointOnSurface returns a point on the line, like ST_PointOnSurface: the
+// interior vertex closest to the centroid, or the closest end point when the
+// line has no interior vertex
+func (ls LineStringOf[P]) PointOnSurface() Point {
+	return linesPointOnSurface([][]Point{xyPoints(ls.Points)})
+}
+
+// PointOnSurface returns a point on the line, keeping its SRID
+func (ls LineStringSOf[P]) PointOnSurface() PointS {
+	return pointWithSRID(linesPointOnSurface([][]Point{xyPoints(ls.Points)}), ls.SRID)
+}
+
+// PointOnSurface returns a point on one of the lines, like ST_PointOnSurface
+func (m MultiLineStringOf[P]) PointOnSurface() Point {
+	return linesPointOnSurface(lineStringParts(m.LineStrings))
+}
+
+// PointOnSurface returns a point on one of the lines, keeping the SRID
+func (m MultiLineStringSOf[P]) PointOnSurface() PointS {
+	return pointWithSRID(linesPointOnSurface(lineStringParts(m.LineStrings)), m.SRID)
+}
+
+// PointOnSurface returns a point guaranteed to lie in the interior of the
+// polygon, like ST_PointOnSurface. It is the middle of the widest section
+// of a horizontal line through the middle of the polygon, which is cheap to
+// compute but may lie close to the boundary; see Polylabel for a point
+// suited to labels.
+func (p PolygonOf[P]) PointOnSurface() Point {
+	return polygonsPointOnSurface([][][]Point{xyRings(p.Rings)})
+}
+
+// PointOnSurface returns a point in the interior of the polygon, keeping its
+// SRID
+func (p PolygonSOf[P]) PointOnSurface() PointS {
+	return pointWithSRID(polygonsPointOnSurface([][][]Point{xyRings(p.Rings)}), p.SRID)
+}
+
+// PointOnSurface returns a point in the interior of one of the polygons, like
+// ST_PointOnSurface
+func (m MultiPolygonOf[P]) PointOnSurface() Point {
+	return polygonsPointOnSurface(multiPolygonRings(m.Polygons))
+}
+
+// PointOnSurface returns a point in the interior of one of the polygons,
+// keeping the SRID
+func (m MultiPolygonSOf[P]) PointOnSurface() PointS {
+	return pointWithSRID(polygonsPointOnSurface(multiPolygonRings(m.Polygons)), m.SRID)
+}
+
+// Polylabel returns the pole of inaccessibility of the polygon: the interior
+// point farthest from its boundary, which is the center of
+// ST_MaximumInscribedCircle. The search stops when the result is known to
+// be within precision of the optimum; a precision of zero or less uses 1%
+// of the smaller side of the bounding box.
+func (p PolygonOf[P]) Polylabel(precision float64) Point {
+	return polylabel(xyRings(p.Rings), precision)
+}
+
+// Polylabel returns the pole of inaccessibility of the polygon, keeping its
+// SRID
+func (p PolygonSOf[P]) Polylabel(precision float64) PointS {
+	return pointWithSRID(polylabel(xyRings(p.Rings), precision), p.SRID)
+}
+
+// Polylabel returns the point farthest from the boundary among all polygons
+func (m MultiPolygonOf[P]) Polylabel(precision float64) Point {
+	return polylabel(polygonParts(m.Polygons), precision)
+}
+
+// Polylabel returns the point farthest from the boundary among all polygons,
+// keeping the SRID
+func (m MultiPolygonSOf[P]) Polylabel(precision float64) PointS {
+	return pointWithSRID(polylabel(polygonParts(m.Polygons), precision), m.SRID)
+}
+
+func pointWithSRID(p Point, srid int32) PointS {
+	return PointS{SRID: srid, X: p.X, Y: p.Y}
+}
+
+func multiPolygonRings[P PointLike](polygons []PolygonOf[P]) [][][]Point {
+	rings := make([][][]Point, len(polygons))
+	for i, p := range polygons {
+		rings[i] = xyRings(p.Rings)
+	}
+	return rings
+}
+
+func linesCentroid(lines [][]Point) Point {
+	var sx, sy, total float64
+	for _, line := range lines {
+		for i := 0; i+1 < len(line); i++ {
+			l := distance(line[i], line[i+1])
+			sx += l * (line[i].X + line[i+1].X) / 2
+			sy += l * (line[i].Y + line[i+1].Y) / 2
+			total += l
+		}
+	}
+	if total > 0 {
+		return Point{X: sx / total, Y: sy / total}
+	}
+	return pointsCentroid(lines)
+}
+
+func pointsCentroid(lines [][]Point) Point {
+	var sx, sy float64
+	var n int
+	for _, line := range lines {
+		for _, p := range line {
+			sx += p.X
+			sy += p.Y
+			n++
+		}
+	}
+	if n == 0 {
+		return emptyPoint
+	}
+	return Point{X: sx / float64(n), Y: sy / float64(n)}
+}
+
+func polygonsCentroid(polygons [][][]Point) Point {
+	var sx, sy, total float64
+	var rings [][]Point
+	for _, polygon := range polygons {
+		for i, ring := range polygon {
+			if len(ring) < 3 {
+				continue
+			}
+			rings = append(rings, ring)
+			// Triangle fan around the first vertex, whose signed area makes
+			// the result independent of the ring orientation
+			var area, cx, cy float64
+			o := ring[0]
+			for j := 1; j+1 < len(ring); j++ {
+				a, b := ring[j], ring[j+1]
+				cross := (a.X-o.X)*(b.Y-o.Y) - (b.X-o.X)*(a.Y-o.Y)
+				area += cross
+				cx += cross * (a.X + b.X - 2*o.X)
+				cy += cross * (a.Y + b.Y - 2*o.Y)
+			}
+			if area == 0 {
+				continue
+			}
+			cx, cy = o.X+cx/(3*area), o.Y+cy/(3*area)
+			weight := math.Abs(area)
+			if i > 0 {
+				weight = -weight
+			}
+			sx += weight * cx
+			sy += weight * cy
+			total += weight
+		}
+	}
+	if total != 0 {
+		return Point{X: sx / total, Y: sy / total}
+	}
+	return linesCentroid(rings)
+}
+
+func linesPointOnSurface(lines [][]Point) Point {
+	c := linesCentroid(lines)
+	best, bestDistance := emptyPoint, math.Inf(1)
+	consider := func(p Point) {
+		if d := distance(p, c); d < bestDistance {
+			best, bestDistance = p, d
+		}
+	}
+	for _, line := range lines {
+		for i := 1; i+1 < len(line); i++ {
+			consider(line[i])
+		}
+	}
+	if !math.IsInf(bestDistance, 1) {
+		return best
+	}
+	for _, line := range lines {
+		if len(line) > 0 {
+			consider(line[0])
+			consider(line[len(line)-1])
+		}
+	}
+	return best
+}
+
+func polygonsPointOnSurface(polygons [][][]Point) Point {
+	best, bestWidth := emptyPoint, -1.0
+	var rings [][]Point
+	for _, polygon := range polygons {
+		if len(polygon) == 0 || len(polygon[0]) == 0 {
+			continue
+		}
+		rings = append(rings, polygon...)
+		y := scanLineY(polygon[0])
+
+		var xs []float64
+		for _, ring := range polygon {
+			for i := 0; i+1 < len(ring); i++ {
+				a, b := ring[i], ring[i+1]
+				if (a.Y > y) != (b.Y > y) {
+					xs = append(xs, a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y))
+				}
+			}
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			if width := xs[i+1] - xs[i]; width > bestWidth {
+				best, bestWidth = Point{X: (xs[i] + xs[i+1]) / 2, Y: y}, width
+			}
+		}
+	}
+	if bestWidth > 0 {
+		return best
+	}
+	// Polygons of zero area are treated as their rings
+	return linesPointOnSurface(rings)
+}
+
+// scanLineY returns a Y ordinate close to the middle of the ring that does not
+// pass through any of its vertices, halfway between the nearest vertices
+// below and above the middle
+func scanLineY(ring []Point) float64 {
+	minY, maxY := ring[0].Y, ring[0].Y
+	for _, p := range ring {
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	centre := (minY + maxY) / 2
+	lo, hi := minY, maxY
+	for _, p := range ring {
+		if p.Y <= centre && p.Y > lo {
+			lo = p.Y
+		} else if p.Y > centre && p.Y < hi {
+			hi = p.Y
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// polylabelCell is a square of the search grid, with the signed distance from
+// its center to the boundary (positive inside) and the largest distance any
+// point of the cell can have
+type polylabelCell struct {
+	center   Point
+	half     float64
+	distance float64
+	max      float64
+}
+
+type polylabelQueue []polylabelCell
+
+func (q polylabelQueue) Len() int           { return len(q) }
+func (q polylabelQueue) Less(i, j int) bool { return q[i].max > q[j].max }
+func (q polylabelQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *polylabelQueue) Push(x any)        { *q = append(*q, x.(polylabelCell)) }
+func (q *polylabelQueue) Pop() any {
+	old := *q
+	cell := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return cell
+}
+
+// polylabel finds the pole of inaccessibility with the quadtree search of
+// Mapbox's polylabel. Rings are combined with the even-odd rule, so the rings
+// of several polygons can be searched at once.
+func polylabel(rings [][]Point, precision float64) Point {
+	var points int
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, ring := range rings {
+		for _, p := range ring {
+			minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+			maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+			points++
+		}
+	}
+	if points == 0 {
+		return emptyPoint
+	}
+	size := math.Min(maxX-minX, maxY-minY)
+	if size == 0 {
+		return Point{X: minX, Y: minY}
+	}
+	if precision <= 0 {
+		precision = size / 100
+	}
+
+	newCell := func(center Point, half float64) polylabelCell {
+		d := signedRingsDistance(center, rings)
+		return polylabelCell{center: center, half: half, distance: d, max: d + half*math.Sqrt2}
+	}
+
+	queue := &polylabelQueue{}
+	half := size / 2
+	for x := minX; x < maxX; x += size {
+		for y := minY; y < maxY; y += size {
+			heap.Push(queue, newCell(Point{X: x + half, Y: y + half}, half))
+		}
+	}
+
+	// Start from the centroid, which is a good guess for compact shapes
+	best := newCell(polygonsCentroid([][][]Point{rings}), 0)
+	if bbox := newCell(Point{X: (minX + maxX) / 2, Y: (minY + maxY) / 2}, 0); bbox.distance > best.distance {
+		best = bbox
+	}
+
+	for queue.Len() > 0 {
+		cell := heap.Pop(queue).(polylabelCell)
+		if cell.distance > best.distance {
+			best = cell
+		}
+		if cell.max-best.distance <= precision {
+			continue
+		}
+		h := cell.half / 2
+		for _, d := range []Point{{X: -h, Y: -h}, {X: h, Y: -h}, {X: -h, Y: h}, {X: h, Y: h}} {
+			heap.Push(queue, newCell(Point{X: cell.center.X + d.X, Y: cell.center.Y + d.Y}, h))
+		}
+	}
+	return best.center
+}
+
+// signedRingsDistance returns the distance from p to the nearest ring,
+// negative when p lies outside the area the rings enclose
+func signedRingsDistance(p Point, rings [][]Point) float64 {
+	inside := false
+	d := math.Inf(1)
+	for _, ring := range rings {
+		for i := 0; i+1 < len(ring); i++ {
+			a, b := ring[i], ring[i+1]
+			if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+				inside = !inside
+			}
+			d = math.Min(d, segmentDistance(p, a, b))
+		}
+	}
+	if inside {
+		return d
+	}
+	return -d
+}
@@ -0,0 +1,107 @@
+package postgis
+
+import (
+	"math"
+	"testing"
+)
+
+func closeTo(a, b Point) bool {
+	return math.Abs(a.X-b.X) < 1e-9 && math.Abs(a.Y-b.Y) < 1e-9
+}
+
+func TestCentroid(t *testing.T) {
+	tests := []struct {
+		name     string
+		centroid Point
+		expected Point
+	}{
+		{"LineString", LineString{Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}}.Centroid(), Point{X: 7.5, Y: 2.5}},
+		{"LineString zero length", LineString{Points: []Point{{X: 1, Y: 2}, {X: 1, Y: 2}}}.Centroid(), Point{X: 1, Y: 2}},
+		{"MultiLineString", MultiLineString{LineStrings: []LineString{
+			{Points: []Point{{X: 0, Y: 0}, {X: 2, Y: 0}}},
+			{Points: []Point{{X: 0, Y: 4}, {X: 6, Y: 4}}},
+		}}.Centroid(), Point{X: 2.5, Y: 3}},
+		{"Polygon", Polygon{Rings: [][]Point{square(0, 0, 4)}}.Centroid(), Point{X: 2, Y: 2}},
+		{"Polygon with hole", Polygon{Rings: [][]Point{square(0, 0, 4), square(0, 0, 2)}}.Centroid(), Point{X: 7.0 / 3, Y: 7.0 / 3}},
+		{"MultiPolygon", MultiPolygon{Polygons: []Polygon{
+			{Rings: [][]Point{square(0, 0, 2)}},
+			{Rings: [][]Point{square(4, 0, 2)}},
+		}}.Centroid(), Point{X: 3, Y: 1}},
+		{"Polygon zero area", Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 0, Y: 0}}}}.Centroid(), Point{X: 2, Y: 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !closeTo(test.centroid, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, test.centroid)
+			}
+		})
+	}
+
+	centroid := PolygonS{SRID: 4326, Rings: [][]Point{square(0, 0, 2)}}.Centroid()
+	if centroid.SRID != 4326 {
+		t.Errorf("Expected SRID 4326, got %d", centroid.SRID)
+	}
+	if empty := (LineString{}).Centroid(); !math.IsNaN(empty.X) || !math.IsNaN(empty.Y) {
+		t.Errorf("Expected NaN coordinates for an empty line, got %v", empty)
+	}
+}
+
+// uShape is a polygon whose centroid lies outside of it
+var uShape = [][]Point{{
+	{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 8, Y: 10}, {X: 8, Y: 2},
+	{X: 2, Y: 2}, {X: 2, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: 0},
+}}
+
+func TestPointOnSurface(t *testing.T) {
+	u := PolygonS{SRID: 3857, Rings: uShape}
+	if pointInRing(u.Centroid().xy(), uShape[0]) != -1 {
+		t.Fatalf("Expected the centroid to lie outside the U shape")
+	}
+
+	p := u.PointOnSurface()
+	if p.SRID != 3857 {
+		t.Errorf("Expected SRID 3857, got %d", p.SRID)
+	}
+	if pointInRing(p.xy(), uShape[0]) != 1 {
+		t.Errorf("Expected a point inside the polygon, got %v", p)
+	}
+
+	withHole := Polygon{Rings: [][]Point{square(0, 0, 10), square(2, 2, 6)}}
+	if p := withHole.PointOnSurface(); pointInRing(p, withHole.Rings[0]) != 1 || pointInRing(p, withHole.Rings[1]) != -1 {
+		t.Errorf("Expected a point outside the hole, got %v", p)
+	}
+
+	line := LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 5}, {X: 5, Y: 5}, {X: 10, Y: 0}}}
+	if p := line.PointOnSurface(); p != (Point{X: 5, Y: 5}) {
+		t.Errorf("Expected the interior vertex closest to the centroid, got %v", p)
+	}
+	segment := LineString{Points: []Point{{X: 0, Y: 0}, {X: 10, Y: 0}}}
+	if p := segment.PointOnSurface(); p != (Point{X: 0, Y: 0}) {
+		t.Errorf("Expected an end point, got %v", p)
+	}
+}
+
+func TestPolylabel(t *testing.T) {
+	u := Polygon{Rings: uShape}
+	p := u.Polylabel(0.01)
+	// The widest part of the U is its 2 unit thick base and arms, so the
+	// pole lies 1 unit from the boundary
+	if d := signedRingsDistance(p, uShape); d < 1-0.01 {
+		t.Errorf("Expected a point 1 unit inside, got %v at distance %f", p, d)
+	}
+
+	s := PolygonS{SRID: 4326, Rings: [][]Point{square(0, 0, 10), square(1, 1, 2)}}
+	label := s.Polylabel(0)
+	if label.SRID != 4326 {
+		t.Errorf("Expected SRID 4326, got %d", label.SRID)
+	}
+	if d := signedRingsDistance(label.xy(), s.Rings); d < 3.5-0.1 {
+		t.Errorf("Expected a point far from the hole, got %v at distance %f", label, d)
+	}
+
+	m := MultiPolygon{Polygons: []Polygon{{Rings: [][]Point{square(0, 0, 2)}}, {Rings: [][]Point{square(10, 10, 6)}}}}
+	if p := m.Polylabel(0.01); !closeTo(p, Point{X: 13, Y: 13}) && distance(p, Point{X: 13, Y: 13}) > 0.02 {
+		t.Errorf("Expected the center of the larger polygon, got %v", p)
+	}
+}
@@ -0,0 +1,108 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// CircularStringOf is a CircularString whose coordinate type P sets its
+// dimensions. Its points are a chain of circular arcs, each defined by a
+// start, a point on the arc and an end which starts the next arc, so a valid
+// CircularString has an odd number of at least three points. An arc whose
+// start and end are the same point is a full circle through the middle point.
+type CircularStringOf[P PointLike] struct {
+	Points []P
+}
+
+// CircularStringSOf is a CircularString with an SRID
+type CircularStringSOf[P PointLike] struct {
+	SRID   int32
+	Points []P
+}
+
+// Varying types of CircularStrings
+type (
+	CircularString   = CircularStringOf[Point]
+	CircularStringZ  = CircularStringOf[PointZ]
+	CircularStringM  = CircularStringOf[PointM]
+	CircularStringZM = CircularStringOf[PointZM]
+
+	CircularStringS   = CircularStringSOf[Point]
+	CircularStringZS  = CircularStringSOf[PointZ]
+	CircularStringMS  = CircularStringSOf[PointM]
+	CircularStringZMS = CircularStringSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (cs *CircularStringSOf[P]) GetSRID() int32     { return cs.SRID }
+func (cs *CircularStringSOf[P]) SetSRID(srid int32) { cs.SRID = srid }
+
+// Implement CollectionGeometry interface for all CircularString types
+func (cs *CircularStringOf[P]) GetElementCount() uint32  { return getElementCountHelper(cs.Points) }
+func (cs *CircularStringSOf[P]) GetElementCount() uint32 { return getElementCountHelper(cs.Points) }
+
+func (cs *CircularStringOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeElementsHelper(cs.Points, buffer)
+}
+
+func (cs *CircularStringSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeElementsHelper(cs.Points, buffer)
+}
+
+func (cs *CircularStringOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	points, err := readElementsHelper[P](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	cs.Points = points
+	return nil
+}
+
+func (cs *CircularStringSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	points, err := readElementsHelper[P](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	cs.Points = points
+	return nil
+}
+
+/** CircularStringOf functions **/
+func (cs *CircularStringOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(cs, value)
+}
+
+func (cs CircularStringOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&cs)
+}
+
+func (cs CircularStringOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, cs.GetElementCount(), func(buf *bytes.Buffer) error {
+		return cs.WriteElements(buf)
+	})
+}
+
+func (cs CircularStringOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBCircularString, coordinateType[P](), false)
+}
+
+/** CircularStringSOf functions **/
+func (cs *CircularStringSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(cs, value)
+}
+
+func (cs CircularStringSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&cs)
+}
+
+func (cs CircularStringSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, cs.GetElementCount(), func(buf *bytes.Buffer) error {
+		return cs.WriteElements(buf)
+	})
+}
+
+func (cs CircularStringSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBCircularString, coordinateType[P](), true)
+}
@@ -0,0 +1,325 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrMixedDimensions is returned when encoding or decoding a
+// GeometryCollection whose members do not all have the same dimensions,
+// which PostGIS refuses
+var ErrMixedDimensions = errors.New("mixed dimension geometries in collection")
+
+// Structs representing GeometryCollections. Members are stored as pointers
+// (e.g. *Point, *LineStringZ) and their coordinate dimensions determine the
+// dimensions of the collection.
+type GeometryCollection struct {
+	Geometries []Geometry
+}
+
+type GeometryCollectionS struct {
+	SRID       int32
+	Geometries []Geometry
+}
+
+// geometryFactories creates an empty geometry for every supported WKB type code
+var geometryFactories = map[uint32]func() Geometry{
+	BuildWKBType(WKBPoint, CoordXY, false):   func() Geometry { return &Point{} },
+	BuildWKBType(WKBPoint, CoordXYZ, false):  func() Geometry { return &PointZ{} },
+	BuildWKBType(WKBPoint, CoordXYM, false):  func() Geometry { return &PointM{} },
+	BuildWKBType(WKBPoint, CoordXYZM, false): func() Geometry { return &PointZM{} },
+	BuildWKBType(WKBPoint, CoordXY, true):    func() Geometry { return &PointS{} },
+	BuildWKBType(WKBPoint, CoordXYZ, true):   func() Geometry { return &PointZS{} },
+	BuildWKBType(WKBPoint, CoordXYM, true):   func() Geometry { return &PointMS{} },
+	BuildWKBType(WKBPoint, CoordXYZM, true):  func() Geometry { return &PointZMS{} },
+
+	BuildWKBType(WKBLineString, CoordXY, false):   func() Geometry { return &LineString{} },
+	BuildWKBType(WKBLineString, CoordXYZ, false):  func() Geometry { return &LineStringZ{} },
+	BuildWKBType(WKBLineString, CoordXYM, false):  func() Geometry { return &LineStringM{} },
+	BuildWKBType(WKBLineString, CoordXYZM, false): func() Geometry { return &LineStringZM{} },
+	BuildWKBType(WKBLineString, CoordXY, true):    func() Geometry { return &LineStringS{} },
+	BuildWKBType(WKBLineString, CoordXYZ, true):   func() Geometry { return &LineStringZS{} },
+	BuildWKBType(WKBLineString, CoordXYM, true):   func() Geometry { return &LineStringMS{} },
+	BuildWKBType(WKBLineString, CoordXYZM, true):  func() Geometry { return &LineStringZMS{} },
+
+	BuildWKBType(WKBPolygon, CoordXY, false):   func() Geometry { return &Polygon{} },
+	BuildWKBType(WKBPolygon, CoordXYZ, false):  func() Geometry { return &PolygonZ{} },
+	BuildWKBType(WKBPolygon, CoordXYM, false):  func() Geometry { return &PolygonM{} },
+	BuildWKBType(WKBPolygon, CoordXYZM, false): func() Geometry { return &PolygonZM{} },
+	BuildWKBType(WKBPolygon, CoordXY, true):    func() Geometry { return &PolygonS{} },
+	BuildWKBType(WKBPolygon, CoordXYZ, true):   func() Geometry { return &PolygonZS{} },
+	BuildWKBType(WKBPolygon, CoordXYM, true):   func() Geometry { return &PolygonMS{} },
+	BuildWKBType(WKBPolygon, CoordXYZM, true):  func() Geometry { return &PolygonZMS{} },
+
+	BuildWKBType(WKBMultiPoint, CoordXY, false):   func() Geometry { return &MultiPoint{} },
+	BuildWKBType(WKBMultiPoint, CoordXYZ, false):  func() Geometry { return &MultiPointZ{} },
+	BuildWKBType(WKBMultiPoint, CoordXYM, false):  func() Geometry { return &MultiPointM{} },
+	BuildWKBType(WKBMultiPoint, CoordXYZM, false): func() Geometry { return &MultiPointZM{} },
+	BuildWKBType(WKBMultiPoint, CoordXY, true):    func() Geometry { return &MultiPointS{} },
+	BuildWKBType(WKBMultiPoint, CoordXYZ, true):   func() Geometry { return &MultiPointZS{} },
+	BuildWKBType(WKBMultiPoint, CoordXYM, true):   func() Geometry { return &MultiPointMS{} },
+	BuildWKBType(WKBMultiPoint, CoordXYZM, true):  func() Geometry { return &MultiPointZMS{} },
+
+	BuildWKBType(WKBMultiLineString, CoordXY, false):   func() Geometry { return &MultiLineString{} },
+	BuildWKBType(WKBMultiLineString, CoordXYZ, false):  func() Geometry { return &MultiLineStringZ{} },
+	BuildWKBType(WKBMultiLineString, CoordXYM, false):  func() Geometry { return &MultiLineStringM{} },
+	BuildWKBType(WKBMultiLineString, CoordXYZM, false): func() Geometry { return &MultiLineStringZM{} },
+	BuildWKBType(WKBMultiLineString, CoordXY, true):    func() Geometry { return &MultiLineStringS{} },
+	BuildWKBType(WKBMultiLineString, CoordXYZ, true):   func() Geometry { return &MultiLineStringZS{} },
+	BuildWKBType(WKBMultiLineString, CoordXYM, true):   func() Geometry { return &MultiLineStringMS{} },
+	BuildWKBType(WKBMultiLineString, CoordXYZM, true):  func() Geometry { return &MultiLineStringZMS{} },
+
+	BuildWKBType(WKBMultiPolygon, CoordXY, false):   func() Geometry { return &MultiPolygon{} },
+	BuildWKBType(WKBMultiPolygon, CoordXYZ, false):  func() Geometry { return &MultiPolygonZ{} },
+	BuildWKBType(WKBMultiPolygon, CoordXYM, false):  func() Geometry { return &MultiPolygonM{} },
+	BuildWKBType(WKBMultiPolygon, CoordXYZM, false): func() Geometry { return &MultiPolygonZM{} },
+	BuildWKBType(WKBMultiPolygon, CoordXY, true):    func() Geometry { return &MultiPolygonS{} },
+	BuildWKBType(WKBMultiPolygon, CoordXYZ, true):   func() Geometry { return &MultiPolygonZS{} },
+	BuildWKBType(WKBMultiPolygon, CoordXYM, true):   func() Geometry { return &MultiPolygonMS{} },
+	BuildWKBType(WKBMultiPolygon, CoordXYZM, true):  func() Geometry { return &MultiPolygonZMS{} },
+
+	BuildWKBType(WKBCircularString, CoordXY, false):   func() Geometry { return &CircularString{} },
+	BuildWKBType(WKBCircularString, CoordXYZ, false):  func() Geometry { return &CircularStringZ{} },
+	BuildWKBType(WKBCircularString, CoordXYM, false):  func() Geometry { return &CircularStringM{} },
+	BuildWKBType(WKBCircularString, CoordXYZM, false): func() Geometry { return &CircularStringZM{} },
+	BuildWKBType(WKBCircularString, CoordXY, true):    func() Geometry { return &CircularStringS{} },
+	BuildWKBType(WKBCircularString, CoordXYZ, true):   func() Geometry { return &CircularStringZS{} },
+	BuildWKBType(WKBCircularString, CoordXYM, true):   func() Geometry { return &CircularStringMS{} },
+	BuildWKBType(WKBCircularString, CoordXYZM, true):  func() Geometry { return &CircularStringZMS{} },
+
+	BuildWKBType(WKBCompoundCurve, CoordXY, false):   func() Geometry { return &CompoundCurve{} },
+	BuildWKBType(WKBCompoundCurve, CoordXYZ, false):  func() Geometry { return &CompoundCurveZ{} },
+	BuildWKBType(WKBCompoundCurve, CoordXYM, false):  func() Geometry { return &CompoundCurveM{} },
+	BuildWKBType(WKBCompoundCurve, CoordXYZM, false): func() Geometry { return &CompoundCurveZM{} },
+	BuildWKBType(WKBCompoundCurve, CoordXY, true):    func() Geometry { return &CompoundCurveS{} },
+	BuildWKBType(WKBCompoundCurve, CoordXYZ, true):   func() Geometry { return &CompoundCurveZS{} },
+	BuildWKBType(WKBCompoundCurve, CoordXYM, true):   func() Geometry { return &CompoundCurveMS{} },
+	BuildWKBType(WKBCompoundCurve, CoordXYZM, true):  func() Geometry { return &CompoundCurveZMS{} },
+
+	BuildWKBType(WKBCurvePolygon, CoordXY, false):   func() Geometry { return &CurvePolygon{} },
+	BuildWKBType(WKBCurvePolygon, CoordXYZ, false):  func() Geometry { return &CurvePolygonZ{} },
+	BuildWKBType(WKBCurvePolygon, CoordXYM, false):  func() Geometry { return &CurvePolygonM{} },
+	BuildWKBType(WKBCurvePolygon, CoordXYZM, false): func() Geometry { return &CurvePolygonZM{} },
+	BuildWKBType(WKBCurvePolygon, CoordXY, true):    func() Geometry { return &CurvePolygonS{} },
+	BuildWKBType(WKBCurvePolygon, CoordXYZ, true):   func() Geometry { return &CurvePolygonZS{} },
+	BuildWKBType(WKBCurvePolygon, CoordXYM, true):   func() Geometry { return &CurvePolygonMS{} },
+	BuildWKBType(WKBCurvePolygon, CoordXYZM, true):  func() Geometry { return &CurvePolygonZMS{} },
+
+	BuildWKBType(WKBMultiCurve, CoordXY, false):   func() Geometry { return &MultiCurve{} },
+	BuildWKBType(WKBMultiCurve, CoordXYZ, false):  func() Geometry { return &MultiCurveZ{} },
+	BuildWKBType(WKBMultiCurve, CoordXYM, false):  func() Geometry { return &MultiCurveM{} },
+	BuildWKBType(WKBMultiCurve, CoordXYZM, false): func() Geometry { return &MultiCurveZM{} },
+	BuildWKBType(WKBMultiCurve, CoordXY, true):    func() Geometry { return &MultiCurveS{} },
+	BuildWKBType(WKBMultiCurve, CoordXYZ, true):   func() Geometry { return &MultiCurveZS{} },
+	BuildWKBType(WKBMultiCurve, CoordXYM, true):   func() Geometry { return &MultiCurveMS{} },
+	BuildWKBType(WKBMultiCurve, CoordXYZM, true):  func() Geometry { return &MultiCurveZMS{} },
+
+	BuildWKBType(WKBMultiSurface, CoordXY, false):   func() Geometry { return &MultiSurface{} },
+	BuildWKBType(WKBMultiSurface, CoordXYZ, false):  func() Geometry { return &MultiSurfaceZ{} },
+	BuildWKBType(WKBMultiSurface, CoordXYM, false):  func() Geometry { return &MultiSurfaceM{} },
+	BuildWKBType(WKBMultiSurface, CoordXYZM, false): func() Geometry { return &MultiSurfaceZM{} },
+	BuildWKBType(WKBMultiSurface, CoordXY, true):    func() Geometry { return &MultiSurfaceS{} },
+	BuildWKBType(WKBMultiSurface, CoordXYZ, true):   func() Geometry { return &MultiSurfaceZS{} },
+	BuildWKBType(WKBMultiSurface, CoordXYM, true):   func() Geometry { return &MultiSurfaceMS{} },
+	BuildWKBType(WKBMultiSurface, CoordXYZM, true):  func() Geometry { return &MultiSurfaceZMS{} },
+
+	BuildWKBType(WKBPolyhedralSurface, CoordXY, false):   func() Geometry { return &PolyhedralSurface{} },
+	BuildWKBType(WKBPolyhedralSurface, CoordXYZ, false):  func() Geometry { return &PolyhedralSurfaceZ{} },
+	BuildWKBType(WKBPolyhedralSurface, CoordXYM, false):  func() Geometry { return &PolyhedralSurfaceM{} },
+	BuildWKBType(WKBPolyhedralSurface, CoordXYZM, false): func() Geometry { return &PolyhedralSurfaceZM{} },
+	BuildWKBType(WKBPolyhedralSurface, CoordXY, true):    func() Geometry { return &PolyhedralSurfaceS{} },
+	BuildWKBType(WKBPolyhedralSurface, CoordXYZ, true):   func() Geometry { return &PolyhedralSurfaceZS{} },
+	BuildWKBType(WKBPolyhedralSurface, CoordXYM, true):   func() Geometry { return &PolyhedralSurfaceMS{} },
+	BuildWKBType(WKBPolyhedralSurface, CoordXYZM, true):  func() Geometry { return &PolyhedralSurfaceZMS{} },
+
+	BuildWKBType(WKBTIN, CoordXY, false):   func() Geometry { return &TIN{} },
+	BuildWKBType(WKBTIN, CoordXYZ, false):  func() Geometry { return &TINZ{} },
+	BuildWKBType(WKBTIN, CoordXYM, false):  func() Geometry { return &TINM{} },
+	BuildWKBType(WKBTIN, CoordXYZM, false): func() Geometry { return &TINZM{} },
+	BuildWKBType(WKBTIN, CoordXY, true):    func() Geometry { return &TINS{} },
+	BuildWKBType(WKBTIN, CoordXYZ, true):   func() Geometry { return &TINZS{} },
+	BuildWKBType(WKBTIN, CoordXYM, true):   func() Geometry { return &TINMS{} },
+	BuildWKBType(WKBTIN, CoordXYZM, true):  func() Geometry { return &TINZMS{} },
+
+	BuildWKBType(WKBTriangle, CoordXY, false):   func() Geometry { return &Triangle{} },
+	BuildWKBType(WKBTriangle, CoordXYZ, false):  func() Geometry { return &TriangleZ{} },
+	BuildWKBType(WKBTriangle, CoordXYM, false):  func() Geometry { return &TriangleM{} },
+	BuildWKBType(WKBTriangle, CoordXYZM, false): func() Geometry { return &TriangleZM{} },
+	BuildWKBType(WKBTriangle, CoordXY, true):    func() Geometry { return &TriangleS{} },
+	BuildWKBType(WKBTriangle, CoordXYZ, true):   func() Geometry { return &TriangleZS{} },
+	BuildWKBType(WKBTriangle, CoordXYM, true):   func() Geometry { return &TriangleMS{} },
+	BuildWKBType(WKBTriangle, CoordXYZM, true):  func() Geometry { return &TriangleZMS{} },
+}
+
+// NewGeometry returns an empty geometry of the Go type matching a WKB type
+// code, e.g. *PointZS for 0xA0000001. GeometryCollections of any dimension
+// map to *GeometryCollection or *GeometryCollectionS.
+func NewGeometry(wkbType uint32) (Geometry, error) {
+	if factory, ok := geometryFactories[wkbType]; ok {
+		return factory(), nil
+	}
+	if info := GetGeometryInfo(wkbType); info.BaseType == WKBGeometryCollection {
+		if info.HasSRID {
+			return &GeometryCollectionS{}, nil
+		}
+		return &GeometryCollection{}, nil
+	}
+	return nil, fmt.Errorf("%w: %d", ErrUnsupportedType, wkbType)
+}
+
+// collectionCoordType returns the coordinate type of the members of a collection
+func collectionCoordType(geometries []Geometry) CoordinateType {
+	if len(geometries) == 0 {
+		return CoordXY
+	}
+	return GetGeometryInfo(geometries[0].GetType()).CoordType
+}
+
+// checkDimensions returns an error wrapping ErrMixedDimensions unless every
+// member has the coordinate type of the first
+func checkDimensions(geometries []Geometry) error {
+	coordType := collectionCoordType(geometries)
+	for i, g := range geometries {
+		if c := GetGeometryInfo(g.GetType()).CoordType; c != coordType {
+			return fmt.Errorf("%w: member %d is %s in a collection of %s", ErrMixedDimensions, i, c, coordType)
+		}
+	}
+	return nil
+}
+
+// writeCollectionHelper writes every member as a complete EWKB geometry
+func writeCollectionHelper[G Geometry](geometries []G, buffer *bytes.Buffer) error {
+	for _, g := range geometries {
+		if err := writeMember(buffer, g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMember writes a member of a collection like writeEWKB, leaving out
+// its SRID: PostGIS only writes the SRID of the outermost geometry
+func writeMember(buffer *bytes.Buffer, g Geometry) error {
+	info := GetGeometryInfo(g.GetType())
+	if err := binary.Write(buffer, binary.LittleEndian, wkbNDR); err != nil {
+		return err
+	}
+	if err := binary.Write(buffer, binary.LittleEndian, BuildWKBType(info.BaseType, info.CoordType, false)); err != nil {
+		return err
+	}
+	return g.Write(buffer)
+}
+
+// readCollectionHelper reads members of any type, creating them from their type code
+func readCollectionHelper(reader io.Reader, count uint32) ([]Geometry, error) {
+	reader = trackOffset(reader)
+	capacity, err := checkCount(reader, count, minGeometrySize)
+	if err != nil {
+		return nil, err
+	}
+	geometries := make([]Geometry, 0, capacity)
+	for i := uint32(0); i < count; i++ {
+		byteOrder, wkbType, err := readEWKBHeader(reader)
+		if err != nil {
+			return nil, err
+		}
+		info := GetGeometryInfo(wkbType)
+		g, err := NewGeometry(BuildWKBType(info.BaseType, info.CoordType, info.HasSRID))
+		if err != nil {
+			return nil, err
+		}
+		if err := readEWKBBody(reader, byteOrder, info, g); err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, g)
+	}
+	return geometries, nil
+}
+
+// Implement SRIDGeometry interface for SRID types
+func (gc *GeometryCollectionS) GetSRID() int32     { return gc.SRID }
+func (gc *GeometryCollectionS) SetSRID(srid int32) { gc.SRID = srid }
+
+// Implement CollectionGeometry interface for all GeometryCollection types
+func (gc *GeometryCollection) GetElementCount() uint32  { return getElementCountHelper(gc.Geometries) }
+func (gc *GeometryCollectionS) GetElementCount() uint32 { return getElementCountHelper(gc.Geometries) }
+
+func (gc *GeometryCollection) WriteElements(buffer *bytes.Buffer) error {
+	if err := checkDimensions(gc.Geometries); err != nil {
+		return err
+	}
+	return writeCollectionHelper(gc.Geometries, buffer)
+}
+
+func (gc *GeometryCollectionS) WriteElements(buffer *bytes.Buffer) error {
+	if err := checkDimensions(gc.Geometries); err != nil {
+		return err
+	}
+	return writeCollectionHelper(gc.Geometries, buffer)
+}
+
+func (gc *GeometryCollection) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readCollectionHelper(reader, count)
+	if err != nil {
+		return err
+	}
+	if err := checkDimensions(geometries); err != nil {
+		return err
+	}
+	gc.Geometries = geometries
+	return nil
+}
+
+func (gc *GeometryCollectionS) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readCollectionHelper(reader, count)
+	if err != nil {
+		return err
+	}
+	if err := checkDimensions(geometries); err != nil {
+		return err
+	}
+	gc.Geometries = geometries
+	return nil
+}
+
+/** GeometryCollection functions **/
+func (gc *GeometryCollection) Scan(value interface{}) error {
+	return scanGeometryHelper(gc, value)
+}
+
+func (gc GeometryCollection) Value() (driver.Value, error) {
+	return valueGeometryHelper(&gc)
+}
+
+func (gc GeometryCollection) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, gc.GetElementCount(), func(buf *bytes.Buffer) error {
+		return gc.WriteElements(buf)
+	})
+}
+
+func (gc GeometryCollection) GetType() uint32 {
+	return BuildWKBType(WKBGeometryCollection, collectionCoordType(gc.Geometries), false)
+}
+
+/** GeometryCollectionS functions **/
+func (gc *GeometryCollectionS) Scan(value interface{}) error {
+	return scanGeometryHelper(gc, value)
+}
+
+func (gc GeometryCollectionS) Value() (driver.Value, error) {
+	return valueGeometryHelper(&gc)
+}
+
+func (gc GeometryCollectionS) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, gc.GetElementCount(), func(buf *bytes.Buffer) error {
+		return gc.WriteElements(buf)
+	})
+}
+
+func (gc GeometryCollectionS) GetType() uint32 {
+	return BuildWKBType(WKBGeometryCollection, collectionCoordType(gc.Geometries), true)
+}
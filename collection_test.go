@@ -0,0 +1,225 @@
+package postgis
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMultiPolygonS(t *testing.T) {
+	// Test MultiPolygon with SRID 4326
+	mp := MultiPolygonS{
+		SRID: 4326,
+		Polygons: []Polygon{
+			{Rings: [][]Point{square(0, 0, 1)}},
+			{Rings: [][]Point{square(5, 5, 2), square(5.5, 5.5, 1)}},
+		},
+	}
+
+	// Test Value() method
+	value, err := mp.Value()
+	if err != nil {
+		t.Errorf("MultiPolygonS.Value() failed: %v", err)
+	}
+
+	// Test Scan() method
+	var mp2 MultiPolygonS
+	err = mp2.Scan(value)
+	if err != nil {
+		t.Errorf("MultiPolygonS.Scan() failed: %v", err)
+	}
+
+	// Verify SRID
+	if mp2.SRID != 4326 {
+		t.Errorf("Expected SRID 4326, got %d", mp2.SRID)
+	}
+
+	// Verify the data
+	if len(mp2.Polygons) != 2 || len(mp2.Polygons[1].Rings) != 2 {
+		t.Fatalf("Expected 2 polygons, the second with a hole, got %v", mp2.Polygons)
+	}
+	for i, point := range mp2.Polygons[1].Rings[1] {
+		if point != mp.Polygons[1].Rings[1][i] {
+			t.Errorf("Point %d mismatch: expected %v, got %v", i, mp.Polygons[1].Rings[1][i], point)
+		}
+	}
+}
+
+func TestMultiLineStringZ(t *testing.T) {
+	// Test MultiLineString with Z coordinates
+	mls := MultiLineStringZ{
+		LineStrings: []LineStringZ{
+			{Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}},
+			{Points: []PointZ{{X: 7, Y: 8, Z: 9}, {X: 10, Y: 11, Z: 12}, {X: 13, Y: 14, Z: 15}}},
+		},
+	}
+
+	// Test Value() method
+	value, err := mls.Value()
+	if err != nil {
+		t.Errorf("MultiLineStringZ.Value() failed: %v", err)
+	}
+
+	// Test Scan() method
+	var mls2 MultiLineStringZ
+	err = mls2.Scan(value)
+	if err != nil {
+		t.Errorf("MultiLineStringZ.Scan() failed: %v", err)
+	}
+
+	// Verify the data
+	if len(mls2.LineStrings) != 2 || len(mls2.LineStrings[1].Points) != 3 {
+		t.Fatalf("Expected lines of 2 and 3 points, got %v", mls2.LineStrings)
+	}
+	if mls2.LineStrings[1].Points[2] != mls.LineStrings[1].Points[2] {
+		t.Errorf("Point mismatch: expected %v, got %v", mls.LineStrings[1].Points[2], mls2.LineStrings[1].Points[2])
+	}
+}
+
+func TestMultiPointMS(t *testing.T) {
+	// Test MultiPoint with M coordinates and SRID
+	mp := MultiPointMS{SRID: 3857, Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 4, Y: 5, M: 6}}}
+
+	// Test Value() method
+	value, err := mp.Value()
+	if err != nil {
+		t.Errorf("MultiPointMS.Value() failed: %v", err)
+	}
+
+	// Test Scan() method
+	var mp2 MultiPointMS
+	err = mp2.Scan(value)
+	if err != nil {
+		t.Errorf("MultiPointMS.Scan() failed: %v", err)
+	}
+
+	// Verify the data
+	if mp2.SRID != 3857 || len(mp2.Points) != 2 || mp2.Points[1] != mp.Points[1] {
+		t.Errorf("Expected %v, got %v", mp, mp2)
+	}
+}
+
+func TestGeometryCollection(t *testing.T) {
+	// Test GeometryCollection with mixed members
+	gc := GeometryCollectionS{
+		SRID: 4326,
+		Geometries: []Geometry{
+			&Point{X: 1, Y: 2},
+			&LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+			&Polygon{Rings: [][]Point{square(0, 0, 1)}},
+			&GeometryCollection{Geometries: []Geometry{&Point{X: 3, Y: 4}}},
+		},
+	}
+
+	// Test Value() method
+	value, err := gc.Value()
+	if err != nil {
+		t.Errorf("GeometryCollectionS.Value() failed: %v", err)
+	}
+
+	// Test Scan() method
+	var gc2 GeometryCollectionS
+	err = gc2.Scan(value)
+	if err != nil {
+		t.Fatalf("GeometryCollectionS.Scan() failed: %v", err)
+	}
+
+	// Verify the data
+	if gc2.SRID != 4326 || len(gc2.Geometries) != 4 {
+		t.Fatalf("Expected 4 members with SRID 4326, got %d members with SRID %d", len(gc2.Geometries), gc2.SRID)
+	}
+	if p, ok := gc2.Geometries[0].(*Point); !ok || *p != (Point{X: 1, Y: 2}) {
+		t.Errorf("Expected *Point(1 2), got %#v", gc2.Geometries[0])
+	}
+	if _, ok := gc2.Geometries[1].(*LineString); !ok {
+		t.Errorf("Expected *LineString, got %T", gc2.Geometries[1])
+	}
+	if _, ok := gc2.Geometries[2].(*Polygon); !ok {
+		t.Errorf("Expected *Polygon, got %T", gc2.Geometries[2])
+	}
+	if nested, ok := gc2.Geometries[3].(*GeometryCollection); !ok || len(nested.Geometries) != 1 {
+		t.Errorf("Expected nested *GeometryCollection, got %#v", gc2.Geometries[3])
+	}
+}
+
+func TestNewGeometry(t *testing.T) {
+	tests := []struct {
+		wkbType  uint32
+		expected Geometry
+	}{
+		{0xA0000001, &PointZS{}},
+		{0x40000002, &LineStringM{}},
+		{0xE0000006, &MultiPolygonZMS{}},
+		{0x80000007, &GeometryCollection{}},
+	}
+
+	for _, test := range tests {
+		g, err := NewGeometry(test.wkbType)
+		if err != nil {
+			t.Errorf("NewGeometry(0x%X) failed: %v", test.wkbType, err)
+			continue
+		}
+		if g.GetType() != test.expected.GetType() {
+			t.Errorf("NewGeometry(0x%X) returned %T", test.wkbType, g)
+		}
+	}
+
+	if _, err := NewGeometry(99); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+}
+
+func TestGeometryCollectionMixedDimensions(t *testing.T) {
+	gc := GeometryCollection{Geometries: []Geometry{&Point{X: 1, Y: 2}, &PointZ{X: 1, Y: 2, Z: 3}}}
+
+	if _, err := gc.Value(); !errors.Is(err, ErrMixedDimensions) {
+		t.Errorf("Expected ErrMixedDimensions from Value(), got %v", err)
+	}
+	if _, err := WriteEWKB(&gc); !errors.Is(err, ErrMixedDimensions) {
+		t.Errorf("Expected ErrMixedDimensions from WriteEWKB(), got %v", err)
+	}
+
+	// GEOMETRYCOLLECTION(POINT(1 2),POINT Z(1 2 3)), which PostGIS refuses
+	mixed, _ := hex.DecodeString("010700000002000000" +
+		"0101000000000000000000F03F0000000000000040" +
+		"0101000080000000000000F03F00000000000000400000000000000840")
+	if err := ReadEWKBBytes(mixed, &GeometryCollection{}); !errors.Is(err, ErrMixedDimensions) {
+		t.Errorf("Expected ErrMixedDimensions for mixed members, got %v", err)
+	}
+
+	// GEOMETRYCOLLECTION Z(POINT(1 2)), whose header disagrees with its member
+	header, _ := hex.DecodeString("010700008001000000" +
+		"0101000000000000000000F03F0000000000000040")
+	if err := ReadEWKBBytes(header, &GeometryCollection{}); !errors.Is(err, ErrMixedDimensions) {
+		t.Errorf("Expected ErrMixedDimensions for a mismatched header, got %v", err)
+	}
+}
+
+func TestGeometryCollectionMemberSRID(t *testing.T) {
+	// Members are written without their own SRID, like PostGIS
+	gc := GeometryCollectionS{SRID: 4326, Geometries: []Geometry{&PointS{SRID: 4326, X: 1, Y: 2}}}
+	expected := "0107000020E6100000010000000101000000000000000000F03F0000000000000040"
+
+	for name, encode := range map[string]func() ([]byte, error){
+		"AppendEWKB": func() ([]byte, error) { return AppendEWKB(nil, &gc) },
+		"WriteEWKB": func() ([]byte, error) {
+			buffer, err := WriteEWKB(&gc)
+			if err != nil {
+				return nil, err
+			}
+			return buffer.Bytes(), nil
+		},
+	} {
+		data, err := encode()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if got := strings.ToUpper(hex.EncodeToString(data)); got != expected {
+			t.Errorf("%s: expected %s, got %s", name, expected, got)
+		}
+	}
+	if size := gc.EncodedSize(); size != len(expected)/2 {
+		t.Errorf("Expected EncodedSize %d, got %d", len(expected)/2, size)
+	}
+}
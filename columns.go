@@ -0,0 +1,179 @@
+package postgis
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTypmodMismatch is returned by ColumnType.Check for geometries that the
+// column would refuse
+var ErrTypmodMismatch = errors.New("geometry does not match column type")
+
+// ColumnType is the declared type of a geometry or geography column, its
+// type modifier, as listed by the geometry_columns and geography_columns
+// views
+type ColumnType struct {
+	Schema, Table, Column string
+	// Geography is set for geography columns
+	Geography bool
+	// Typmod is false for columns declared without a type modifier, plain
+	// geometry or geography, which hold any geometry. The views list them as
+	// GEOMETRY with 2 dimensions and SRID 0, and so is any column declared
+	// that way.
+	Typmod bool
+	// BaseType is the type of geometry the column holds, such as WKBPoint,
+	// or 0 for any type
+	BaseType  uint32
+	CoordType CoordinateType
+	// SRID is the SRID of the geometries, or 0 for any SRID
+	SRID int32
+}
+
+// String returns the type of the column as PostgreSQL writes it, such as
+// geometry(PointZ,4326)
+func (c ColumnType) String() string {
+	name := "geometry"
+	if c.Geography {
+		name = "geography"
+	}
+	if !c.Typmod {
+		return name
+	}
+	base := "Geometry"
+	if c.BaseType != 0 {
+		base = baseTypeNames[c.BaseType]
+	}
+	base += [...]string{"", "Z", "M", "ZM"}[c.CoordType]
+	if c.SRID != 0 {
+		return fmt.Sprintf("%s(%s,%d)", name, base, c.SRID)
+	}
+	return fmt.Sprintf("%s(%s)", name, base)
+}
+
+// Check reports whether the column accepts g, applying the rules PostGIS
+// applies on insert: a geometry without SRID takes the SRID of the column,
+// the type must be that of the column, except for an empty MultiPoint in a
+// Point column, and Z and M must match. A GeometryCollection column refuses
+// MultiPoint, MultiLineString and MultiPolygon, as postgis_valid_typmod
+// does despite its comment saying otherwise. It returns an error wrapping
+// ErrTypmodMismatch otherwise.
+func (c ColumnType) Check(g Geometry) error {
+	if !c.Typmod {
+		return nil
+	}
+	info := GetGeometryInfo(g.GetType())
+	srid := sridOf(g)
+	if c.SRID != 0 && srid != nil && *srid != 0 && *srid != c.SRID {
+		return fmt.Errorf("%w: geometry SRID (%d) does not match column SRID (%d) of %s", ErrTypmodMismatch, *srid, c.SRID, c)
+	}
+
+	// An empty MultiPoint is stored as an empty Point, as PostGIS dumps
+	// empty points that way
+	emptyMultiPoint := false
+	if collection, ok := g.(CollectionGeometry); ok && info.BaseType == WKBMultiPoint {
+		emptyMultiPoint = collection.GetElementCount() == 0
+	}
+	switch {
+	case c.BaseType == 0 || c.BaseType == info.BaseType:
+	case c.BaseType == WKBPoint && emptyMultiPoint:
+	default:
+		return fmt.Errorf("%w: geometry type (%s) does not match column type %s", ErrTypmodMismatch, baseTypeNames[info.BaseType], c)
+	}
+
+	columnZ, columnM := c.CoordType == CoordXYZ || c.CoordType == CoordXYZM, c.CoordType == CoordXYM || c.CoordType == CoordXYZM
+	geometryZ, geometryM := info.CoordType == CoordXYZ || info.CoordType == CoordXYZM, info.CoordType == CoordXYM || info.CoordType == CoordXYZM
+	switch {
+	case columnZ && !geometryZ:
+		return fmt.Errorf("%w: column %s has Z dimension but geometry does not", ErrTypmodMismatch, c)
+	case geometryZ && !columnZ:
+		return fmt.Errorf("%w: geometry has Z dimension but column %s does not", ErrTypmodMismatch, c)
+	case columnM && !geometryM:
+		return fmt.Errorf("%w: column %s has M dimension but geometry does not", ErrTypmodMismatch, c)
+	case geometryM && !columnM:
+		return fmt.Errorf("%w: geometry has M dimension but column %s does not", ErrTypmodMismatch, c)
+	}
+	return nil
+}
+
+// Querier runs queries, implemented by *sql.DB, *sql.Tx and *sql.Conn
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// columnTypesQuery lists the geometry and geography columns of a table,
+// whose schema defaults to the current schema
+const columnTypesQuery = `SELECT f_table_schema, f_table_name, f_geometry_column, false, type, coord_dimension, srid
+FROM geometry_columns
+WHERE f_table_name = $1 AND f_table_schema = COALESCE(NULLIF($2::text, ''), current_schema())
+UNION ALL
+SELECT f_table_schema, f_table_name, f_geography_column, true, type, coord_dimension, srid
+FROM geography_columns
+WHERE f_table_name = $1 AND f_table_schema = COALESCE(NULLIF($2::text, ''), current_schema())`
+
+// ColumnTypes returns the geometry and geography columns of a table by
+// name, read from the geometry_columns and geography_columns views. An
+// empty schema is the current schema.
+//
+//	columns, err := postgis.ColumnTypes(ctx, db, "", "shops")
+//	if err := columns["location"].Check(&location); err != nil {
+//		return err
+//	}
+func ColumnTypes(ctx context.Context, db Querier, schema, table string) (map[string]ColumnType, error) {
+	rows, err := db.QueryContext(ctx, columnTypesQuery, table, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]ColumnType)
+	for rows.Next() {
+		var c ColumnType
+		var typeName string
+		var dimensions int
+		if err := rows.Scan(&c.Schema, &c.Table, &c.Column, &c.Geography, &typeName, &dimensions, &c.SRID); err != nil {
+			return nil, err
+		}
+		if err := c.setType(typeName, dimensions); err != nil {
+			return nil, err
+		}
+		columns[c.Column] = c
+	}
+	return columns, rows.Err()
+}
+
+// setType parses the type of a column as listed by the views: geometry_columns
+// writes POINT or POINTM with the number of dimensions, and
+// geography_columns Point, PointZ, PointM or PointZM
+func (c *ColumnType) setType(typeName string, dimensions int) error {
+	name := strings.ToUpper(typeName)
+	c.CoordType = CoordXY
+	switch {
+	case strings.HasSuffix(name, "ZM"):
+		c.CoordType, name = CoordXYZM, strings.TrimSuffix(name, "ZM")
+	case strings.HasSuffix(name, "Z"):
+		c.CoordType, name = CoordXYZ, strings.TrimSuffix(name, "Z")
+	case strings.HasSuffix(name, "M"):
+		c.CoordType, name = CoordXYM, strings.TrimSuffix(name, "M")
+		if dimensions == 4 {
+			c.CoordType = CoordXYZM
+		}
+	case dimensions == 3:
+		c.CoordType = CoordXYZ
+	case dimensions == 4:
+		c.CoordType = CoordXYZM
+	}
+
+	c.BaseType = 0
+	if name != "GEOMETRY" {
+		baseType, ok := wktTypes[name]
+		if !ok {
+			return fmt.Errorf("%w: column %s has type %s", ErrUnsupportedType, c.Column, typeName)
+		}
+		c.BaseType = baseType
+	}
+	c.Typmod = c.BaseType != 0 || c.CoordType != CoordXY || c.SRID != 0
+	return nil
+}
@@ -0,0 +1,125 @@
+package postgis
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// viewsDriver is a database/sql driver answering every query with the rows
+// of the geometry_columns and geography_columns views
+type viewsDriver struct {
+	rows [][]driver.Value
+	args []driver.NamedValue
+}
+
+func (d *viewsDriver) Open(string) (driver.Conn, error) { return viewsConn{d}, nil }
+
+type viewsConn struct{ d *viewsDriver }
+
+func (c viewsConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c viewsConn) Close() error                        { return nil }
+func (c viewsConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c viewsConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.args = args
+	return &viewsRows{rows: c.d.rows}, nil
+}
+
+type viewsRows struct{ rows [][]driver.Value }
+
+func (r *viewsRows) Columns() []string {
+	return []string{"f_table_schema", "f_table_name", "f_geometry_column", "bool", "type", "coord_dimension", "srid"}
+}
+func (r *viewsRows) Close() error { return nil }
+
+func (r *viewsRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestColumnTypes(t *testing.T) {
+	views := &viewsDriver{rows: [][]driver.Value{
+		{"public", "shops", "location", false, "POINT", int64(2), int64(4326)},
+		{"public", "shops", "path", false, "LINESTRINGM", int64(3), int64(3857)},
+		{"public", "shops", "track", false, "LINESTRING", int64(4), int64(0)},
+		{"public", "shops", "anything", false, "GEOMETRY", int64(2), int64(0)},
+		{"public", "shops", "area", true, "PolygonZ", int64(3), int64(4326)},
+		{"public", "shops", "places", true, "Geometry", int64(2), int64(4326)},
+	}}
+	sql.Register("postgis-views", views)
+	db, err := sql.Open("postgis-views", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	columns, err := ColumnTypes(context.Background(), db, "", "shops")
+	if err != nil {
+		t.Fatalf("ColumnTypes failed: %v", err)
+	}
+	if args := []any{views.args[0].Value, views.args[1].Value}; !reflect.DeepEqual(args, []any{"shops", ""}) {
+		t.Errorf("Unexpected arguments %v", args)
+	}
+	expected := map[string]string{
+		"location": "geometry(Point,4326)",
+		"path":     "geometry(LineStringM,3857)",
+		"track":    "geometry(LineStringZM)",
+		"anything": "geometry",
+		"area":     "geography(PolygonZ,4326)",
+		"places":   "geography(Geometry,4326)",
+	}
+	if len(columns) != len(expected) {
+		t.Errorf("Expected %d columns, got %v", len(expected), columns)
+	}
+	for name, typ := range expected {
+		if c := columns[name]; c.String() != typ || c.Column != name || c.Table != "shops" || c.Schema != "public" {
+			t.Errorf("Expected %s for %s, got %s (%+v)", typ, name, c, c)
+		}
+	}
+}
+
+func TestColumnTypeCheck(t *testing.T) {
+	point := ColumnType{Typmod: true, BaseType: WKBPoint, SRID: 4326}
+	collection := ColumnType{Typmod: true, BaseType: WKBGeometryCollection}
+	tests := []struct {
+		column   ColumnType
+		geometry Geometry
+		ok       bool
+	}{
+		{point, &PointS{SRID: 4326}, true},
+		{point, &Point{}, true},
+		{point, &PointS{SRID: 3857}, false},
+		{point, &PointZS{SRID: 4326}, false},
+		{point, &MultiPoint{}, true},
+		{point, &MultiPoint{Points: []Point{{X: 1, Y: 2}}}, false},
+		{point, &LineStringS{SRID: 4326}, false},
+		{collection, &GeometryCollection{}, true},
+		{collection, &MultiPolygon{}, false},
+		{collection, &MultiPoint{}, false},
+		{collection, &Polygon{}, false},
+		{ColumnType{Typmod: true, CoordType: CoordXYZ}, &PolygonZ{}, true},
+		{ColumnType{Typmod: true, CoordType: CoordXYZ}, &PolygonZM{}, false},
+		{ColumnType{Typmod: true, CoordType: CoordXYZM}, &PolygonZ{}, false},
+		{ColumnType{Typmod: true, BaseType: WKBLineString, CoordType: CoordXYM}, &LineStringM{}, true},
+		{ColumnType{Typmod: true, BaseType: WKBLineString, CoordType: CoordXYM}, &LineString{}, false},
+		{ColumnType{}, &TINZMS{SRID: 1}, true},
+	}
+	for _, test := range tests {
+		err := test.column.Check(test.geometry)
+		if test.ok && err != nil {
+			t.Errorf("Expected %s to accept %s, got %v", test.column, geometryTypeName(test.geometry), err)
+		}
+		if !test.ok && !errors.Is(err, ErrTypmodMismatch) {
+			t.Errorf("Expected %s to refuse %s, got %v", test.column, geometryTypeName(test.geometry), err)
+		}
+	}
+}
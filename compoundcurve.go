@@ -0,0 +1,107 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// CompoundCurveOf is a CompoundCurve whose coordinate type P sets its
+// dimensions: a continuous curve made of LineStrings and CircularStrings,
+// each starting where the previous one ends. Its members are stored as
+// pointers, e.g. *LineStringZ or *CircularStringZ, and have no SRID.
+type CompoundCurveOf[P PointLike] struct {
+	Curves []Curve[P]
+}
+
+// CompoundCurveSOf is a CompoundCurve with an SRID
+type CompoundCurveSOf[P PointLike] struct {
+	SRID   int32
+	Curves []Curve[P]
+}
+
+// Varying types of CompoundCurves
+type (
+	CompoundCurve   = CompoundCurveOf[Point]
+	CompoundCurveZ  = CompoundCurveOf[PointZ]
+	CompoundCurveM  = CompoundCurveOf[PointM]
+	CompoundCurveZM = CompoundCurveOf[PointZM]
+
+	CompoundCurveS   = CompoundCurveSOf[Point]
+	CompoundCurveZS  = CompoundCurveSOf[PointZ]
+	CompoundCurveMS  = CompoundCurveSOf[PointM]
+	CompoundCurveZMS = CompoundCurveSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (c *CompoundCurveSOf[P]) GetSRID() int32     { return c.SRID }
+func (c *CompoundCurveSOf[P]) SetSRID(srid int32) { c.SRID = srid }
+
+// Implement CollectionGeometry interface for all CompoundCurve types
+func (c *CompoundCurveOf[P]) GetElementCount() uint32  { return getElementCountHelper(c.Curves) }
+func (c *CompoundCurveSOf[P]) GetElementCount() uint32 { return getElementCountHelper(c.Curves) }
+
+func (c *CompoundCurveOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeCollectionHelper(c.Curves, buffer)
+}
+
+func (c *CompoundCurveSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeCollectionHelper(c.Curves, buffer)
+}
+
+func (c *CompoundCurveOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readMembersHelper(reader, count, newSegment[P])
+	if err != nil {
+		return err
+	}
+	c.Curves = geometries
+	return nil
+}
+
+func (c *CompoundCurveSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readMembersHelper(reader, count, newSegment[P])
+	if err != nil {
+		return err
+	}
+	c.Curves = geometries
+	return nil
+}
+
+/** CompoundCurveOf functions **/
+func (c *CompoundCurveOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(c, value)
+}
+
+func (c CompoundCurveOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&c)
+}
+
+func (c CompoundCurveOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, c.GetElementCount(), func(buf *bytes.Buffer) error {
+		return c.WriteElements(buf)
+	})
+}
+
+func (c CompoundCurveOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBCompoundCurve, coordinateType[P](), false)
+}
+
+/** CompoundCurveSOf functions **/
+func (c *CompoundCurveSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(c, value)
+}
+
+func (c CompoundCurveSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&c)
+}
+
+func (c CompoundCurveSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, c.GetElementCount(), func(buf *bytes.Buffer) error {
+		return c.WriteElements(buf)
+	})
+}
+
+func (c CompoundCurveSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBCompoundCurve, coordinateType[P](), true)
+}
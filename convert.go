@@ -0,0 +1,618 @@
+package postgis
+
+import "fmt"
+
+// ForceXY returns a copy of g with only X and Y, like ST_Force2D. The result
+// is a pointer to the matching type of the same kind, keeping the SRID: a
+// *LineStringZS gives a *LineStringS.
+func ForceXY(g Geometry) (Geometry, error) {
+	return reshape(g, CoordXY, sridOf(g))
+}
+
+// Force3DZ returns a copy of g with X, Y and Z, like ST_Force3DZ. A missing
+// Z is set to zero and M is dropped.
+func Force3DZ(g Geometry) (Geometry, error) {
+	return reshape(g, CoordXYZ, sridOf(g))
+}
+
+// Force3DM returns a copy of g with X, Y and M, like ST_Force3DM. A missing
+// M is set to zero and Z is dropped.
+func Force3DM(g Geometry) (Geometry, error) {
+	return reshape(g, CoordXYM, sridOf(g))
+}
+
+// Force4D returns a copy of g with X, Y, Z and M, like ST_Force4D. Missing
+// values are set to zero.
+func Force4D(g Geometry) (Geometry, error) {
+	return reshape(g, CoordXYZM, sridOf(g))
+}
+
+// WithSRID returns a copy of g with the given SRID, like ST_SetSRID. The
+// result has the SRID variant of the type of g: a *LineString gives a
+// *LineStringS. The coordinates are not transformed.
+func WithSRID(g Geometry, srid int32) (Geometry, error) {
+	return reshape(g, keepLayout, &srid)
+}
+
+// StripSRID returns a copy of g without SRID, of the variant of its type
+// that has none: a *PointZS gives a *PointZ.
+func StripSRID(g Geometry) (Geometry, error) {
+	return reshape(g, keepLayout, nil)
+}
+
+// keepLayout asks reshape to keep the coordinate type of a geometry
+const keepLayout CoordinateType = -1
+
+// reshaper is implemented by every geometry type of this package. reshape
+// returns a copy with the given coordinate type, or its own for keepLayout,
+// with the given SRID, or without one when srid is nil.
+type reshaper interface {
+	reshape(layout CoordinateType, srid *int32) (Geometry, error)
+}
+
+func reshape(g Geometry, layout CoordinateType, srid *int32) (Geometry, error) {
+	if r, ok := g.(reshaper); ok {
+		return r.reshape(layout, srid)
+	}
+	return nil, fmt.Errorf("%w: %s cannot be converted", ErrUnsupportedType, geometryTypeName(g))
+}
+
+func sridOf(g Geometry) *int32 {
+	if s, ok := g.(SRIDGeometry); ok {
+		srid := s.GetSRID()
+		return &srid
+	}
+	return nil
+}
+
+func resolveLayout[P PointLike](layout CoordinateType) CoordinateType {
+	return keep(layout, coordinateType[P]())
+}
+
+// keep returns layout, or own for keepLayout
+func keep(layout, own CoordinateType) CoordinateType {
+	if layout == keepLayout {
+		return own
+	}
+	return layout
+}
+
+// newPoint returns a point of the given coordinate type and SRID
+func newPoint(x, y, z, m float64, layout CoordinateType, srid *int32) Geometry {
+	if srid == nil {
+		switch layout {
+		case CoordXYZ:
+			return &PointZ{X: x, Y: y, Z: z}
+		case CoordXYM:
+			return &PointM{X: x, Y: y, M: m}
+		case CoordXYZM:
+			return &PointZM{X: x, Y: y, Z: z, M: m}
+		}
+		return &Point{X: x, Y: y}
+	}
+	switch layout {
+	case CoordXYZ:
+		return &PointZS{SRID: *srid, X: x, Y: y, Z: z}
+	case CoordXYM:
+		return &PointMS{SRID: *srid, X: x, Y: y, M: m}
+	case CoordXYZM:
+		return &PointZMS{SRID: *srid, X: x, Y: y, Z: z, M: m}
+	}
+	return &PointS{SRID: *srid, X: x, Y: y}
+}
+
+// Implement reshaper interface for all types
+func (p *Point) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return newPoint(p.X, p.Y, 0, 0, keep(layout, CoordXY), srid), nil
+}
+
+func (p *PointZ) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return newPoint(p.X, p.Y, p.Z, 0, keep(layout, CoordXYZ), srid), nil
+}
+
+func (p *PointM) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return newPoint(p.X, p.Y, 0, p.M, keep(layout, CoordXYM), srid), nil
+}
+
+func (p *PointZM) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return newPoint(p.X, p.Y, p.Z, p.M, keep(layout, CoordXYZM), srid), nil
+}
+
+func (p *PointS) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return newPoint(p.X, p.Y, 0, 0, keep(layout, CoordXY), srid), nil
+}
+
+func (p *PointZS) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return newPoint(p.X, p.Y, p.Z, 0, keep(layout, CoordXYZ), srid), nil
+}
+
+func (p *PointMS) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return newPoint(p.X, p.Y, 0, p.M, keep(layout, CoordXYM), srid), nil
+}
+
+func (p *PointZMS) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return newPoint(p.X, p.Y, p.Z, p.M, keep(layout, CoordXYZM), srid), nil
+}
+
+func (ls *LineStringOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeLineString(ls.Points, layout, srid), nil
+}
+
+func (ls *LineStringSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeLineString(ls.Points, layout, srid), nil
+}
+
+func (p *PolygonOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapePolygon(p.Rings, layout, srid), nil
+}
+
+func (p *PolygonSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapePolygon(p.Rings, layout, srid), nil
+}
+
+func (m *MultiPointOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiPoint(m.Points, layout, srid), nil
+}
+
+func (m *MultiPointSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiPoint(m.Points, layout, srid), nil
+}
+
+func (m *MultiLineStringOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiLineString(m.LineStrings, layout, srid), nil
+}
+
+func (m *MultiLineStringSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiLineString(m.LineStrings, layout, srid), nil
+}
+
+func (m *MultiPolygonOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiPolygon(m.Polygons, layout, srid), nil
+}
+
+func (m *MultiPolygonSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiPolygon(m.Polygons, layout, srid), nil
+}
+
+func (gc *GeometryCollection) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeCollection(gc.Geometries, layout, srid)
+}
+
+func (gc *GeometryCollectionS) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeCollection(gc.Geometries, layout, srid)
+}
+
+func (cs *CircularStringOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeCircularString(cs.Points, layout, srid), nil
+}
+
+func (cs *CircularStringSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeCircularString(cs.Points, layout, srid), nil
+}
+
+func (c *CompoundCurveOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeCompoundCurve(c.Curves, layout, srid), nil
+}
+
+func (c *CompoundCurveSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeCompoundCurve(c.Curves, layout, srid), nil
+}
+
+func (p *CurvePolygonOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeCurvePolygon(p.Rings, layout, srid), nil
+}
+
+func (p *CurvePolygonSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeCurvePolygon(p.Rings, layout, srid), nil
+}
+
+func (m *MultiCurveOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiCurve(m.Curves, layout, srid), nil
+}
+
+func (m *MultiCurveSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiCurve(m.Curves, layout, srid), nil
+}
+
+func (m *MultiSurfaceOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiSurface(m.Surfaces, layout, srid), nil
+}
+
+func (m *MultiSurfaceSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeMultiSurface(m.Surfaces, layout, srid), nil
+}
+
+func (s *PolyhedralSurfaceOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapePolyhedralSurface(s.Polygons, layout, srid), nil
+}
+
+func (s *PolyhedralSurfaceSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapePolyhedralSurface(s.Polygons, layout, srid), nil
+}
+
+func (m *TINOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeTIN(m.Triangles, layout, srid), nil
+}
+
+func (m *TINSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeTIN(m.Triangles, layout, srid), nil
+}
+
+func (t *TriangleOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeTriangle(t.Points, layout, srid), nil
+}
+
+func (t *TriangleSOf[P]) reshape(layout CoordinateType, srid *int32) (Geometry, error) {
+	return reshapeTriangle(t.Points, layout, srid), nil
+}
+
+func reshapeLineString[P PointLike](points []P, layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newLineString(convertPoints[PointZ](points), srid)
+	case CoordXYM:
+		return newLineString(convertPoints[PointM](points), srid)
+	case CoordXYZM:
+		return newLineString(convertPoints[PointZM](points), srid)
+	}
+	return newLineString(convertPoints[Point](points), srid)
+}
+
+func newLineString[Q PointLike](points []Q, srid *int32) Geometry {
+	if srid != nil {
+		return &LineStringSOf[Q]{SRID: *srid, Points: points}
+	}
+	return &LineStringOf[Q]{Points: points}
+}
+
+func reshapePolygon[P PointLike](rings [][]P, layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newPolygon(convertRings[PointZ](rings), srid)
+	case CoordXYM:
+		return newPolygon(convertRings[PointM](rings), srid)
+	case CoordXYZM:
+		return newPolygon(convertRings[PointZM](rings), srid)
+	}
+	return newPolygon(convertRings[Point](rings), srid)
+}
+
+func newPolygon[Q PointLike](rings [][]Q, srid *int32) Geometry {
+	if srid != nil {
+		return &PolygonSOf[Q]{SRID: *srid, Rings: rings}
+	}
+	return &PolygonOf[Q]{Rings: rings}
+}
+
+func reshapeMultiPoint[P PointLike](points []P, layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newMultiPoint(convertPoints[PointZ](points), srid)
+	case CoordXYM:
+		return newMultiPoint(convertPoints[PointM](points), srid)
+	case CoordXYZM:
+		return newMultiPoint(convertPoints[PointZM](points), srid)
+	}
+	return newMultiPoint(convertPoints[Point](points), srid)
+}
+
+func newMultiPoint[Q PointLike](points []Q, srid *int32) Geometry {
+	if srid != nil {
+		return &MultiPointSOf[Q]{SRID: *srid, Points: points}
+	}
+	return &MultiPointOf[Q]{Points: points}
+}
+
+func reshapeMultiLineString[P PointLike](lines []LineStringOf[P], layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newMultiLineString(convertLineStrings[PointZ](lines), srid)
+	case CoordXYM:
+		return newMultiLineString(convertLineStrings[PointM](lines), srid)
+	case CoordXYZM:
+		return newMultiLineString(convertLineStrings[PointZM](lines), srid)
+	}
+	return newMultiLineString(convertLineStrings[Point](lines), srid)
+}
+
+func newMultiLineString[Q PointLike](lines []LineStringOf[Q], srid *int32) Geometry {
+	if srid != nil {
+		return &MultiLineStringSOf[Q]{SRID: *srid, LineStrings: lines}
+	}
+	return &MultiLineStringOf[Q]{LineStrings: lines}
+}
+
+func reshapeMultiPolygon[P PointLike](polygons []PolygonOf[P], layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newMultiPolygon(convertPolygons[PointZ](polygons), srid)
+	case CoordXYM:
+		return newMultiPolygon(convertPolygons[PointM](polygons), srid)
+	case CoordXYZM:
+		return newMultiPolygon(convertPolygons[PointZM](polygons), srid)
+	}
+	return newMultiPolygon(convertPolygons[Point](polygons), srid)
+}
+
+func newMultiPolygon[Q PointLike](polygons []PolygonOf[Q], srid *int32) Geometry {
+	if srid != nil {
+		return &MultiPolygonSOf[Q]{SRID: *srid, Polygons: polygons}
+	}
+	return &MultiPolygonOf[Q]{Polygons: polygons}
+}
+
+func reshapeCircularString[P PointLike](points []P, layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newCircularString(convertPoints[PointZ](points), srid)
+	case CoordXYM:
+		return newCircularString(convertPoints[PointM](points), srid)
+	case CoordXYZM:
+		return newCircularString(convertPoints[PointZM](points), srid)
+	}
+	return newCircularString(convertPoints[Point](points), srid)
+}
+
+func newCircularString[Q PointLike](points []Q, srid *int32) Geometry {
+	if srid != nil {
+		return &CircularStringSOf[Q]{SRID: *srid, Points: points}
+	}
+	return &CircularStringOf[Q]{Points: points}
+}
+
+func reshapeCompoundCurve[P PointLike](curves []Curve[P], layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newCompoundCurve(convertMembers[Curve[PointZ]](curves, CoordXYZ), srid)
+	case CoordXYM:
+		return newCompoundCurve(convertMembers[Curve[PointM]](curves, CoordXYM), srid)
+	case CoordXYZM:
+		return newCompoundCurve(convertMembers[Curve[PointZM]](curves, CoordXYZM), srid)
+	}
+	return newCompoundCurve(convertMembers[Curve[Point]](curves, CoordXY), srid)
+}
+
+func newCompoundCurve[Q PointLike](curves []Curve[Q], srid *int32) Geometry {
+	if srid != nil {
+		return &CompoundCurveSOf[Q]{SRID: *srid, Curves: curves}
+	}
+	return &CompoundCurveOf[Q]{Curves: curves}
+}
+
+func reshapeCurvePolygon[P PointLike](rings []Curve[P], layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newCurvePolygon(convertMembers[Curve[PointZ]](rings, CoordXYZ), srid)
+	case CoordXYM:
+		return newCurvePolygon(convertMembers[Curve[PointM]](rings, CoordXYM), srid)
+	case CoordXYZM:
+		return newCurvePolygon(convertMembers[Curve[PointZM]](rings, CoordXYZM), srid)
+	}
+	return newCurvePolygon(convertMembers[Curve[Point]](rings, CoordXY), srid)
+}
+
+func newCurvePolygon[Q PointLike](rings []Curve[Q], srid *int32) Geometry {
+	if srid != nil {
+		return &CurvePolygonSOf[Q]{SRID: *srid, Rings: rings}
+	}
+	return &CurvePolygonOf[Q]{Rings: rings}
+}
+
+func reshapeMultiCurve[P PointLike](curves []Curve[P], layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newMultiCurve(convertMembers[Curve[PointZ]](curves, CoordXYZ), srid)
+	case CoordXYM:
+		return newMultiCurve(convertMembers[Curve[PointM]](curves, CoordXYM), srid)
+	case CoordXYZM:
+		return newMultiCurve(convertMembers[Curve[PointZM]](curves, CoordXYZM), srid)
+	}
+	return newMultiCurve(convertMembers[Curve[Point]](curves, CoordXY), srid)
+}
+
+func newMultiCurve[Q PointLike](curves []Curve[Q], srid *int32) Geometry {
+	if srid != nil {
+		return &MultiCurveSOf[Q]{SRID: *srid, Curves: curves}
+	}
+	return &MultiCurveOf[Q]{Curves: curves}
+}
+
+func reshapeMultiSurface[P PointLike](surfaces []Surface[P], layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newMultiSurface(convertMembers[Surface[PointZ]](surfaces, CoordXYZ), srid)
+	case CoordXYM:
+		return newMultiSurface(convertMembers[Surface[PointM]](surfaces, CoordXYM), srid)
+	case CoordXYZM:
+		return newMultiSurface(convertMembers[Surface[PointZM]](surfaces, CoordXYZM), srid)
+	}
+	return newMultiSurface(convertMembers[Surface[Point]](surfaces, CoordXY), srid)
+}
+
+func newMultiSurface[Q PointLike](surfaces []Surface[Q], srid *int32) Geometry {
+	if srid != nil {
+		return &MultiSurfaceSOf[Q]{SRID: *srid, Surfaces: surfaces}
+	}
+	return &MultiSurfaceOf[Q]{Surfaces: surfaces}
+}
+
+// convertMembers reshapes the members of a curved geometry to layout. The
+// members are types of this package without SRID, so the result has the
+// member type M of the same coordinate type and reshaping cannot fail.
+func convertMembers[M any, G Geometry](members []G, layout CoordinateType) []M {
+	if members == nil {
+		return nil
+	}
+	result := make([]M, len(members))
+	for i, g := range members {
+		member, _ := reshape(g, layout, nil)
+		result[i] = member.(M)
+	}
+	return result
+}
+
+func reshapePolyhedralSurface[P PointLike](polygons []PolygonOf[P], layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newPolyhedralSurface(convertPolygons[PointZ](polygons), srid)
+	case CoordXYM:
+		return newPolyhedralSurface(convertPolygons[PointM](polygons), srid)
+	case CoordXYZM:
+		return newPolyhedralSurface(convertPolygons[PointZM](polygons), srid)
+	}
+	return newPolyhedralSurface(convertPolygons[Point](polygons), srid)
+}
+
+func newPolyhedralSurface[Q PointLike](polygons []PolygonOf[Q], srid *int32) Geometry {
+	if srid != nil {
+		return &PolyhedralSurfaceSOf[Q]{SRID: *srid, Polygons: polygons}
+	}
+	return &PolyhedralSurfaceOf[Q]{Polygons: polygons}
+}
+
+func reshapeTIN[P PointLike](triangles []TriangleOf[P], layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newTIN(convertTriangles[PointZ](triangles), srid)
+	case CoordXYM:
+		return newTIN(convertTriangles[PointM](triangles), srid)
+	case CoordXYZM:
+		return newTIN(convertTriangles[PointZM](triangles), srid)
+	}
+	return newTIN(convertTriangles[Point](triangles), srid)
+}
+
+func newTIN[Q PointLike](triangles []TriangleOf[Q], srid *int32) Geometry {
+	if srid != nil {
+		return &TINSOf[Q]{SRID: *srid, Triangles: triangles}
+	}
+	return &TINOf[Q]{Triangles: triangles}
+}
+
+func reshapeTriangle[P PointLike](points []P, layout CoordinateType, srid *int32) Geometry {
+	switch resolveLayout[P](layout) {
+	case CoordXYZ:
+		return newTriangle(convertPoints[PointZ](points), srid)
+	case CoordXYM:
+		return newTriangle(convertPoints[PointM](points), srid)
+	case CoordXYZM:
+		return newTriangle(convertPoints[PointZM](points), srid)
+	}
+	return newTriangle(convertPoints[Point](points), srid)
+}
+
+func newTriangle[Q PointLike](points []Q, srid *int32) Geometry {
+	if srid != nil {
+		return &TriangleSOf[Q]{SRID: *srid, Points: points}
+	}
+	return &TriangleOf[Q]{Points: points}
+}
+
+// reshapeCollection converts the members to layout, keeping their own SRID,
+// and gives the collection the SRID
+func reshapeCollection(geometries []Geometry, layout CoordinateType, srid *int32) (Geometry, error) {
+	members := make([]Geometry, len(geometries))
+	for i, g := range geometries {
+		member, err := reshape(g, layout, sridOf(g))
+		if err != nil {
+			return nil, err
+		}
+		members[i] = member
+	}
+	if srid != nil {
+		return &GeometryCollectionS{SRID: *srid, Geometries: members}, nil
+	}
+	return &GeometryCollection{Geometries: members}, nil
+}
+
+// convertPoints copies points to the coordinate type Q, dropping the values
+// Q lacks and setting the ones it adds to zero
+func convertPoints[Q, P PointLike](points []P) []Q {
+	if points == nil {
+		return nil
+	}
+	result := make([]Q, len(points))
+	for i, p := range points {
+		result[i] = convertPoint[Q](p)
+	}
+	return result
+}
+
+func convertPoint[Q, P PointLike](p P) Q {
+	return pointFrom[Q](pointValues(p))
+}
+
+// pointValues returns the coordinates of p, zero for the ones it lacks
+func pointValues[P PointLike](p P) (x, y, z, m float64) {
+	switch p := any(p).(type) {
+	case Point:
+		x, y = p.X, p.Y
+	case PointZ:
+		x, y, z = p.X, p.Y, p.Z
+	case PointM:
+		x, y, m = p.X, p.Y, p.M
+	case PointZM:
+		x, y, z, m = p.X, p.Y, p.Z, p.M
+	}
+	return x, y, z, m
+}
+
+// pointFrom returns a point of type P, dropping the values it lacks
+func pointFrom[P PointLike](x, y, z, m float64) P {
+	var p P
+	switch p := any(&p).(type) {
+	case *Point:
+		*p = Point{X: x, Y: y}
+	case *PointZ:
+		*p = PointZ{X: x, Y: y, Z: z}
+	case *PointM:
+		*p = PointM{X: x, Y: y, M: m}
+	case *PointZM:
+		*p = PointZM{X: x, Y: y, Z: z, M: m}
+	}
+	return p
+}
+
+func convertRings[Q, P PointLike](rings [][]P) [][]Q {
+	if rings == nil {
+		return nil
+	}
+	result := make([][]Q, len(rings))
+	for i, ring := range rings {
+		result[i] = convertPoints[Q](ring)
+	}
+	return result
+}
+
+func convertLineStrings[Q, P PointLike](lines []LineStringOf[P]) []LineStringOf[Q] {
+	if lines == nil {
+		return nil
+	}
+	result := make([]LineStringOf[Q], len(lines))
+	for i, ls := range lines {
+		result[i] = LineStringOf[Q]{Points: convertPoints[Q](ls.Points)}
+	}
+	return result
+}
+
+func convertPolygons[Q, P PointLike](polygons []PolygonOf[P]) []PolygonOf[Q] {
+	if polygons == nil {
+		return nil
+	}
+	result := make([]PolygonOf[Q], len(polygons))
+	for i, p := range polygons {
+		result[i] = PolygonOf[Q]{Rings: convertRings[Q](p.Rings)}
+	}
+	return result
+}
+
+func convertTriangles[Q, P PointLike](triangles []TriangleOf[P]) []TriangleOf[Q] {
+	if triangles == nil {
+		return nil
+	}
+	result := make([]TriangleOf[Q], len(triangles))
+	for i, t := range triangles {
+		result[i] = TriangleOf[Q]{Points: convertPoints[Q](t.Points)}
+	}
+	return result
+}
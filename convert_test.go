@@ -0,0 +1,135 @@
+package postgis
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestForceDimensions(t *testing.T) {
+	ls := &LineStringZMS{SRID: 4326, Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}}
+
+	tests := []struct {
+		name     string
+		force    func(Geometry) (Geometry, error)
+		expected Geometry
+	}{
+		{"ForceXY", ForceXY, &LineStringS{SRID: 4326, Points: []Point{{X: 1, Y: 2}, {X: 5, Y: 6}}}},
+		{"Force3DZ", Force3DZ, &LineStringZS{SRID: 4326, Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 5, Y: 6, Z: 7}}}},
+		{"Force3DM", Force3DM, &LineStringMS{SRID: 4326, Points: []PointM{{X: 1, Y: 2, M: 4}, {X: 5, Y: 6, M: 8}}}},
+		{"Force4D", Force4D, &LineStringZMS{SRID: 4326, Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.force(ls)
+			if err != nil {
+				t.Fatalf("%s failed: %v", test.name, err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %#v, got %#v", test.expected, result)
+			}
+		})
+	}
+
+	// The result does not share the points of the input
+	result, _ := Force4D(ls)
+	result.(*LineStringZMS).Points[0].X = 100
+	if ls.Points[0].X != 1 {
+		t.Errorf("Expected a copy, the input changed to %v", ls.Points[0])
+	}
+}
+
+func TestForceAddsZeroes(t *testing.T) {
+	result, err := Force4D(&Point{X: 1, Y: 2})
+	if err != nil {
+		t.Fatalf("Force4D failed: %v", err)
+	}
+	if expected := (&PointZM{X: 1, Y: 2}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, result)
+	}
+
+	result, err = Force3DZ(&MultiPolygonM{Polygons: []PolygonM{{Rings: [][]PointM{{{X: 0, Y: 0, M: 1}, {X: 1, Y: 0, M: 2}, {X: 0, Y: 1, M: 3}, {X: 0, Y: 0, M: 1}}}}}})
+	if err != nil {
+		t.Fatalf("Force3DZ failed: %v", err)
+	}
+	expected := &MultiPolygonZ{Polygons: []PolygonZ{{Rings: [][]PointZ{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, result)
+	}
+}
+
+func TestWithSRID(t *testing.T) {
+	tests := []struct {
+		input    Geometry
+		expected Geometry
+	}{
+		{&PointZ{X: 1, Y: 2, Z: 3}, &PointZS{SRID: 3857, X: 1, Y: 2, Z: 3}},
+		{&PointMS{SRID: 4326, X: 1, Y: 2, M: 3}, &PointMS{SRID: 3857, X: 1, Y: 2, M: 3}},
+		{&LineStringM{Points: []PointM{{X: 1, Y: 2, M: 3}}}, &LineStringMS{SRID: 3857, Points: []PointM{{X: 1, Y: 2, M: 3}}}},
+		{&Polygon{Rings: [][]Point{{{X: 0, Y: 0}}}}, &PolygonS{SRID: 3857, Rings: [][]Point{{{X: 0, Y: 0}}}}},
+		{&MultiPoint{Points: []Point{{X: 1, Y: 2}}}, &MultiPointS{SRID: 3857, Points: []Point{{X: 1, Y: 2}}}},
+		{&MultiLineStringZ{LineStrings: []LineStringZ{{Points: []PointZ{{X: 1, Y: 2, Z: 3}}}}}, &MultiLineStringZS{SRID: 3857, LineStrings: []LineStringZ{{Points: []PointZ{{X: 1, Y: 2, Z: 3}}}}}},
+	}
+	for _, test := range tests {
+		t.Run(geometryTypeName(test.input), func(t *testing.T) {
+			result, err := WithSRID(test.input, 3857)
+			if err != nil {
+				t.Fatalf("WithSRID failed: %v", err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %#v, got %#v", test.expected, result)
+			}
+
+			stripped, err := StripSRID(result)
+			if err != nil {
+				t.Fatalf("StripSRID failed: %v", err)
+			}
+			if _, ok := stripped.(SRIDGeometry); ok {
+				t.Errorf("Expected no SRID, got %s", geometryTypeName(stripped))
+			}
+			info := GetGeometryInfo(test.input.GetType())
+			if stripped.GetType() != BuildWKBType(info.BaseType, info.CoordType, false) {
+				t.Errorf("Expected the coordinate type to be kept, got %s", geometryTypeName(stripped))
+			}
+		})
+	}
+}
+
+func TestConvertCollection(t *testing.T) {
+	gc := &GeometryCollection{Geometries: []Geometry{
+		&PointZS{SRID: 4326, X: 1, Y: 2, Z: 3},
+		&LineStringZ{Points: []PointZ{{X: 1, Y: 2, Z: 3}}},
+	}}
+
+	result, err := ForceXY(gc)
+	if err != nil {
+		t.Fatalf("ForceXY failed: %v", err)
+	}
+	expected := &GeometryCollection{Geometries: []Geometry{
+		&PointS{SRID: 4326, X: 1, Y: 2},
+		&LineString{Points: []Point{{X: 1, Y: 2}}},
+	}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, result)
+	}
+
+	result, err = WithSRID(gc, 3857)
+	if err != nil {
+		t.Fatalf("WithSRID failed: %v", err)
+	}
+	if !reflect.DeepEqual(result, &GeometryCollectionS{SRID: 3857, Geometries: gc.Geometries}) {
+		t.Errorf("Expected the members to be kept, got %#v", result)
+	}
+}
+
+// foreignGeometry is a Geometry implemented outside this package
+type foreignGeometry struct{ Geometry }
+
+func TestConvertUnsupported(t *testing.T) {
+	gc := &GeometryCollection{Geometries: []Geometry{foreignGeometry{&Point{}}}}
+	for _, g := range []Geometry{foreignGeometry{&Point{}}, gc} {
+		if _, err := ForceXY(g); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("Expected ErrUnsupportedType for %s, got %v", geometryTypeName(g), err)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package postgis
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// ErrLayoutMismatch is returned when a CoordSeq does not have the layout of
+// the requested point type, or its length is not a multiple of its stride
+var ErrLayoutMismatch = errors.New("coordinate sequence layout mismatch")
+
+// String returns the name of the layout, such as "XYZM"
+func (c CoordinateType) String() string {
+	switch c {
+	case CoordXY:
+		return "XY"
+	case CoordXYZ:
+		return "XYZ"
+	case CoordXYM:
+		return "XYM"
+	case CoordXYZM:
+		return "XYZM"
+	}
+	return fmt.Sprintf("CoordinateType(%d)", int(c))
+}
+
+// Stride returns the number of values per coordinate: 2 for CoordXY, 3 for
+// CoordXYZ and CoordXYM and 4 for CoordXYZM
+func (c CoordinateType) Stride() int {
+	switch c {
+	case CoordXYZ, CoordXYM:
+		return 3
+	case CoordXYZM:
+		return 4
+	}
+	return 2
+}
+
+// CoordSeq is a sequence of coordinates stored as one flat slice, X, Y and
+// then Z and/or M for each point as set by Layout, which is how numeric code
+// usually expects them. A CoordSeq can share its storage with the Points of
+// a LineString or the rings of a Polygon, since point types are laid out as
+// consecutive float64 values: SeqOf and PointsOf convert between the two
+// without copying.
+type CoordSeq struct {
+	Layout CoordinateType
+	Flat   []float64
+}
+
+// NewCoordSeq returns a sequence using flat as its storage
+func NewCoordSeq(layout CoordinateType, flat []float64) (CoordSeq, error) {
+	if layout < CoordXY || layout > CoordXYZM {
+		return CoordSeq{}, fmt.Errorf("%w: unknown layout %v", ErrLayoutMismatch, layout)
+	}
+	if len(flat)%layout.Stride() != 0 {
+		return CoordSeq{}, fmt.Errorf("%w: %d values do not divide into coordinates of %d", ErrLayoutMismatch, len(flat), layout.Stride())
+	}
+	return CoordSeq{Layout: layout, Flat: flat}, nil
+}
+
+// Stride returns the number of values per coordinate
+func (s CoordSeq) Stride() int {
+	return s.Layout.Stride()
+}
+
+// Len returns the number of coordinates
+func (s CoordSeq) Len() int {
+	return len(s.Flat) / s.Stride()
+}
+
+// At returns the values of coordinate i. The slice shares the storage of the
+// sequence.
+func (s CoordSeq) At(i int) []float64 {
+	stride := s.Stride()
+	return s.Flat[i*stride : (i+1)*stride : (i+1)*stride]
+}
+
+// XY returns the X and Y of coordinate i
+func (s CoordSeq) XY(i int) Point {
+	stride := s.Stride()
+	return Point{X: s.Flat[i*stride], Y: s.Flat[i*stride+1]}
+}
+
+// Slice returns coordinates i to j-1, sharing the storage of the sequence
+func (s CoordSeq) Slice(i, j int) CoordSeq {
+	stride := s.Stride()
+	return CoordSeq{Layout: s.Layout, Flat: s.Flat[i*stride : j*stride]}
+}
+
+// Clone returns a copy of the sequence with its own storage
+func (s CoordSeq) Clone() CoordSeq {
+	return CoordSeq{Layout: s.Layout, Flat: append([]float64(nil), s.Flat...)}
+}
+
+// Convert returns a copy of the sequence with another layout. Dimensions the
+// sequence lacks are set to zero and the ones layout lacks are dropped.
+func (s CoordSeq) Convert(layout CoordinateType) CoordSeq {
+	if layout == s.Layout {
+		return s.Clone()
+	}
+	n := s.Len()
+	result := CoordSeq{Layout: layout, Flat: make([]float64, 0, n*layout.Stride())}
+	for i := 0; i < n; i++ {
+		xy := s.XY(i)
+		z, m := s.zm(i)
+		result.Flat = append(result.Flat, xy.X, xy.Y)
+		switch layout {
+		case CoordXYZ:
+			result.Flat = append(result.Flat, z)
+		case CoordXYM:
+			result.Flat = append(result.Flat, m)
+		case CoordXYZM:
+			result.Flat = append(result.Flat, z, m)
+		}
+	}
+	return result
+}
+
+// zm returns the Z and M of coordinate i, zero when the layout lacks them
+func (s CoordSeq) zm(i int) (z, m float64) {
+	c := s.At(i)
+	switch s.Layout {
+	case CoordXYZ:
+		z = c[2]
+	case CoordXYM:
+		m = c[2]
+	case CoordXYZM:
+		z, m = c[2], c[3]
+	}
+	return z, m
+}
+
+// SeqOf returns a sequence viewing the coordinates of points, without
+// copying. Changes to either are visible in the other.
+func SeqOf[P PointLike](points []P) CoordSeq {
+	layout := coordinateType[P]()
+	if cap(points) == 0 {
+		return CoordSeq{Layout: layout}
+	}
+	stride := layout.Stride()
+	flat := unsafe.Slice((*float64)(unsafe.Pointer(unsafe.SliceData(points))), cap(points)*stride)
+	return CoordSeq{Layout: layout, Flat: flat[:len(points)*stride]}
+}
+
+// PointsOf returns the coordinates of s as points of type P, without
+// copying. Changes to either are visible in the other. The layout of s must
+// match P; use Convert first to change it.
+func PointsOf[P PointLike](s CoordSeq) ([]P, error) {
+	layout := coordinateType[P]()
+	if s.Layout != layout {
+		return nil, fmt.Errorf("%w: %v coordinates cannot be viewed as %T", ErrLayoutMismatch, s.Layout, *new(P))
+	}
+	stride := layout.Stride()
+	if len(s.Flat)%stride != 0 {
+		return nil, fmt.Errorf("%w: %d values do not divide into coordinates of %d", ErrLayoutMismatch, len(s.Flat), stride)
+	}
+	if cap(s.Flat) == 0 {
+		return nil, nil
+	}
+	points := unsafe.Slice((*P)(unsafe.Pointer(unsafe.SliceData(s.Flat))), cap(s.Flat)/stride)
+	return points[:len(s.Flat)/stride], nil
+}
+
+// Seq returns the points of the line as a CoordSeq sharing their storage
+func (ls LineStringOf[P]) Seq() CoordSeq { return SeqOf(ls.Points) }
+
+// Seq returns the points of the line as a CoordSeq sharing their storage
+func (ls LineStringSOf[P]) Seq() CoordSeq { return SeqOf(ls.Points) }
+
+// RingSeqs returns the rings of the polygon as CoordSeqs sharing their
+// storage
+func (p PolygonOf[P]) RingSeqs() []CoordSeq { return ringSeqs(p.Rings) }
+
+// RingSeqs returns the rings of the polygon as CoordSeqs sharing their
+// storage
+func (p PolygonSOf[P]) RingSeqs() []CoordSeq { return ringSeqs(p.Rings) }
+
+func ringSeqs[P PointLike](rings [][]P) []CoordSeq {
+	seqs := make([]CoordSeq, len(rings))
+	for i, ring := range rings {
+		seqs[i] = SeqOf(ring)
+	}
+	return seqs
+}
+
+// LineStringFromSeq returns a LineString whose points share the storage of s
+func LineStringFromSeq[P PointLike](s CoordSeq) (LineStringOf[P], error) {
+	points, err := PointsOf[P](s)
+	return LineStringOf[P]{Points: points}, err
+}
+
+// PolygonFromSeqs returns a Polygon whose rings share the storage of rings
+func PolygonFromSeqs[P PointLike](rings []CoordSeq) (PolygonOf[P], error) {
+	polygon := PolygonOf[P]{Rings: make([][]P, len(rings))}
+	for i, ring := range rings {
+		points, err := PointsOf[P](ring)
+		if err != nil {
+			return PolygonOf[P]{}, err
+		}
+		polygon.Rings[i] = points
+	}
+	return polygon, nil
+}
@@ -0,0 +1,112 @@
+package postgis
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCoordSeqViews(t *testing.T) {
+	ls := LineStringZ{Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}, {X: 7, Y: 8, Z: 9}}}
+	seq := ls.Seq()
+	if seq.Layout != CoordXYZ || seq.Stride() != 3 || seq.Len() != 3 {
+		t.Fatalf("Expected 3 XYZ coordinates, got %v with %d values", seq.Layout, len(seq.Flat))
+	}
+	if !reflect.DeepEqual(seq.Flat, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Unexpected flat values %v", seq.Flat)
+	}
+	if !reflect.DeepEqual(seq.At(1), []float64{4, 5, 6}) || seq.XY(2) != (Point{X: 7, Y: 8}) {
+		t.Errorf("Unexpected coordinates %v and %v", seq.At(1), seq.XY(2))
+	}
+
+	// The sequence and the line share their storage
+	seq.Flat[4] = 50
+	if ls.Points[1].Y != 50 {
+		t.Errorf("Expected the change to be visible in the line, got %v", ls.Points[1])
+	}
+	ls.Points[2].Z = 90
+	if seq.At(2)[2] != 90 {
+		t.Errorf("Expected the change to be visible in the sequence, got %v", seq.At(2))
+	}
+
+	sub := seq.Slice(1, 3)
+	points, err := PointsOf[PointZ](sub)
+	if err != nil {
+		t.Fatalf("PointsOf failed: %v", err)
+	}
+	if !reflect.DeepEqual(points, ls.Points[1:]) || &points[0] != &ls.Points[1] {
+		t.Errorf("Expected a view of the last two points, got %v", points)
+	}
+}
+
+func TestCoordSeqFromFlat(t *testing.T) {
+	seq, err := NewCoordSeq(CoordXYM, []float64{0, 0, 1, 1, 0, 2, 1, 1, 3, 0, 0, 4})
+	if err != nil {
+		t.Fatalf("NewCoordSeq failed: %v", err)
+	}
+	ls, err := LineStringFromSeq[PointM](seq)
+	if err != nil {
+		t.Fatalf("LineStringFromSeq failed: %v", err)
+	}
+	if len(ls.Points) != 4 || ls.Points[2] != (PointM{X: 1, Y: 1, M: 3}) {
+		t.Errorf("Unexpected points %v", ls.Points)
+	}
+
+	// A line backed by a sequence encodes like any other
+	value, err := ls.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	var decoded LineStringM
+	if err := decoded.Scan(value); err != nil || !reflect.DeepEqual(decoded, ls) {
+		t.Errorf("Expected %v, got %v (%v)", ls, decoded, err)
+	}
+
+	polygon, err := PolygonFromSeqs[PointM]([]CoordSeq{seq})
+	if err != nil {
+		t.Fatalf("PolygonFromSeqs failed: %v", err)
+	}
+	if rings := polygon.RingSeqs(); len(rings) != 1 || &rings[0].Flat[0] != &seq.Flat[0] {
+		t.Errorf("Expected the ring to share the storage of the sequence")
+	}
+
+	if _, err := NewCoordSeq(CoordXYZM, []float64{1, 2, 3}); !errors.Is(err, ErrLayoutMismatch) {
+		t.Errorf("Expected ErrLayoutMismatch for a partial coordinate, got %v", err)
+	}
+	if _, err := PointsOf[PointZ](seq); !errors.Is(err, ErrLayoutMismatch) {
+		t.Errorf("Expected ErrLayoutMismatch for another layout, got %v", err)
+	}
+}
+
+func TestCoordSeqConvert(t *testing.T) {
+	seq := SeqOf([]PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}})
+	tests := []struct {
+		layout   CoordinateType
+		expected []float64
+	}{
+		{CoordXY, []float64{1, 2, 5, 6}},
+		{CoordXYZ, []float64{1, 2, 3, 5, 6, 7}},
+		{CoordXYM, []float64{1, 2, 4, 5, 6, 8}},
+		{CoordXYZM, []float64{1, 2, 3, 4, 5, 6, 7, 8}},
+	}
+	for _, test := range tests {
+		converted := seq.Convert(test.layout)
+		if converted.Layout != test.layout || !reflect.DeepEqual(converted.Flat, test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.layout, test.expected, converted.Flat)
+		}
+		converted.Flat[0] = -1
+		if seq.Flat[0] != 1 {
+			t.Errorf("%v: expected a copy", test.layout)
+		}
+	}
+
+	// Missing dimensions are zero
+	xy := SeqOf([]Point{{X: 1, Y: 2}}).Convert(CoordXYZM)
+	if !reflect.DeepEqual(xy.Flat, []float64{1, 2, 0, 0}) {
+		t.Errorf("Expected zero Z and M, got %v", xy.Flat)
+	}
+
+	if empty := SeqOf[Point](nil); empty.Len() != 0 {
+		t.Errorf("Expected an empty sequence, got %v", empty)
+	}
+}
@@ -0,0 +1,169 @@
+package postgis
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"reflect"
+)
+
+// AppendCopyField appends g as a field of the binary COPY format, its EWKB
+// preceded by its length as a 32-bit big endian integer, which
+// geometry_recv reads in COPY ... FROM STDIN (FORMAT binary). A nil g or
+// nil pointer is written as NULL.
+func AppendCopyField(dst []byte, g Geometry) ([]byte, error) {
+	if g, _ = copyGeometry(g); g == nil {
+		return binary.BigEndian.AppendUint32(dst, 0xFFFFFFFF), nil
+	}
+	field, err := AppendEWKB(append(dst, 0, 0, 0, 0), g)
+	if err != nil {
+		return dst, err
+	}
+	binary.BigEndian.PutUint32(field[len(dst):], uint32(len(field)-len(dst)-4))
+	return field, nil
+}
+
+// CopyFromSource is a source of rows for bulk loading, the interface of
+// pgx.CopyFromSource
+type CopyFromSource interface {
+	// Next moves to the next row, returning false when there are no more
+	Next() bool
+	// Values returns the values of the current row
+	Values() ([]any, error)
+	// Err returns the error that stopped Next, if any
+	Err() error
+}
+
+// CopyFrom wraps src, such as pgx.CopyFromRows(rows), for pgx's binary
+// CopyFrom: the geometries and Null geometries among the values of each row
+// are replaced by their EWKB, which pgx sends as the binary field of
+// geometry columns.
+//
+//	count, err := conn.CopyFrom(ctx, pgx.Identifier{"shops"}, []string{"name", "location"},
+//		postgis.CopyFrom(pgx.CopyFromRows(rows)))
+//
+// Like Value, the geometries are validated first if SetValidateOnValue is
+// on.
+func CopyFrom(src CopyFromSource) CopyFromSource {
+	return &copySource{src: src}
+}
+
+// CopyFromRows returns a CopyFromSource of rows for pgx's CopyFrom, like
+// CopyFrom(pgx.CopyFromRows(rows))
+func CopyFromRows(rows [][]any) CopyFromSource {
+	return CopyFrom(&rowsSource{rows: rows, index: -1})
+}
+
+// copySource encodes the geometries of the rows of src
+type copySource struct {
+	src    CopyFromSource
+	values []any
+}
+
+func (s *copySource) Next() bool { return s.src.Next() }
+func (s *copySource) Err() error { return s.src.Err() }
+
+func (s *copySource) Values() ([]any, error) {
+	row, err := s.src.Values()
+	if err != nil {
+		return nil, err
+	}
+	// The row is copied rather than modified, as it may belong to the caller
+	s.values = append(s.values[:0], row...)
+	for i, value := range s.values {
+		g, isGeometry := copyGeometry(value)
+		if !isGeometry {
+			continue
+		}
+		if g == nil {
+			s.values[i] = nil
+			continue
+		}
+		if validateOnValue.Load() {
+			if err := ValidateDetail(g).Err(); err != nil {
+				return nil, err
+			}
+		}
+		if s.values[i], err = AppendEWKB(nil, g); err != nil {
+			return nil, err
+		}
+	}
+	return s.values, nil
+}
+
+// copyGeometry returns the geometry held by a value of a row, which may be a
+// geometry, a geometry value such as a PointS rather than a *PointS, or a
+// Null. The geometry is nil for NULL values and nil pointers.
+func copyGeometry(value any) (Geometry, bool) {
+	switch v := value.(type) {
+	case Geometry:
+		if r := reflect.ValueOf(v); r.Kind() == reflect.Pointer && r.IsNil() {
+			return nil, true
+		}
+		return v, true
+	case interface{ geometry() (Geometry, bool) }:
+		g, valid := v.geometry()
+		if !valid {
+			return nil, true
+		}
+		return g, true
+	case ewkbAppender:
+		pointer := reflect.New(reflect.TypeOf(v))
+		pointer.Elem().Set(reflect.ValueOf(v))
+		g, ok := pointer.Interface().(Geometry)
+		return g, ok
+	}
+	return nil, false
+}
+
+// rowsSource is a CopyFromSource of a slice of rows
+type rowsSource struct {
+	rows  [][]any
+	index int
+}
+
+func (s *rowsSource) Next() bool {
+	s.index++
+	return s.index < len(s.rows)
+}
+
+func (s *rowsSource) Values() ([]any, error) { return s.rows[s.index], nil }
+func (s *rowsSource) Err() error             { return nil }
+
+// CopyInStmt is a statement prepared from lib/pq's pq.CopyIn, a *sql.Stmt
+type CopyInStmt interface {
+	Exec(args ...any) (sql.Result, error)
+}
+
+// CopyIn loads the rows of src through stmt, prepared from lib/pq's
+// pq.CopyIn, and returns their number. lib/pq uses the text COPY format, in
+// which geometries are sent as the hex EWKB of their Value. The final Exec
+// without arguments that flushes the data is made by CopyIn; the statement
+// and its transaction are left to the caller.
+//
+//	stmt, err := tx.Prepare(pq.CopyIn("shops", "name", "location"))
+//	count, err := postgis.CopyIn(stmt, postgis.CopyFromRows(rows))
+//	err = stmt.Close()
+func CopyIn(stmt CopyInStmt, src CopyFromSource) (int64, error) {
+	// The geometries are left for database/sql to convert with Value
+	if s, ok := src.(*copySource); ok {
+		src = s.src
+	}
+	var count int64
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			return count, err
+		}
+		if _, err := stmt.Exec(values...); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := src.Err(); err != nil {
+		return count, err
+	}
+	if _, err := stmt.Exec(); err != nil {
+		return count, err
+	}
+	return count, nil
+}
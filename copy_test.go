@@ -0,0 +1,110 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAppendCopyField(t *testing.T) {
+	p := &PointS{SRID: 4326, X: 1, Y: 2}
+	field, err := AppendCopyField([]byte("prefix"), p)
+	if err != nil {
+		t.Fatalf("AppendCopyField failed: %v", err)
+	}
+	ewkb := mustWriteEWKB(t, p)
+	expected := append([]byte("prefix\x00\x00\x00\x19"), ewkb...)
+	if len(ewkb) != 0x19 || !bytes.Equal(field, expected) {
+		t.Errorf("Expected %x, got %x", expected, field)
+	}
+
+	for _, g := range []Geometry{nil, (*Point)(nil)} {
+		if field, _ := AppendCopyField(nil, g); !bytes.Equal(field, []byte{0xFF, 0xFF, 0xFF, 0xFF}) {
+			t.Errorf("Expected NULL, got %x", field)
+		}
+	}
+}
+
+func TestCopyFrom(t *testing.T) {
+	p := &PointS{SRID: 4326, X: 1, Y: 2}
+	rows := [][]any{
+		{"first", p, NewNull(p)},
+		{"second", PointZ{X: 1, Y: 2, Z: 3}, Null[*PointS]{}},
+		{"third", (*PointS)(nil), nil},
+	}
+	src := CopyFromRows(rows)
+	var got [][]any
+	for src.Next() {
+		values, err := src.Values()
+		if err != nil {
+			t.Fatalf("Values failed: %v", err)
+		}
+		got = append(got, append([]any(nil), values...))
+	}
+	if src.Err() != nil {
+		t.Fatalf("Err: %v", src.Err())
+	}
+	expected := [][]any{
+		{"first", mustWriteEWKB(t, p), mustWriteEWKB(t, p)},
+		{"second", mustWriteEWKB(t, &PointZ{X: 1, Y: 2, Z: 3}), nil},
+		{"third", nil, nil},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	// The rows of the caller are left alone
+	if rows[0][1] != p {
+		t.Errorf("Expected the rows to be unchanged, got %v", rows[0])
+	}
+
+	SetValidateOnValue(true)
+	defer SetValidateOnValue(false)
+	bowtie := &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 0}}}}
+	src = CopyFromRows([][]any{{bowtie}})
+	src.Next()
+	var validationErr *ValidationError
+	if _, err := src.Values(); !errors.As(err, &validationErr) {
+		t.Errorf("Expected *ValidationError, got %v", err)
+	}
+}
+
+// copyInStmt records the arguments of Exec like a pq.CopyIn statement
+type copyInStmt struct {
+	calls [][]driver.Value
+	err   error
+}
+
+func (s *copyInStmt) Exec(args ...any) (sql.Result, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		value, err := driver.DefaultParameterConverter.ConvertValue(arg)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	s.calls = append(s.calls, values)
+	return driver.RowsAffected(0), s.err
+}
+
+func TestCopyIn(t *testing.T) {
+	p := &PointS{SRID: 4326, X: 1, Y: 2}
+	var stmt copyInStmt
+	count, err := CopyIn(&stmt, CopyFromRows([][]any{{int64(1), p}, {int64(2), Null[*PointS]{}}}))
+	if err != nil || count != 2 {
+		t.Fatalf("Expected 2 rows, got %d (%v)", count, err)
+	}
+	hexEWKB, _ := p.Value()
+	expected := [][]driver.Value{{int64(1), hexEWKB}, {int64(2), nil}, {}}
+	if !reflect.DeepEqual(stmt.calls, expected) {
+		t.Errorf("Expected %v, got %v", expected, stmt.calls)
+	}
+
+	failing := copyInStmt{err: errors.New("copy failed")}
+	if _, err := CopyIn(&failing, CopyFromRows([][]any{{p}})); err != failing.err {
+		t.Errorf("Expected the Exec error, got %v", err)
+	}
+}
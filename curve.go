@@ -0,0 +1,398 @@
+package postgis
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+)
+
+// ErrInvalidCurve is returned by CurveToLine for a CircularString that does
+// not have an odd number of at least three points
+var ErrInvalidCurve = errors.New("invalid curve")
+
+// DefaultCurveSegments is the number of segments per quarter circle that
+// CurveToLine uses when none is given, like ST_CurveToLine
+const DefaultCurveSegments = 32
+
+// Curve is implemented by the curves of coordinate type P: *LineStringOf[P],
+// *CircularStringOf[P] and *CompoundCurveOf[P]. Curves are the members of
+// CompoundCurves, CurvePolygons and MultiCurves.
+type Curve[P PointLike] interface {
+	Geometry
+	// linearize returns the points of the curve with every arc approximated
+	// by perQuadrant segments per quarter circle
+	linearize(perQuadrant int) ([]P, error)
+}
+
+// Surface is implemented by the surfaces of coordinate type P:
+// *PolygonOf[P] and *CurvePolygonOf[P]. Surfaces are the members of
+// MultiSurfaces.
+type Surface[P PointLike] interface {
+	Geometry
+	// linearizeRings returns the rings of the surface with every arc
+	// approximated by perQuadrant segments per quarter circle
+	linearizeRings(perQuadrant int) ([][]P, error)
+}
+
+// newCurve returns an empty curve for a member type code, or false when
+// curves cannot have that type
+func newCurve[P PointLike](baseType uint32) (Curve[P], bool) {
+	switch baseType {
+	case WKBLineString:
+		return &LineStringOf[P]{}, true
+	case WKBCircularString:
+		return &CircularStringOf[P]{}, true
+	case WKBCompoundCurve:
+		return &CompoundCurveOf[P]{}, true
+	}
+	return nil, false
+}
+
+// newSegment is newCurve for the members of a CompoundCurve, which cannot be
+// CompoundCurves themselves
+func newSegment[P PointLike](baseType uint32) (Curve[P], bool) {
+	if baseType == WKBCompoundCurve {
+		return nil, false
+	}
+	return newCurve[P](baseType)
+}
+
+func newSurface[P PointLike](baseType uint32) (Surface[P], bool) {
+	switch baseType {
+	case WKBPolygon:
+		return &PolygonOf[P]{}, true
+	case WKBCurvePolygon:
+		return &CurvePolygonOf[P]{}, true
+	}
+	return nil, false
+}
+
+// readMembersHelper reads members of the types newMember accepts, which must
+// have the coordinate type of the container and no SRID
+func readMembersHelper[G Geometry](reader io.Reader, count uint32, newMember func(baseType uint32) (G, bool)) ([]G, error) {
+	capacity, err := checkCount(reader, count, minGeometrySize)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]G, 0, capacity)
+	for i := uint32(0); i < count; i++ {
+		byteOrder, wkbType, err := readEWKBHeader(reader)
+		if err != nil {
+			return nil, err
+		}
+		info := GetGeometryInfo(wkbType)
+		g, ok := newMember(info.BaseType)
+		if !ok {
+			return nil, fmt.Errorf("%w: member of type %d is not allowed", ErrTypeMismatch, info.BaseType)
+		}
+		if err := readEWKBBody(reader, byteOrder, info, g); err != nil {
+			return nil, err
+		}
+		members = append(members, g)
+	}
+	return members, nil
+}
+
+// CurveToLine approximates the arcs of g with straight segments, like
+// ST_CurveToLine, using segmentsPerQuadrant segments per quarter circle or
+// DefaultCurveSegments when it is below 1. CircularStrings and CompoundCurves
+// become LineStrings, CurvePolygons become Polygons, MultiCurves become
+// MultiLineStrings and MultiSurfaces become MultiPolygons, keeping the SRID.
+// The members of GeometryCollections are converted as well and other
+// geometries are returned unchanged. Z and M are interpolated along each arc
+// between its control points.
+func CurveToLine(g Geometry, segmentsPerQuadrant int) (Geometry, error) {
+	if segmentsPerQuadrant < 1 {
+		segmentsPerQuadrant = DefaultCurveSegments
+	}
+	if l, ok := g.(linearizer); ok {
+		return l.curveToLine(segmentsPerQuadrant)
+	}
+	return g, nil
+}
+
+// linearizer is implemented by the curved geometry types and by
+// GeometryCollections, whose members may be curved
+type linearizer interface {
+	curveToLine(perQuadrant int) (Geometry, error)
+}
+
+// Implement Curve and Surface interfaces for the linear types
+func (ls *LineStringOf[P]) linearize(int) ([]P, error) { return slices.Clone(ls.Points), nil }
+
+func (p *PolygonOf[P]) linearizeRings(int) ([][]P, error) {
+	rings := make([][]P, len(p.Rings))
+	for i, ring := range p.Rings {
+		rings[i] = slices.Clone(ring)
+	}
+	return rings, nil
+}
+
+// Implement Curve and Surface interfaces for the curved types
+func (cs *CircularStringOf[P]) linearize(perQuadrant int) ([]P, error) {
+	return linearizeArcs(cs.Points, perQuadrant)
+}
+
+func (c *CompoundCurveOf[P]) linearize(perQuadrant int) ([]P, error) {
+	return joinCurves(c.Curves, perQuadrant)
+}
+
+func (p *CurvePolygonOf[P]) linearizeRings(perQuadrant int) ([][]P, error) {
+	return linearizeCurves(p.Rings, perQuadrant)
+}
+
+// Implement linearizer interface for the curved types
+func (cs *CircularStringOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	points, err := linearizeArcs(cs.Points, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newLineString(points, nil), nil
+}
+
+func (cs *CircularStringSOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	points, err := linearizeArcs(cs.Points, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newLineString(points, &cs.SRID), nil
+}
+
+func (c *CompoundCurveOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	points, err := joinCurves(c.Curves, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newLineString(points, nil), nil
+}
+
+func (c *CompoundCurveSOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	points, err := joinCurves(c.Curves, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newLineString(points, &c.SRID), nil
+}
+
+func (p *CurvePolygonOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	rings, err := linearizeCurves(p.Rings, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newPolygon(rings, nil), nil
+}
+
+func (p *CurvePolygonSOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	rings, err := linearizeCurves(p.Rings, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newPolygon(rings, &p.SRID), nil
+}
+
+func (m *MultiCurveOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	lines, err := curveLineStrings(m.Curves, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newMultiLineString(lines, nil), nil
+}
+
+func (m *MultiCurveSOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	lines, err := curveLineStrings(m.Curves, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newMultiLineString(lines, &m.SRID), nil
+}
+
+func (m *MultiSurfaceOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	polygons, err := surfacePolygons(m.Surfaces, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newMultiPolygon(polygons, nil), nil
+}
+
+func (m *MultiSurfaceSOf[P]) curveToLine(perQuadrant int) (Geometry, error) {
+	polygons, err := surfacePolygons(m.Surfaces, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return newMultiPolygon(polygons, &m.SRID), nil
+}
+
+func (gc *GeometryCollection) curveToLine(perQuadrant int) (Geometry, error) {
+	members, err := curveToLineMembers(gc.Geometries, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return &GeometryCollection{Geometries: members}, nil
+}
+
+func (gc *GeometryCollectionS) curveToLine(perQuadrant int) (Geometry, error) {
+	members, err := curveToLineMembers(gc.Geometries, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	return &GeometryCollectionS{SRID: gc.SRID, Geometries: members}, nil
+}
+
+func curveToLineMembers(geometries []Geometry, perQuadrant int) ([]Geometry, error) {
+	members := make([]Geometry, len(geometries))
+	for i, g := range geometries {
+		member, err := CurveToLine(g, perQuadrant)
+		if err != nil {
+			return nil, err
+		}
+		members[i] = member
+	}
+	return members, nil
+}
+
+func linearizeCurves[P PointLike](curves []Curve[P], perQuadrant int) ([][]P, error) {
+	result := make([][]P, len(curves))
+	for i, c := range curves {
+		points, err := c.linearize(perQuadrant)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = points
+	}
+	return result, nil
+}
+
+func curveLineStrings[P PointLike](curves []Curve[P], perQuadrant int) ([]LineStringOf[P], error) {
+	lines, err := linearizeCurves(curves, perQuadrant)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]LineStringOf[P], len(lines))
+	for i, points := range lines {
+		result[i] = LineStringOf[P]{Points: points}
+	}
+	return result, nil
+}
+
+func surfacePolygons[P PointLike](surfaces []Surface[P], perQuadrant int) ([]PolygonOf[P], error) {
+	result := make([]PolygonOf[P], len(surfaces))
+	for i, s := range surfaces {
+		rings, err := s.linearizeRings(perQuadrant)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = PolygonOf[P]{Rings: rings}
+	}
+	return result, nil
+}
+
+// joinCurves linearizes the members of a CompoundCurve into one line,
+// dropping the point where one member ends and the next one starts
+func joinCurves[P PointLike](curves []Curve[P], perQuadrant int) ([]P, error) {
+	var result []P
+	for _, c := range curves {
+		points, err := c.linearize(perQuadrant)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(result); n > 0 && len(points) > 0 && result[n-1] == points[0] {
+			points = points[1:]
+		}
+		result = append(result, points...)
+	}
+	return result, nil
+}
+
+// linearizeArcs approximates the arcs of a CircularString
+func linearizeArcs[P PointLike](points []P, perQuadrant int) ([]P, error) {
+	if len(points) == 0 {
+		return nil, nil
+	}
+	if len(points) < 3 || len(points)%2 == 0 {
+		return nil, fmt.Errorf("%w: circular string with %d points", ErrInvalidCurve, len(points))
+	}
+	result := []P{points[0]}
+	for i := 0; i+2 < len(points); i += 2 {
+		result = appendArc(result, points[i], points[i+1], points[i+2], perQuadrant)
+	}
+	return result, nil
+}
+
+// appendArc appends the points approximating the arc from p0 through p1 to
+// p2, leaving out p0. An arc whose start and end are equal is the full
+// circle through p1, drawn counter-clockwise, and an arc through collinear
+// points is a straight line.
+func appendArc[P PointLike](dst []P, p0, p1, p2 P, perQuadrant int) []P {
+	a, b, c := p0.xy(), p1.xy(), p2.xy()
+	if a == b || b == c {
+		return append(dst, p1, p2)
+	}
+
+	var center Point
+	ccw := true
+	if a == c {
+		center = Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+	} else {
+		turn := orientation(a, b, c)
+		if turn == 0 {
+			return append(dst, p1, p2)
+		}
+		center = circumcenter(a, b, c)
+		ccw = turn > 0
+	}
+
+	start := math.Atan2(a.Y-center.Y, a.X-center.X)
+	middle := sweepAngle(start, math.Atan2(b.Y-center.Y, b.X-center.X), ccw)
+	sweep := 2 * math.Pi
+	if a != c {
+		sweep = sweepAngle(start, math.Atan2(c.Y-center.Y, c.X-center.X), ccw)
+	}
+
+	radius := distance(center, a)
+	_, _, z0, m0 := pointValues(p0)
+	_, _, z1, m1 := pointValues(p1)
+	_, _, z2, m2 := pointValues(p2)
+	step := math.Pi / 2 / float64(perQuadrant)
+	n := int(math.Ceil(math.Abs(sweep)/step - 1e-9))
+	for i := 1; i < n; i++ {
+		angle := sweep * float64(i) / float64(n)
+		// Z and M change linearly from p0 to p1 and from p1 to p2
+		var z, m float64
+		if math.Abs(angle) <= math.Abs(middle) {
+			f := angle / middle
+			z, m = z0+(z1-z0)*f, m0+(m1-m0)*f
+		} else {
+			f := (angle - middle) / (sweep - middle)
+			z, m = z1+(z2-z1)*f, m1+(m2-m1)*f
+		}
+		x := center.X + radius*math.Cos(start+angle)
+		y := center.Y + radius*math.Sin(start+angle)
+		dst = append(dst, pointFrom[P](x, y, z, m))
+	}
+	return append(dst, p2)
+}
+
+// circumcenter returns the center of the circle through three points that
+// are not collinear
+func circumcenter(a, b, c Point) Point {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	return Point{X: a.X + (cy*b2-by*c2)/d, Y: a.Y + (bx*c2-cx*b2)/d}
+}
+
+// sweepAngle returns the angle from one direction to another, turning
+// counter-clockwise (positive) or clockwise (negative)
+func sweepAngle(from, to float64, ccw bool) float64 {
+	d := math.Mod(to-from, 2*math.Pi)
+	switch {
+	case ccw && d <= 0:
+		d += 2 * math.Pi
+	case !ccw && d >= 0:
+		d -= 2 * math.Pi
+	}
+	return d
+}
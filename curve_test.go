@@ -0,0 +1,252 @@
+package postgis
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCurveScan(t *testing.T) {
+	// CIRCULARSTRING(0 0,1 1,2 0) with SRID 4326, as returned by PostGIS
+	var cs CircularStringS
+	if err := cs.Scan("0108000020E61000000300000000000000000000000000000000000000000000000000F03F000000000000F03F00000000000000400000000000000000"); err != nil {
+		t.Fatalf("Failed to scan: %v", err)
+	}
+	expected := CircularStringS{SRID: 4326, Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}
+	if !reflect.DeepEqual(cs, expected) {
+		t.Errorf("Expected %v, got %v", expected, cs)
+	}
+
+	// Curves can be members of GeometryCollections and scanned into Null
+	gc := &GeometryCollection{Geometries: []Geometry{&CircularString{Points: cs.Points}, &CompoundCurve{Curves: []Curve[Point]{&LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 0}}}}}}}
+	var decoded GeometryCollection
+	if err := ReadEWKBBytes(mustWriteEWKB(t, gc), &decoded); err != nil {
+		t.Fatalf("Failed to read collection: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, gc) {
+		t.Errorf("Expected %v, got %v", gc, &decoded)
+	}
+
+	var null Null[Geometry]
+	if err := null.Scan(hex.EncodeToString(mustWriteEWKB(t, &expected))); err != nil {
+		t.Fatalf("Failed to scan into Null: %v", err)
+	}
+	if !null.Valid || !reflect.DeepEqual(null.Geometry, &expected) {
+		t.Errorf("Expected %v, got %v", &expected, null.Geometry)
+	}
+}
+
+func TestCurveMemberTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		member   Geometry
+		geometry Geometry
+	}{
+		{"point in compound curve", &Point{X: 1, Y: 2}, &CompoundCurve{}},
+		{"compound curve in compound curve", &CompoundCurve{}, &CompoundCurve{}},
+		{"polygon in multicurve", &Polygon{}, &MultiCurve{}},
+		{"linestring in multisurface", &LineString{}, &MultiSurface{}},
+		{"dimension", &LineStringZ{}, &CurvePolygon{}},
+		{"SRID", &LineStringS{SRID: 4326}, &MultiCurve{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := append(hostileEWKB(test.geometry.GetType(), 1), mustWriteEWKB(t, test.member)...)
+			if err := ReadEWKBBytes(data, test.geometry); !errors.Is(err, ErrTypeMismatch) {
+				t.Errorf("Expected ErrTypeMismatch, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCurveToLineArc(t *testing.T) {
+	// A clockwise half circle around (1 0)
+	cs := &CircularStringS{SRID: 4326, Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}
+	result, err := CurveToLine(cs, 2)
+	if err != nil {
+		t.Fatalf("CurveToLine failed: %v", err)
+	}
+	ls, ok := result.(*LineStringS)
+	if !ok {
+		t.Fatalf("Expected *LineStringS, got %s", geometryTypeName(result))
+	}
+	if ls.SRID != 4326 || len(ls.Points) != 5 {
+		t.Fatalf("Expected 5 points with SRID 4326, got %v", ls)
+	}
+	s := math.Sqrt(0.5)
+	expected := []Point{{X: 0, Y: 0}, {X: 1 - s, Y: s}, {X: 1, Y: 1}, {X: 1 + s, Y: s}, {X: 2, Y: 0}}
+	for i, p := range ls.Points {
+		if math.Abs(p.X-expected[i].X) > 1e-12 || math.Abs(p.Y-expected[i].Y) > 1e-12 {
+			t.Errorf("Expected point %d to be %v, got %v", i, expected[i], p)
+		}
+	}
+
+	// The default gives 32 segments per quadrant
+	result, err = CurveToLine(cs, 0)
+	if err != nil {
+		t.Fatalf("CurveToLine failed: %v", err)
+	}
+	if n := len(result.(*LineStringS).Points); n != 65 {
+		t.Errorf("Expected 65 points, got %d", n)
+	}
+
+	// A full circle, counter-clockwise from the start
+	result, err = CurveToLine(&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0}}}, 1)
+	if err != nil {
+		t.Fatalf("CurveToLine failed: %v", err)
+	}
+	circle := []Point{{X: 0, Y: 0}, {X: 1, Y: -1}, {X: 2, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}
+	for i, p := range result.(*LineString).Points {
+		if math.Abs(p.X-circle[i].X) > 1e-12 || math.Abs(p.Y-circle[i].Y) > 1e-12 {
+			t.Errorf("Expected point %d to be %v, got %v", i, circle[i], p)
+		}
+	}
+
+	// Collinear control points give a straight line
+	result, err = CurveToLine(&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}}, 4)
+	if err != nil {
+		t.Fatalf("CurveToLine failed: %v", err)
+	}
+	if expected := (&LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}}); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCurveToLineInterpolatesZM(t *testing.T) {
+	cs := &CircularStringZM{Points: []PointZM{{X: 0, Y: 0, Z: 0, M: 10}, {X: 1, Y: 1, Z: 2, M: 20}, {X: 2, Y: 0, Z: 6, M: 30}}}
+	result, err := CurveToLine(cs, 2)
+	if err != nil {
+		t.Fatalf("CurveToLine failed: %v", err)
+	}
+	points := result.(*LineStringZM).Points
+	zs := []float64{0, 1, 2, 4, 6}
+	ms := []float64{10, 15, 20, 25, 30}
+	for i, p := range points {
+		if math.Abs(p.Z-zs[i]) > 1e-12 || math.Abs(p.M-ms[i]) > 1e-12 {
+			t.Errorf("Expected Z %g and M %g at point %d, got %v", zs[i], ms[i], i, p)
+		}
+	}
+}
+
+func TestCurveToLineTypes(t *testing.T) {
+	arc := &CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}
+	closing := &LineString{Points: []Point{{X: 2, Y: 0}, {X: 0, Y: 0}}}
+	compound := &CompoundCurve{Curves: []Curve[Point]{arc, closing}}
+	line := []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}, {X: 0, Y: 0}}
+
+	tests := []struct {
+		input    Geometry
+		expected Geometry
+	}{
+		{compound, &LineString{Points: line}},
+		{&CompoundCurveS{SRID: 3857, Curves: compound.Curves}, &LineStringS{SRID: 3857, Points: line}},
+		{&CurvePolygon{Rings: []Curve[Point]{compound}}, &Polygon{Rings: [][]Point{line}}},
+		{&CurvePolygonS{SRID: 3857, Rings: []Curve[Point]{compound}}, &PolygonS{SRID: 3857, Rings: [][]Point{line}}},
+		{&MultiCurve{Curves: []Curve[Point]{arc, closing}}, &MultiLineString{LineStrings: []LineString{{Points: line[:3]}, {Points: line[2:]}}}},
+		{&MultiSurfaceS{SRID: 3857, Surfaces: []Surface[Point]{&CurvePolygon{Rings: []Curve[Point]{compound}}, &Polygon{Rings: [][]Point{line}}}}, &MultiPolygonS{SRID: 3857, Polygons: []Polygon{{Rings: [][]Point{line}}, {Rings: [][]Point{line}}}}},
+		{&GeometryCollection{Geometries: []Geometry{arc, &Point{X: 1, Y: 2}}}, &GeometryCollection{Geometries: []Geometry{&LineString{Points: line[:3]}, &Point{X: 1, Y: 2}}}},
+		{closing, closing},
+	}
+	for _, test := range tests {
+		t.Run(geometryTypeName(test.input), func(t *testing.T) {
+			// One segment per quadrant reproduces the control points of the
+			// half circle
+			result, err := CurveToLine(test.input, 1)
+			if err != nil {
+				t.Fatalf("CurveToLine failed: %v", err)
+			}
+			if !equalWithin(result, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+// equalWithin reports whether two values are equal, allowing coordinates
+// to differ by rounding errors
+func equalWithin(a, b any) bool {
+	return approxEqual(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func approxEqual(a, b reflect.Value) bool {
+	if a.Kind() != b.Kind() || a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Float64:
+		return math.Abs(a.Float()-b.Float()) <= 1e-9
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return approxEqual(a.Elem(), b.Elem())
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !approxEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !approxEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return a.Interface() == b.Interface()
+}
+
+func TestCurveToLineInvalid(t *testing.T) {
+	for _, g := range []Geometry{
+		&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}},
+		&CompoundCurve{Curves: []Curve[Point]{&CircularString{Points: []Point{{X: 0, Y: 0}}}}},
+	} {
+		if _, err := CurveToLine(g, 4); !errors.Is(err, ErrInvalidCurve) {
+			t.Errorf("Expected ErrInvalidCurve for %v, got %v", g, err)
+		}
+		if detail := ValidateDetail(g); detail.Valid || detail.Reason != ReasonTooFewPoints {
+			t.Errorf("Expected too few points for %v, got %v", g, detail)
+		}
+	}
+}
+
+func TestCurveEnvelope(t *testing.T) {
+	// The envelope follows the arc, not only its control points
+	cs := &CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: -1}, {X: 2, Y: 0}, {X: 3, Y: 1}, {X: 4, Y: 0}}}
+	e := EnvelopeOf(cs)
+	if e.MinX != 0 || e.MaxX != 4 || math.Abs(e.MinY+1) > 1e-12 || math.Abs(e.MaxY-1) > 1e-12 {
+		t.Errorf("Unexpected envelope %v", e)
+	}
+	if e := EnvelopeOf(&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0}}}); math.Abs(e.MaxY-1) > 1e-12 {
+		t.Errorf("Expected the full circle to reach Y 1, got %v", e)
+	}
+}
+
+func TestCurveConvert(t *testing.T) {
+	compound := &CompoundCurveZ{Curves: []Curve[PointZ]{
+		&CircularStringZ{Points: []PointZ{{X: 0, Y: 0, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: 2, Y: 0, Z: 1}}},
+		&LineStringZ{Points: []PointZ{{X: 2, Y: 0, Z: 1}, {X: 0, Y: 0, Z: 1}}},
+	}}
+	result, err := WithSRID(compound, 4326)
+	if err != nil {
+		t.Fatalf("WithSRID failed: %v", err)
+	}
+	result, err = ForceXY(result)
+	if err != nil {
+		t.Fatalf("ForceXY failed: %v", err)
+	}
+	expected := &CompoundCurveS{SRID: 4326, Curves: []Curve[Point]{
+		&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}},
+		&LineString{Points: []Point{{X: 2, Y: 0}, {X: 0, Y: 0}}},
+	}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
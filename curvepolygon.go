@@ -0,0 +1,107 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// CurvePolygonOf is a CurvePolygon whose coordinate type P sets its
+// dimensions: a Polygon whose rings may be LineStrings, CircularStrings or
+// CompoundCurves. The first ring is the exterior and the rest are holes.
+// Rings are stored as pointers and have no SRID.
+type CurvePolygonOf[P PointLike] struct {
+	Rings []Curve[P]
+}
+
+// CurvePolygonSOf is a CurvePolygon with an SRID
+type CurvePolygonSOf[P PointLike] struct {
+	SRID  int32
+	Rings []Curve[P]
+}
+
+// Varying types of CurvePolygons
+type (
+	CurvePolygon   = CurvePolygonOf[Point]
+	CurvePolygonZ  = CurvePolygonOf[PointZ]
+	CurvePolygonM  = CurvePolygonOf[PointM]
+	CurvePolygonZM = CurvePolygonOf[PointZM]
+
+	CurvePolygonS   = CurvePolygonSOf[Point]
+	CurvePolygonZS  = CurvePolygonSOf[PointZ]
+	CurvePolygonMS  = CurvePolygonSOf[PointM]
+	CurvePolygonZMS = CurvePolygonSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (p *CurvePolygonSOf[P]) GetSRID() int32     { return p.SRID }
+func (p *CurvePolygonSOf[P]) SetSRID(srid int32) { p.SRID = srid }
+
+// Implement CollectionGeometry interface for all CurvePolygon types
+func (p *CurvePolygonOf[P]) GetElementCount() uint32  { return getElementCountHelper(p.Rings) }
+func (p *CurvePolygonSOf[P]) GetElementCount() uint32 { return getElementCountHelper(p.Rings) }
+
+func (p *CurvePolygonOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeCollectionHelper(p.Rings, buffer)
+}
+
+func (p *CurvePolygonSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeCollectionHelper(p.Rings, buffer)
+}
+
+func (p *CurvePolygonOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readMembersHelper(reader, count, newCurve[P])
+	if err != nil {
+		return err
+	}
+	p.Rings = geometries
+	return nil
+}
+
+func (p *CurvePolygonSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readMembersHelper(reader, count, newCurve[P])
+	if err != nil {
+		return err
+	}
+	p.Rings = geometries
+	return nil
+}
+
+/** CurvePolygonOf functions **/
+func (p *CurvePolygonOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(p, value)
+}
+
+func (p CurvePolygonOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&p)
+}
+
+func (p CurvePolygonOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, p.GetElementCount(), func(buf *bytes.Buffer) error {
+		return p.WriteElements(buf)
+	})
+}
+
+func (p CurvePolygonOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBCurvePolygon, coordinateType[P](), false)
+}
+
+/** CurvePolygonSOf functions **/
+func (p *CurvePolygonSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(p, value)
+}
+
+func (p CurvePolygonSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&p)
+}
+
+func (p CurvePolygonSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, p.GetElementCount(), func(buf *bytes.Buffer) error {
+		return p.WriteElements(buf)
+	})
+}
+
+func (p CurvePolygonSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBCurvePolygon, coordinateType[P](), true)
+}
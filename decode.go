@@ -0,0 +1,249 @@
+package postgis
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sync/atomic"
+)
+
+// DefaultMaxElementCount is the default limit on the number of points, rings
+// or member geometries read for any single element count
+const DefaultMaxElementCount = 1 << 24
+
+var maxElementCount atomic.Uint32
+
+func init() {
+	maxElementCount.Store(DefaultMaxElementCount)
+}
+
+// SetMaxElementCount sets the largest element count the decoders accept
+// before failing with ErrCorruptEWKB, which bounds the memory a corrupt or
+// hostile value can make them allocate. Zero removes the limit; counts are
+// then only checked against the length of the input when it is known, as
+// with Scan and ReadEWKBBytes.
+func SetMaxElementCount(max uint32) {
+	maxElementCount.Store(max)
+}
+
+// checkCount validates an element count read from the input, each element
+// taking at least minSize bytes, and returns the capacity to allocate for
+// the elements. When the input length is unknown the capacity is bounded and
+// the elements are appended as they are read, so that memory only grows
+// with the data actually received.
+func checkCount(reader io.Reader, count uint32, minSize int) (int, error) {
+	if max := maxElementCount.Load(); max > 0 && count > max {
+		return 0, fmt.Errorf("%w: element count %d exceeds the maximum of %d", ErrCorruptEWKB, count, max)
+	}
+	r, ok := reader.(*ewkbReader)
+	if !ok {
+		return min(int(count), 1024), nil
+	}
+	if remaining := len(r.data) - r.off; uint64(count)*uint64(minSize) > uint64(remaining) {
+		return 0, fmt.Errorf("%w: %d elements of at least %d bytes do not fit in the remaining %d bytes", ErrCorruptEWKB, count, minSize, remaining)
+	}
+	return int(count), nil
+}
+
+// maxNestingDepth is the deepest nesting of geometries that ReadEWKB and the
+// EWKT parser accept, so that hostile input cannot exhaust the stack
+const maxNestingDepth = 32
+
+// enterNested counts one more level of nesting on reader, failing past
+// maxNestingDepth. The returned function leaves the level again.
+func enterNested(reader io.Reader) (func(), error) {
+	var depth *int
+	switch r := reader.(type) {
+	case *ewkbReader:
+		depth = &r.depth
+	case *countingReader:
+		depth = &r.depth
+	default:
+		return func() {}, nil
+	}
+	if *depth >= maxNestingDepth {
+		return nil, fmt.Errorf("%w: geometries nested deeper than %d levels", ErrCorruptEWKB, maxNestingDepth)
+	}
+	*depth++
+	return func() { *depth-- }, nil
+}
+
+// ewkbReader reads EWKB from a byte slice. It implements io.Reader so that it
+// passes through the public ReadElements and ReadPoint methods unchanged,
+// while the decoding helpers take whole blocks of coordinates straight from
+// its buffer instead of going through binary.Read for every value.
+type ewkbReader struct {
+	data  []byte
+	off   int
+	depth int
+}
+
+func newEWKBReader(data []byte) *ewkbReader {
+	return &ewkbReader{data: data}
+}
+
+func (r *ewkbReader) Read(p []byte) (int, error) {
+	if r.off >= len(r.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data[r.off:])
+	r.off += n
+	return n, nil
+}
+
+// next returns the following n bytes without copying them. Like io.ReadFull,
+// it fails with io.EOF when no data is left and io.ErrUnexpectedEOF when
+// only part of it is, leaving the offset at the start of the missing block.
+func (r *ewkbReader) next(n int) ([]byte, error) {
+	if remaining := len(r.data) - r.off; n > remaining {
+		if remaining == 0 && n > 0 {
+			return nil, io.EOF
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b, nil
+}
+
+// ReadEWKBBytes reads a geometry from binary (not hex encoded) EWKB, as sent
+// by drivers using the binary protocol. It decodes directly from data, which
+// is faster than going through ReadEWKB with an io.Reader.
+func ReadEWKBBytes(data []byte, g Geometry) error {
+	return ReadEWKB(newEWKBReader(data), g)
+}
+
+// readBlock reads n bytes, without copying when reading from an ewkbReader.
+// Other readers are read progressively, so a wrong length cannot allocate
+// more than the data available.
+func readBlock(reader io.Reader, n int) ([]byte, error) {
+	if r, ok := reader.(*ewkbReader); ok {
+		return r.next(n)
+	}
+	if n <= 64 {
+		block := make([]byte, n)
+		if _, err := io.ReadFull(reader, block); err != nil {
+			return nil, err
+		}
+		return block, nil
+	}
+	block, err := io.ReadAll(io.LimitReader(reader, int64(n)))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(block) == 0:
+		return nil, io.EOF
+	case len(block) < n:
+		return nil, io.ErrUnexpectedEOF
+	}
+	return block, nil
+}
+
+func readByte(reader io.Reader) (byte, error) {
+	if r, ok := reader.(*ewkbReader); ok {
+		b, err := r.next(1)
+		if err != nil {
+			return 0, err
+		}
+		return b[0], nil
+	}
+	var b [1]byte
+	if _, err := io.ReadFull(reader, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func readUint32(reader io.Reader, byteOrder binary.ByteOrder) (uint32, error) {
+	if r, ok := reader.(*ewkbReader); ok {
+		b, err := r.next(4)
+		if err != nil {
+			return 0, err
+		}
+		return byteOrder.Uint32(b), nil
+	}
+	var b [4]byte
+	if _, err := io.ReadFull(reader, b[:]); err != nil {
+		return 0, err
+	}
+	return byteOrder.Uint32(b[:]), nil
+}
+
+// readFloats reads consecutive float64 values into the given fields
+func readFloats(reader io.Reader, byteOrder binary.ByteOrder, values ...*float64) error {
+	block, err := readBlock(reader, 8*len(values))
+	if err != nil {
+		return err
+	}
+	little := byteOrder == binary.LittleEndian
+	for i, v := range values {
+		*v = float64At(block[8*i:], little)
+	}
+	return nil
+}
+
+func float64At(b []byte, little bool) float64 {
+	if little {
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(b))
+}
+
+// coordinateSize returns the encoded size of a point type, or 0 for other types
+func coordinateSize[T any]() int {
+	var zero T
+	switch any(zero).(type) {
+	case Point:
+		return 16
+	case PointZ, PointM:
+		return 24
+	case PointZM:
+		return 32
+	}
+	return 0
+}
+
+// readCoordinates reads count points as one block and converts it in place
+func readCoordinates[T any](reader io.Reader, byteOrder binary.ByteOrder, count uint32) ([]T, error) {
+	size := coordinateSize[T]()
+	if size == 0 {
+		return nil, fmt.Errorf("%w: coordinate type %T", ErrUnsupportedType, *new(T))
+	}
+	if _, err := checkCount(reader, count, size); err != nil {
+		return nil, err
+	}
+
+	block, err := readBlock(reader, int(count)*size)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]T, count)
+	little := byteOrder == binary.LittleEndian
+	switch points := any(points).(type) {
+	case []Point:
+		for i := range points {
+			b := block[i*16:]
+			points[i] = Point{X: float64At(b, little), Y: float64At(b[8:], little)}
+		}
+	case []PointZ:
+		for i := range points {
+			b := block[i*24:]
+			points[i] = PointZ{X: float64At(b, little), Y: float64At(b[8:], little), Z: float64At(b[16:], little)}
+		}
+	case []PointM:
+		for i := range points {
+			b := block[i*24:]
+			points[i] = PointM{X: float64At(b, little), Y: float64At(b[8:], little), M: float64At(b[16:], little)}
+		}
+	case []PointZM:
+		for i := range points {
+			b := block[i*32:]
+			points[i] = PointZM{X: float64At(b, little), Y: float64At(b[8:], little), Z: float64At(b[16:], little), M: float64At(b[24:], little)}
+		}
+	default:
+		return nil, fmt.Errorf("%w: coordinate type %T", ErrUnsupportedType, points)
+	}
+	return points, nil
+}
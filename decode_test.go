@@ -0,0 +1,338 @@
+package postgis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func randomPoints(n int) []Point {
+	r := rand.New(rand.NewSource(int64(n)))
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{X: r.Float64() * 360, Y: r.Float64() * 180}
+	}
+	return points
+}
+
+// bigEndian re-encodes little-endian EWKB made of a header and float64 or
+// uint32 values, as laid out by layout, in big-endian byte order
+func bigEndian(ndr []byte, layout ...int) []byte {
+	xdr := []byte{wkbXDR}
+	ndr = ndr[1:]
+	for _, size := range layout {
+		for i := size - 1; i >= 0; i-- {
+			xdr = append(xdr, ndr[i])
+		}
+		ndr = ndr[size:]
+	}
+	return xdr
+}
+
+func TestReadEWKBBytes(t *testing.T) {
+	geometries := []Geometry{
+		&PointZMS{SRID: 4326, X: 1, Y: 2, Z: 3, M: 4},
+		&LineStringM{Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 4, Y: 5, M: 6}}},
+		&PolygonZS{SRID: 3857, Rings: [][]PointZ{{{X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 2}, {X: 0, Y: 1, Z: 3}, {X: 0, Y: 0, Z: 1}}}},
+		&MultiPointZM{Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}}},
+		&MultiPolygonS{SRID: 4326, Polygons: []Polygon{{Rings: [][]Point{square(0, 0, 1)}}}},
+		&GeometryCollectionS{SRID: 4326, Geometries: []Geometry{&Point{X: 1, Y: 2}, &LineString{Points: randomPoints(3)}}},
+	}
+
+	for _, g := range geometries {
+		t.Run(strings.TrimPrefix(geometryTypeName(g), "*postgis."), func(t *testing.T) {
+			buffer, err := WriteEWKB(g)
+			if err != nil {
+				t.Fatalf("Failed to write EWKB: %v", err)
+			}
+
+			fromBytes := reflect.New(reflect.TypeOf(g).Elem()).Interface().(Geometry)
+			if err := ReadEWKBBytes(buffer.Bytes(), fromBytes); err != nil {
+				t.Fatalf("ReadEWKBBytes failed: %v", err)
+			}
+			if !reflect.DeepEqual(g, fromBytes) {
+				t.Errorf("Expected %v, got %v", g, fromBytes)
+			}
+
+			// Any other io.Reader takes the copying path
+			fromReader := reflect.New(reflect.TypeOf(g).Elem()).Interface().(Geometry)
+			if err := ReadEWKB(bytes.NewReader(buffer.Bytes()), fromReader); err != nil {
+				t.Fatalf("ReadEWKB failed: %v", err)
+			}
+			if !reflect.DeepEqual(g, fromReader) {
+				t.Errorf("Expected %v, got %v", g, fromReader)
+			}
+		})
+	}
+}
+
+func TestReadEWKBBigEndian(t *testing.T) {
+	ls := LineStringS{SRID: 4326, Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+	buffer, err := WriteEWKB(&ls)
+	if err != nil {
+		t.Fatalf("Failed to write EWKB: %v", err)
+	}
+	xdr := bigEndian(buffer.Bytes(), 4, 4, 4, 8, 8, 8, 8)
+
+	var decoded LineStringS
+	if err := decoded.Scan(hex.EncodeToString(xdr)); err != nil {
+		t.Fatalf("Failed to scan big-endian EWKB: %v", err)
+	}
+	if !reflect.DeepEqual(ls, decoded) {
+		t.Errorf("Expected %v, got %v", ls, decoded)
+	}
+}
+
+func TestReadEWKBTruncated(t *testing.T) {
+	buffer, err := WriteEWKB(&LineString{Points: randomPoints(10)})
+	if err != nil {
+		t.Fatalf("Failed to write EWKB: %v", err)
+	}
+	data := buffer.Bytes()
+
+	var ls LineString
+	if err := ReadEWKBBytes(data[:3], &ls); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if err := ReadEWKB(bytes.NewReader(data[:len(data)-1]), &ls); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if err := ReadEWKBBytes(nil, &ls); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}
+
+func benchmarkScan(b *testing.B, g Geometry, newGeometry func() Geometry) {
+	value, err := g.Value()
+	if err != nil {
+		b.Fatalf("Failed to encode: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := newGeometry().Scan(value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkReadEWKBBytes(b *testing.B, g Geometry, newGeometry func() Geometry) {
+	buffer, err := WriteEWKB(g)
+	if err != nil {
+		b.Fatalf("Failed to encode: %v", err)
+	}
+	data := buffer.Bytes()
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ReadEWKBBytes(data, newGeometry()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanPointS(b *testing.B) {
+	benchmarkScan(b, &PointS{SRID: 4326, X: 1, Y: 2}, func() Geometry { return &PointS{} })
+}
+
+func BenchmarkScanLineString100k(b *testing.B) {
+	benchmarkScan(b, &LineStringS{SRID: 4326, Points: randomPoints(100000)}, func() Geometry { return &LineStringS{} })
+}
+
+func BenchmarkReadEWKBBytesLineString100k(b *testing.B) {
+	benchmarkReadEWKBBytes(b, &LineStringS{SRID: 4326, Points: randomPoints(100000)}, func() Geometry { return &LineStringS{} })
+}
+
+func BenchmarkReadEWKBBytesPolygon(b *testing.B) {
+	polygon := &PolygonS{SRID: 4326, Rings: [][]Point{square(0, 0, 10), square(1, 1, 1), square(5, 5, 1)}}
+	benchmarkReadEWKBBytes(b, polygon, func() Geometry { return &PolygonS{} })
+}
+
+func BenchmarkReadEWKBBytesMultiPolygon(b *testing.B) {
+	polygons := make([]Polygon, 100)
+	for i := range polygons {
+		polygons[i] = Polygon{Rings: [][]Point{square(float64(i), 0, 1)}}
+	}
+	benchmarkReadEWKBBytes(b, &MultiPolygonS{SRID: 4326, Polygons: polygons}, func() Geometry { return &MultiPolygonS{} })
+}
+
+// hostileEWKB returns little-endian EWKB made of a type code followed by raw
+// uint32 values, such as element counts
+func hostileEWKB(wkbType uint32, values ...uint32) []byte {
+	data := binary.LittleEndian.AppendUint32([]byte{wkbNDR}, wkbType)
+	for _, v := range values {
+		data = binary.LittleEndian.AppendUint32(data, v)
+	}
+	return data
+}
+
+func TestReadEWKBCorruptCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		geometry Geometry
+	}{
+		{"LineString", hostileEWKB(WKBLineString, 0xFFFFFFFF), &LineString{}},
+		{"Polygon rings", hostileEWKB(WKBPolygon, 0x7FFFFFF), &Polygon{}},
+		{"Polygon points", hostileEWKB(WKBPolygon, 1, 0x7FFFFFF), &Polygon{}},
+		{"MultiPoint", hostileEWKB(WKBMultiPoint, 1000), &MultiPoint{}},
+		{"MultiPolygon", hostileEWKB(WKBMultiPolygon, 0xFFFFFF), &MultiPolygon{}},
+		{"GeometryCollection", hostileEWKB(WKBGeometryCollection, 0xFFFFFF), &GeometryCollection{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ReadEWKBBytes(test.data, test.geometry); !errors.Is(err, ErrCorruptEWKB) {
+				t.Errorf("Expected ErrCorruptEWKB, got %v", err)
+			}
+			if err := test.geometry.Scan(hex.EncodeToString(test.data)); !errors.Is(err, ErrCorruptEWKB) {
+				t.Errorf("Expected ErrCorruptEWKB from Scan, got %v", err)
+			}
+			// The length of other readers is unknown, so they fail when the
+			// data runs out, without allocating for the whole count first.
+			// Bytes are measured rather than allocations, whose number
+			// changes under the race detector.
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			if err := ReadEWKB(bytes.NewReader(test.data), test.geometry); err == nil {
+				t.Errorf("Expected an error")
+			}
+			runtime.ReadMemStats(&after)
+			if allocated, limit := after.TotalAlloc-before.TotalAlloc, uint64(64<<10+64*len(test.data)); allocated > limit {
+				t.Errorf("Expected at most %d bytes allocated, got %d", limit, allocated)
+			}
+		})
+	}
+}
+
+// nestedCollections returns the EWKB of depth GeometryCollections, each the
+// only member of the one before
+func nestedCollections(depth int) []byte {
+	var data []byte
+	for i := 0; i < depth; i++ {
+		count := uint32(1)
+		if i == depth-1 {
+			count = 0
+		}
+		data = append(data, hostileEWKB(WKBGeometryCollection, count)...)
+	}
+	return data
+}
+
+func TestReadEWKBNestingDepth(t *testing.T) {
+	var gc GeometryCollection
+	if err := ReadEWKBBytes(nestedCollections(maxNestingDepth), &gc); err != nil {
+		t.Fatalf("Expected %d levels to decode, got %v", maxNestingDepth, err)
+	}
+
+	// Deeper input fails instead of exhausting the stack
+	deep := nestedCollections(1_000_000)
+	var decodeErr *DecodeError
+	if err := ReadEWKBBytes(deep, &gc); !errors.As(err, &decodeErr) || !errors.Is(err, ErrCorruptEWKB) {
+		t.Errorf("Expected a DecodeError wrapping ErrCorruptEWKB, got %v", err)
+	}
+	if err := ReadEWKB(bytes.NewReader(deep), &gc); !errors.Is(err, ErrCorruptEWKB) {
+		t.Errorf("Expected ErrCorruptEWKB from ReadEWKB, got %v", err)
+	}
+	if err := gc.Scan(deep); !errors.Is(err, ErrCorruptEWKB) {
+		t.Errorf("Expected ErrCorruptEWKB from Scan, got %v", err)
+	}
+	if err := gc.ReadElements(bytes.NewReader(deep[9:]), binary.LittleEndian, 1); !errors.Is(err, ErrCorruptEWKB) {
+		t.Errorf("Expected ErrCorruptEWKB from ReadElements, got %v", err)
+	}
+}
+
+func TestSetMaxElementCount(t *testing.T) {
+	defer SetMaxElementCount(DefaultMaxElementCount)
+
+	buffer, err := WriteEWKB(&LineString{Points: randomPoints(3)})
+	if err != nil {
+		t.Fatalf("Failed to write EWKB: %v", err)
+	}
+
+	SetMaxElementCount(2)
+	var ls LineString
+	if err := ReadEWKB(bytes.NewReader(buffer.Bytes()), &ls); !errors.Is(err, ErrCorruptEWKB) {
+		t.Errorf("Expected ErrCorruptEWKB above the maximum, got %v", err)
+	}
+
+	SetMaxElementCount(3)
+	if err := ReadEWKB(bytes.NewReader(buffer.Bytes()), &ls); err != nil {
+		t.Errorf("Expected the maximum to be inclusive, got %v", err)
+	}
+
+	SetMaxElementCount(0)
+	if err := ReadEWKBBytes(hostileEWKB(WKBLineString, 0xFFFFFFFF), &ls); !errors.Is(err, ErrCorruptEWKB) {
+		t.Errorf("Expected counts to be checked against the input length without a maximum, got %v", err)
+	}
+}
+
+// fuzzTargets returns an empty geometry of every type of this package
+func fuzzTargets() []func() Geometry {
+	codes := make([]uint32, 0, len(geometryFactories))
+	for code := range geometryFactories {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	targets := []func() Geometry{
+		func() Geometry { return &GeometryCollection{} },
+		func() Geometry { return &GeometryCollectionS{} },
+	}
+	for _, code := range codes {
+		targets = append(targets, geometryFactories[code])
+	}
+	return targets
+}
+
+func FuzzReadEWKB(f *testing.F) {
+	for _, g := range sampleGeometries() {
+		encoded, err := AppendEWKB(nil, g)
+		if err != nil {
+			f.Fatalf("Failed to encode %T: %v", g, err)
+		}
+		f.Add(encoded)
+	}
+	f.Add(hostileEWKB(WKBGeometryCollection, 1, 0))
+	f.Add(nestedCollections(maxNestingDepth + 1))
+	f.Add(bigEndian(hostileEWKB(WKBPolygon, 1, 0), 4, 4, 4))
+
+	targets := fuzzTargets()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, newGeometry := range targets {
+			g := newGeometry()
+			err := ReadEWKBBytes(data, g)
+
+			// Both decoding paths must agree
+			other := newGeometry()
+			if otherErr := ReadEWKB(bytes.NewReader(data), other); (err == nil) != (otherErr == nil) {
+				t.Fatalf("%T: ReadEWKBBytes returned %v but ReadEWKB returned %v", g, err, otherErr)
+			}
+			if err != nil {
+				continue
+			}
+
+			// Whatever decodes must encode and decode to the same bytes
+			encoded, err := AppendEWKB(nil, g)
+			if err != nil {
+				t.Fatalf("%T: failed to encode %v: %v", g, g, err)
+			}
+			again := newGeometry()
+			if err := ReadEWKBBytes(encoded, again); err != nil {
+				t.Fatalf("%T: failed to decode %x: %v", g, encoded, err)
+			}
+			if reencoded, _ := AppendEWKB(nil, again); !bytes.Equal(encoded, reencoded) {
+				t.Fatalf("%T: round trip changed %x to %x", g, encoded, reencoded)
+			}
+		}
+	})
+}
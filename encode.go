@@ -0,0 +1,436 @@
+package postgis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ewkbAppender is implemented by every geometry type of this package. The
+// EncodedSize of a geometry is exactly the number of bytes appendEWKB adds.
+type ewkbAppender interface {
+	EncodedSize() int
+	appendEWKB(dst []byte) ([]byte, error)
+}
+
+// AppendEWKB appends the binary EWKB encoding of g to dst and returns the
+// extended slice, like append. For the geometries of this package nothing is
+// allocated when dst has room for EncodedSize() more bytes, so one buffer can
+// be reused to encode any number of rows. Other Geometry implementations are
+// encoded through their Write method.
+func AppendEWKB(dst []byte, g Geometry) ([]byte, error) {
+	if a, ok := g.(ewkbAppender); ok {
+		return a.appendEWKB(dst)
+	}
+	buffer := bytes.NewBuffer(nil)
+	if err := writeEWKB(buffer, g); err != nil {
+		return dst, err
+	}
+	return append(dst, buffer.Bytes()...), nil
+}
+
+// AppendHexEWKB appends the hex encoded EWKB of g to dst, which is the text
+// format PostGIS accepts for geometry parameters and returns for geometry
+// columns. The binary encoding is written into the space reserved for the
+// hex digits and expanded in place, so at most one allocation is made, to
+// grow dst by 2*EncodedSize() bytes.
+func AppendHexEWKB(dst []byte, g Geometry) ([]byte, error) {
+	a, ok := g.(ewkbAppender)
+	if !ok {
+		encoded, err := AppendEWKB(nil, g)
+		if err != nil {
+			return dst, err
+		}
+		start := len(dst)
+		dst = append(dst, make([]byte, 2*len(encoded))...)
+		hexEncode(dst[start:], encoded)
+		return dst, nil
+	}
+
+	n := a.EncodedSize()
+	start := len(dst)
+	dst = slices.Grow(dst, 2*n)[:start+2*n]
+	encoded, err := a.appendEWKB(dst[start+n : start+n])
+	if err != nil {
+		return dst[:start], err
+	}
+	if len(encoded) != n {
+		return dst[:start], fmt.Errorf("encoded %d bytes for %s, expected %d", len(encoded), geometryTypeName(g), n)
+	}
+	hexEncode(dst[start:], encoded)
+	return dst, nil
+}
+
+const hexDigits = "0123456789abcdef"
+
+// hexEncode writes the hex digits of src to dst. src may be the second half
+// of dst: the digits of byte i go to dst[2i] and dst[2i+1], which never
+// overwrite bytes of src that have not been read yet.
+func hexEncode(dst, src []byte) {
+	for i := 0; i < len(src); i++ {
+		v := src[i]
+		dst[2*i] = hexDigits[v>>4]
+		dst[2*i+1] = hexDigits[v&0x0f]
+	}
+}
+
+// headerSize returns the size of the byte order, type code and SRID
+func headerSize(hasSRID bool) int {
+	if hasSRID {
+		return 9
+	}
+	return 5
+}
+
+func appendHeader(dst []byte, wkbType uint32) []byte {
+	return binary.LittleEndian.AppendUint32(append(dst, wkbNDR), wkbType)
+}
+
+func appendSRIDHeader(dst []byte, wkbType uint32, srid int32) []byte {
+	return binary.LittleEndian.AppendUint32(appendHeader(dst, wkbType), uint32(srid))
+}
+
+func appendFloats(dst []byte, values ...float64) []byte {
+	for _, v := range values {
+		dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(v))
+	}
+	return dst
+}
+
+func pointsSize[P PointLike](points []P) int {
+	return 4 + len(points)*coordinateSize[P]()
+}
+
+func ringsSize[P PointLike](rings [][]P) int {
+	size := 4
+	for _, ring := range rings {
+		size += pointsSize(ring)
+	}
+	return size
+}
+
+func multiPointSize[P PointLike](points []P) int {
+	return 4 + len(points)*(headerSize(false)+coordinateSize[P]())
+}
+
+func elementsSize[T ewkbAppender](elements []T) int {
+	size := 4
+	for _, e := range elements {
+		size += e.EncodedSize()
+	}
+	return size
+}
+
+func membersSize[G Geometry](geometries []G) int {
+	size := 4
+	for _, g := range geometries {
+		if a, ok := any(g).(ewkbAppender); ok {
+			size += a.EncodedSize()
+		} else if buffer, err := WriteEWKB(g); err == nil {
+			size += buffer.Len()
+		}
+		// appendMember leaves out the SRID
+		if GetGeometryInfo(g.GetType()).HasSRID {
+			size -= 4
+		}
+	}
+	return size
+}
+
+// appendPoints appends the point count and the coordinates
+func appendPoints[P PointLike](dst []byte, points []P) []byte {
+	return appendCoordinates(binary.LittleEndian.AppendUint32(dst, uint32(len(points))), points)
+}
+
+func appendCoordinates[P PointLike](dst []byte, points []P) []byte {
+	switch points := any(points).(type) {
+	case []Point:
+		for _, p := range points {
+			dst = appendFloats(dst, p.X, p.Y)
+		}
+	case []PointZ:
+		for _, p := range points {
+			dst = appendFloats(dst, p.X, p.Y, p.Z)
+		}
+	case []PointM:
+		for _, p := range points {
+			dst = appendFloats(dst, p.X, p.Y, p.M)
+		}
+	case []PointZM:
+		for _, p := range points {
+			dst = appendFloats(dst, p.X, p.Y, p.Z, p.M)
+		}
+	}
+	return dst
+}
+
+func appendRings[P PointLike](dst []byte, rings [][]P) []byte {
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(rings)))
+	for _, ring := range rings {
+		dst = appendPoints(dst, ring)
+	}
+	return dst
+}
+
+// appendMultiPoint appends the points of a MultiPoint, each with its own header
+func appendMultiPoint[P PointLike](dst []byte, points []P) []byte {
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(points)))
+	for i := range points {
+		dst = appendCoordinates(appendHeader(dst, points[i].GetType()), points[i:i+1])
+	}
+	return dst
+}
+
+func appendElements[T ewkbAppender](dst []byte, elements []T) ([]byte, error) {
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(elements)))
+	for _, e := range elements {
+		var err error
+		if dst, err = e.appendEWKB(dst); err != nil {
+			return dst, err
+		}
+	}
+	return dst, nil
+}
+
+func appendMembers[G Geometry](dst []byte, geometries []G) ([]byte, error) {
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(geometries)))
+	for _, g := range geometries {
+		var err error
+		if dst, err = appendMember(dst, g); err != nil {
+			return dst, err
+		}
+	}
+	return dst, nil
+}
+
+// appendMember appends a member of a collection like AppendEWKB, leaving out
+// its SRID: PostGIS only writes the SRID of the outermost geometry
+func appendMember(dst []byte, g Geometry) ([]byte, error) {
+	start := len(dst)
+	dst, err := AppendEWKB(dst, g)
+	if err != nil {
+		return dst[:start], err
+	}
+	if info := GetGeometryInfo(binary.LittleEndian.Uint32(dst[start+1:])); info.HasSRID {
+		binary.LittleEndian.PutUint32(dst[start+1:], BuildWKBType(info.BaseType, info.CoordType, false))
+		dst = append(dst[:start+5], dst[start+9:]...)
+	}
+	return dst, nil
+}
+
+// Implement EncodedSize and ewkbAppender for all types
+
+func (p Point) EncodedSize() int    { return headerSize(false) + coordinateSize[Point]() }
+func (p PointZ) EncodedSize() int   { return headerSize(false) + coordinateSize[PointZ]() }
+func (p PointM) EncodedSize() int   { return headerSize(false) + coordinateSize[PointM]() }
+func (p PointZM) EncodedSize() int  { return headerSize(false) + coordinateSize[PointZM]() }
+func (p PointS) EncodedSize() int   { return headerSize(true) + coordinateSize[Point]() }
+func (p PointZS) EncodedSize() int  { return headerSize(true) + coordinateSize[PointZ]() }
+func (p PointMS) EncodedSize() int  { return headerSize(true) + coordinateSize[PointM]() }
+func (p PointZMS) EncodedSize() int { return headerSize(true) + coordinateSize[PointZM]() }
+
+func (p Point) appendEWKB(dst []byte) ([]byte, error) {
+	return appendFloats(appendHeader(dst, p.GetType()), p.X, p.Y), nil
+}
+
+func (p PointZ) appendEWKB(dst []byte) ([]byte, error) {
+	return appendFloats(appendHeader(dst, p.GetType()), p.X, p.Y, p.Z), nil
+}
+
+func (p PointM) appendEWKB(dst []byte) ([]byte, error) {
+	return appendFloats(appendHeader(dst, p.GetType()), p.X, p.Y, p.M), nil
+}
+
+func (p PointZM) appendEWKB(dst []byte) ([]byte, error) {
+	return appendFloats(appendHeader(dst, p.GetType()), p.X, p.Y, p.Z, p.M), nil
+}
+
+func (p PointS) appendEWKB(dst []byte) ([]byte, error) {
+	return appendFloats(appendSRIDHeader(dst, p.GetType(), p.SRID), p.X, p.Y), nil
+}
+
+func (p PointZS) appendEWKB(dst []byte) ([]byte, error) {
+	return appendFloats(appendSRIDHeader(dst, p.GetType(), p.SRID), p.X, p.Y, p.Z), nil
+}
+
+func (p PointMS) appendEWKB(dst []byte) ([]byte, error) {
+	return appendFloats(appendSRIDHeader(dst, p.GetType(), p.SRID), p.X, p.Y, p.M), nil
+}
+
+func (p PointZMS) appendEWKB(dst []byte) ([]byte, error) {
+	return appendFloats(appendSRIDHeader(dst, p.GetType(), p.SRID), p.X, p.Y, p.Z, p.M), nil
+}
+
+func (ls LineStringOf[P]) EncodedSize() int  { return headerSize(false) + pointsSize(ls.Points) }
+func (ls LineStringSOf[P]) EncodedSize() int { return headerSize(true) + pointsSize(ls.Points) }
+
+func (ls LineStringOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendPoints(appendHeader(dst, ls.GetType()), ls.Points), nil
+}
+
+func (ls LineStringSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendPoints(appendSRIDHeader(dst, ls.GetType(), ls.SRID), ls.Points), nil
+}
+
+func (p PolygonOf[P]) EncodedSize() int  { return headerSize(false) + ringsSize(p.Rings) }
+func (p PolygonSOf[P]) EncodedSize() int { return headerSize(true) + ringsSize(p.Rings) }
+
+func (p PolygonOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendRings(appendHeader(dst, p.GetType()), p.Rings), nil
+}
+
+func (p PolygonSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendRings(appendSRIDHeader(dst, p.GetType(), p.SRID), p.Rings), nil
+}
+
+func (m MultiPointOf[P]) EncodedSize() int  { return headerSize(false) + multiPointSize(m.Points) }
+func (m MultiPointSOf[P]) EncodedSize() int { return headerSize(true) + multiPointSize(m.Points) }
+
+func (m MultiPointOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMultiPoint(appendHeader(dst, m.GetType()), m.Points), nil
+}
+
+func (m MultiPointSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMultiPoint(appendSRIDHeader(dst, m.GetType(), m.SRID), m.Points), nil
+}
+
+func (m MultiLineStringOf[P]) EncodedSize() int {
+	return headerSize(false) + elementsSize(m.LineStrings)
+}
+func (m MultiLineStringSOf[P]) EncodedSize() int {
+	return headerSize(true) + elementsSize(m.LineStrings)
+}
+
+func (m MultiLineStringOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendElements(appendHeader(dst, m.GetType()), m.LineStrings)
+}
+
+func (m MultiLineStringSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendElements(appendSRIDHeader(dst, m.GetType(), m.SRID), m.LineStrings)
+}
+
+func (m MultiPolygonOf[P]) EncodedSize() int  { return headerSize(false) + elementsSize(m.Polygons) }
+func (m MultiPolygonSOf[P]) EncodedSize() int { return headerSize(true) + elementsSize(m.Polygons) }
+
+func (m MultiPolygonOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendElements(appendHeader(dst, m.GetType()), m.Polygons)
+}
+
+func (m MultiPolygonSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendElements(appendSRIDHeader(dst, m.GetType(), m.SRID), m.Polygons)
+}
+
+func (gc GeometryCollection) EncodedSize() int  { return headerSize(false) + membersSize(gc.Geometries) }
+func (gc GeometryCollectionS) EncodedSize() int { return headerSize(true) + membersSize(gc.Geometries) }
+
+func (gc GeometryCollection) appendEWKB(dst []byte) ([]byte, error) {
+	if err := checkDimensions(gc.Geometries); err != nil {
+		return dst, err
+	}
+	return appendMembers(appendHeader(dst, gc.GetType()), gc.Geometries)
+}
+
+func (gc GeometryCollectionS) appendEWKB(dst []byte) ([]byte, error) {
+	if err := checkDimensions(gc.Geometries); err != nil {
+		return dst, err
+	}
+	return appendMembers(appendSRIDHeader(dst, gc.GetType(), gc.SRID), gc.Geometries)
+}
+
+func (cs CircularStringOf[P]) EncodedSize() int  { return headerSize(false) + pointsSize(cs.Points) }
+func (cs CircularStringSOf[P]) EncodedSize() int { return headerSize(true) + pointsSize(cs.Points) }
+
+func (cs CircularStringOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendPoints(appendHeader(dst, cs.GetType()), cs.Points), nil
+}
+
+func (cs CircularStringSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendPoints(appendSRIDHeader(dst, cs.GetType(), cs.SRID), cs.Points), nil
+}
+
+func (c CompoundCurveOf[P]) EncodedSize() int  { return headerSize(false) + membersSize(c.Curves) }
+func (c CompoundCurveSOf[P]) EncodedSize() int { return headerSize(true) + membersSize(c.Curves) }
+
+func (c CompoundCurveOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMembers(appendHeader(dst, c.GetType()), c.Curves)
+}
+
+func (c CompoundCurveSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMembers(appendSRIDHeader(dst, c.GetType(), c.SRID), c.Curves)
+}
+
+func (p CurvePolygonOf[P]) EncodedSize() int  { return headerSize(false) + membersSize(p.Rings) }
+func (p CurvePolygonSOf[P]) EncodedSize() int { return headerSize(true) + membersSize(p.Rings) }
+
+func (p CurvePolygonOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMembers(appendHeader(dst, p.GetType()), p.Rings)
+}
+
+func (p CurvePolygonSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMembers(appendSRIDHeader(dst, p.GetType(), p.SRID), p.Rings)
+}
+
+func (m MultiCurveOf[P]) EncodedSize() int  { return headerSize(false) + membersSize(m.Curves) }
+func (m MultiCurveSOf[P]) EncodedSize() int { return headerSize(true) + membersSize(m.Curves) }
+
+func (m MultiCurveOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMembers(appendHeader(dst, m.GetType()), m.Curves)
+}
+
+func (m MultiCurveSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMembers(appendSRIDHeader(dst, m.GetType(), m.SRID), m.Curves)
+}
+
+func (m MultiSurfaceOf[P]) EncodedSize() int  { return headerSize(false) + membersSize(m.Surfaces) }
+func (m MultiSurfaceSOf[P]) EncodedSize() int { return headerSize(true) + membersSize(m.Surfaces) }
+
+func (m MultiSurfaceOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMembers(appendHeader(dst, m.GetType()), m.Surfaces)
+}
+
+func (m MultiSurfaceSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendMembers(appendSRIDHeader(dst, m.GetType(), m.SRID), m.Surfaces)
+}
+
+func (s PolyhedralSurfaceOf[P]) EncodedSize() int {
+	return headerSize(false) + elementsSize(s.Polygons)
+}
+func (s PolyhedralSurfaceSOf[P]) EncodedSize() int {
+	return headerSize(true) + elementsSize(s.Polygons)
+}
+
+func (s PolyhedralSurfaceOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendElements(appendHeader(dst, s.GetType()), s.Polygons)
+}
+
+func (s PolyhedralSurfaceSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendElements(appendSRIDHeader(dst, s.GetType(), s.SRID), s.Polygons)
+}
+
+func (m TINOf[P]) EncodedSize() int  { return headerSize(false) + elementsSize(m.Triangles) }
+func (m TINSOf[P]) EncodedSize() int { return headerSize(true) + elementsSize(m.Triangles) }
+
+func (m TINOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendElements(appendHeader(dst, m.GetType()), m.Triangles)
+}
+
+func (m TINSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendElements(appendSRIDHeader(dst, m.GetType(), m.SRID), m.Triangles)
+}
+
+func (t TriangleOf[P]) EncodedSize() int {
+	return headerSize(false) + ringsSize(triangleRings(t.Points))
+}
+func (t TriangleSOf[P]) EncodedSize() int {
+	return headerSize(true) + ringsSize(triangleRings(t.Points))
+}
+
+func (t TriangleOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendRings(appendHeader(dst, t.GetType()), triangleRings(t.Points)), nil
+}
+
+func (t TriangleSOf[P]) appendEWKB(dst []byte) ([]byte, error) {
+	return appendRings(appendSRIDHeader(dst, t.GetType(), t.SRID), triangleRings(t.Points)), nil
+}
@@ -0,0 +1,172 @@
+package postgis
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sampleGeometries returns a geometry of every type of this package
+func sampleGeometries() []Geometry {
+	return []Geometry{
+		&Point{X: 1, Y: 2},
+		&PointZ{X: 1, Y: 2, Z: 3},
+		&PointM{X: 1, Y: 2, M: 3},
+		&PointZM{X: 1, Y: 2, Z: 3, M: 4},
+		&PointS{SRID: 4326, X: 1, Y: 2},
+		&PointZS{SRID: 4326, X: 1, Y: 2, Z: 3},
+		&PointMS{SRID: 4326, X: 1, Y: 2, M: 3},
+		&PointZMS{SRID: 4326, X: 1, Y: 2, Z: 3, M: 4},
+		&LineString{Points: []Point{{X: 1, Y: 2}, {X: 5, Y: 6}}},
+		&LineStringZ{Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 5, Y: 6, Z: 7}}},
+		&LineStringM{Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 5, Y: 6, M: 7}}},
+		&LineStringZM{Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}},
+		&LineStringS{SRID: 4326, Points: []Point{{X: 1, Y: 2}, {X: 5, Y: 6}}},
+		&LineStringZS{SRID: 4326, Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 5, Y: 6, Z: 7}}},
+		&LineStringMS{SRID: 4326, Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 5, Y: 6, M: 7}}},
+		&LineStringZMS{SRID: 4326, Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}},
+		&Polygon{Rings: [][]Point{{{X: 0, Y: 1}, {X: 4, Y: 5}, {X: 8, Y: 9}, {X: 0, Y: 1}}, {}}},
+		&PolygonZ{Rings: [][]PointZ{{{X: 0, Y: 1, Z: 2}, {X: 4, Y: 5, Z: 6}, {X: 8, Y: 9, Z: 10}, {X: 0, Y: 1, Z: 2}}, {}}},
+		&PolygonM{Rings: [][]PointM{{{X: 0, Y: 1, M: 2}, {X: 4, Y: 5, M: 6}, {X: 8, Y: 9, M: 10}, {X: 0, Y: 1, M: 2}}, {}}},
+		&PolygonZM{Rings: [][]PointZM{{{X: 0, Y: 1, Z: 2, M: 3}, {X: 4, Y: 5, Z: 6, M: 7}, {X: 8, Y: 9, Z: 10, M: 11}, {X: 0, Y: 1, Z: 2, M: 3}}, {}}},
+		&PolygonS{SRID: 4326, Rings: [][]Point{{{X: 0, Y: 1}, {X: 4, Y: 5}, {X: 8, Y: 9}, {X: 0, Y: 1}}, {}}},
+		&PolygonZS{SRID: 4326, Rings: [][]PointZ{{{X: 0, Y: 1, Z: 2}, {X: 4, Y: 5, Z: 6}, {X: 8, Y: 9, Z: 10}, {X: 0, Y: 1, Z: 2}}, {}}},
+		&PolygonMS{SRID: 4326, Rings: [][]PointM{{{X: 0, Y: 1, M: 2}, {X: 4, Y: 5, M: 6}, {X: 8, Y: 9, M: 10}, {X: 0, Y: 1, M: 2}}, {}}},
+		&PolygonZMS{SRID: 4326, Rings: [][]PointZM{{{X: 0, Y: 1, Z: 2, M: 3}, {X: 4, Y: 5, Z: 6, M: 7}, {X: 8, Y: 9, Z: 10, M: 11}, {X: 0, Y: 1, Z: 2, M: 3}}, {}}},
+		&MultiPoint{Points: []Point{{X: 1, Y: 2}, {X: 5, Y: 6}}},
+		&MultiPointZ{Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 5, Y: 6, Z: 7}}},
+		&MultiPointM{Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 5, Y: 6, M: 7}}},
+		&MultiPointZM{Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}},
+		&MultiPointS{SRID: 4326, Points: []Point{{X: 1, Y: 2}, {X: 5, Y: 6}}},
+		&MultiPointZS{SRID: 4326, Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 5, Y: 6, Z: 7}}},
+		&MultiPointMS{SRID: 4326, Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 5, Y: 6, M: 7}}},
+		&MultiPointZMS{SRID: 4326, Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}},
+		&MultiLineString{LineStrings: []LineString{{Points: []Point{{X: 1, Y: 2}, {X: 5, Y: 6}}}, {}}},
+		&MultiLineStringZ{LineStrings: []LineStringZ{{Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 5, Y: 6, Z: 7}}}, {}}},
+		&MultiLineStringM{LineStrings: []LineStringM{{Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 5, Y: 6, M: 7}}}, {}}},
+		&MultiLineStringZM{LineStrings: []LineStringZM{{Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}}, {}}},
+		&MultiLineStringS{SRID: 4326, LineStrings: []LineString{{Points: []Point{{X: 1, Y: 2}, {X: 5, Y: 6}}}, {}}},
+		&MultiLineStringZS{SRID: 4326, LineStrings: []LineStringZ{{Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 5, Y: 6, Z: 7}}}, {}}},
+		&MultiLineStringMS{SRID: 4326, LineStrings: []LineStringM{{Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 5, Y: 6, M: 7}}}, {}}},
+		&MultiLineStringZMS{SRID: 4326, LineStrings: []LineStringZM{{Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}}, {}}},
+		&MultiPolygon{Polygons: []Polygon{{Rings: [][]Point{{{X: 0, Y: 1}, {X: 4, Y: 5}, {X: 8, Y: 9}, {X: 0, Y: 1}}}}}},
+		&MultiPolygonZ{Polygons: []PolygonZ{{Rings: [][]PointZ{{{X: 0, Y: 1, Z: 2}, {X: 4, Y: 5, Z: 6}, {X: 8, Y: 9, Z: 10}, {X: 0, Y: 1, Z: 2}}}}}},
+		&MultiPolygonM{Polygons: []PolygonM{{Rings: [][]PointM{{{X: 0, Y: 1, M: 2}, {X: 4, Y: 5, M: 6}, {X: 8, Y: 9, M: 10}, {X: 0, Y: 1, M: 2}}}}}},
+		&MultiPolygonZM{Polygons: []PolygonZM{{Rings: [][]PointZM{{{X: 0, Y: 1, Z: 2, M: 3}, {X: 4, Y: 5, Z: 6, M: 7}, {X: 8, Y: 9, Z: 10, M: 11}, {X: 0, Y: 1, Z: 2, M: 3}}}}}},
+		&MultiPolygonS{SRID: 4326, Polygons: []Polygon{{Rings: [][]Point{{{X: 0, Y: 1}, {X: 4, Y: 5}, {X: 8, Y: 9}, {X: 0, Y: 1}}}}}},
+		&MultiPolygonZS{SRID: 4326, Polygons: []PolygonZ{{Rings: [][]PointZ{{{X: 0, Y: 1, Z: 2}, {X: 4, Y: 5, Z: 6}, {X: 8, Y: 9, Z: 10}, {X: 0, Y: 1, Z: 2}}}}}},
+		&MultiPolygonMS{SRID: 4326, Polygons: []PolygonM{{Rings: [][]PointM{{{X: 0, Y: 1, M: 2}, {X: 4, Y: 5, M: 6}, {X: 8, Y: 9, M: 10}, {X: 0, Y: 1, M: 2}}}}}},
+		&MultiPolygonZMS{SRID: 4326, Polygons: []PolygonZM{{Rings: [][]PointZM{{{X: 0, Y: 1, Z: 2, M: 3}, {X: 4, Y: 5, Z: 6, M: 7}, {X: 8, Y: 9, Z: 10, M: 11}, {X: 0, Y: 1, Z: 2, M: 3}}}}}},
+		&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}},
+		&CircularStringZMS{SRID: 4326, Points: []PointZM{{X: 0, Y: 0, Z: 1, M: 2}, {X: 1, Y: 1, Z: 3, M: 4}, {X: 2, Y: 0, Z: 5, M: 6}}},
+		&CompoundCurve{Curves: []Curve[Point]{&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}, &LineString{Points: []Point{{X: 2, Y: 0}, {X: 0, Y: 0}}}}},
+		&CompoundCurveZS{SRID: 4326, Curves: []Curve[PointZ]{&LineStringZ{Points: []PointZ{{X: 0, Y: 0, Z: 1}, {X: 2, Y: 0, Z: 1}}}}},
+		&CurvePolygon{Rings: []Curve[Point]{&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0}}}, &LineString{}}},
+		&CurvePolygonMS{SRID: 4326, Rings: []Curve[PointM]{&CompoundCurveM{Curves: []Curve[PointM]{&CircularStringM{Points: []PointM{{X: 0, Y: 0, M: 1}, {X: 1, Y: 1, M: 2}, {X: 2, Y: 0, M: 3}}}, &LineStringM{Points: []PointM{{X: 2, Y: 0, M: 3}, {X: 0, Y: 0, M: 1}}}}}}},
+		&MultiCurve{Curves: []Curve[Point]{&LineString{Points: []Point{{X: 1, Y: 2}, {X: 5, Y: 6}}}, &CircularString{}}},
+		&MultiCurveZM{Curves: []Curve[PointZM]{&CircularStringZM{Points: []PointZM{{X: 0, Y: 0, Z: 1, M: 2}, {X: 1, Y: 1, Z: 3, M: 4}, {X: 2, Y: 0, Z: 5, M: 6}}}}},
+		&MultiSurface{Surfaces: []Surface[Point]{&Polygon{Rings: [][]Point{{{X: 0, Y: 1}, {X: 4, Y: 5}, {X: 8, Y: 9}, {X: 0, Y: 1}}}}, &CurvePolygon{}}},
+		&MultiSurfaceS{SRID: 4326, Surfaces: []Surface[Point]{&CurvePolygon{Rings: []Curve[Point]{&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0}}}}}}},
+		&PolyhedralSurfaceZ{Polygons: []PolygonZ{{Rings: [][]PointZ{{{X: 0, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 1, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 0}}}}, {}}},
+		&PolyhedralSurfaceS{SRID: 4326},
+		&TINZ{Triangles: []TriangleZ{{Points: []PointZ{{X: 0, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 0}}}}},
+		&TINMS{SRID: 4326, Triangles: []TriangleM{{}}},
+		&Triangle{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 9}, {X: 9, Y: 0}, {X: 0, Y: 0}}},
+		&TriangleZMS{SRID: 4326},
+		&GeometryCollection{Geometries: []Geometry{&PointZ{X: 1, Y: 2, Z: 3}, &LineStringZ{Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}}}},
+		&GeometryCollectionS{SRID: 4326, Geometries: []Geometry{&PointS{SRID: 4326, X: 1, Y: 2}, &GeometryCollection{}}},
+	}
+}
+
+func TestAppendEWKB(t *testing.T) {
+	for _, g := range sampleGeometries() {
+		t.Run(strings.TrimPrefix(geometryTypeName(g), "*postgis."), func(t *testing.T) {
+			// Write goes through the original bytes.Buffer encoders
+			buffer := bytes.NewBuffer(nil)
+			if err := writeEWKB(buffer, g); err != nil {
+				t.Fatalf("Failed to write EWKB: %v", err)
+			}
+
+			prefix := []byte("prefix")
+			encoded, err := AppendEWKB(prefix, g)
+			if err != nil {
+				t.Fatalf("AppendEWKB failed: %v", err)
+			}
+			if !bytes.Equal(encoded[len(prefix):], buffer.Bytes()) || string(encoded[:len(prefix)]) != "prefix" {
+				t.Errorf("Expected %x, got %x", buffer.Bytes(), encoded[len(prefix):])
+			}
+			if size := g.(ewkbAppender).EncodedSize(); size != buffer.Len() {
+				t.Errorf("Expected EncodedSize %d, got %d", buffer.Len(), size)
+			}
+
+			hexEncoded, err := AppendHexEWKB(prefix, g)
+			if err != nil {
+				t.Fatalf("AppendHexEWKB failed: %v", err)
+			}
+			if expected := "prefix" + EncodeEWKB(buffer); string(hexEncoded) != expected {
+				t.Errorf("Expected %s, got %s", expected, hexEncoded)
+			}
+
+			// The encoding must decode back to the same geometry
+			decoded := reflect.New(reflect.TypeOf(g).Elem()).Interface().(Geometry)
+			if err := decoded.Scan(string(hexEncoded[len(prefix):])); err != nil {
+				t.Fatalf("Failed to scan: %v", err)
+			}
+			if !bytes.Equal(mustWriteEWKB(t, decoded), buffer.Bytes()) {
+				t.Errorf("Round trip changed the geometry to %v", decoded)
+			}
+		})
+	}
+}
+
+func mustWriteEWKB(t *testing.T, g Geometry) []byte {
+	t.Helper()
+	buffer, err := WriteEWKB(g)
+	if err != nil {
+		t.Fatalf("Failed to write EWKB: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestAppendEWKBAllocations(t *testing.T) {
+	ls := &LineStringS{SRID: 4326, Points: randomPoints(1000)}
+	buf := make([]byte, 0, 2*ls.EncodedSize())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = AppendEWKB(buf[:0], ls)
+		buf, _ = AppendHexEWKB(buf[:0], ls)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations when reusing a buffer, got %f", allocs)
+	}
+}
+
+func BenchmarkValueLineString100k(b *testing.B) {
+	ls := LineStringS{SRID: 4326, Points: randomPoints(100000)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := ls.Value(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAppendHexEWKBPointS(b *testing.B) {
+	p := &PointS{SRID: 4326, X: 1, Y: 2}
+	buf := make([]byte, 0, 2*p.EncodedSize())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = AppendHexEWKB(buf[:0], p)
+	}
+}
+
+func BenchmarkAppendEWKBLineString100k(b *testing.B) {
+	ls := &LineStringS{SRID: 4326, Points: randomPoints(100000)}
+	buf := make([]byte, 0, ls.EncodedSize())
+	b.SetBytes(int64(ls.EncodedSize()))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = AppendEWKB(buf[:0], ls)
+	}
+}
@@ -0,0 +1,304 @@
+package postgis
+
+import "math"
+
+// Envelope is an axis-aligned bounding box, like the box2d of PostGIS. An
+// envelope with MinX > MaxX is empty; use EmptyEnvelope to create one.
+type Envelope struct {
+	MinX, MinY float64
+	MaxX, MaxY float64
+}
+
+// EmptyEnvelope returns an envelope containing nothing, which grows to the
+// first point or envelope it is extended with
+func EmptyEnvelope() Envelope {
+	return Envelope{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+}
+
+// IsEmpty reports whether the envelope contains nothing
+func (e Envelope) IsEmpty() bool {
+	return e.MinX > e.MaxX || e.MinY > e.MaxY
+}
+
+// Intersects reports whether the envelopes share at least one point, like the
+// && operator
+func (e Envelope) Intersects(o Envelope) bool {
+	return e.MinX <= o.MaxX && o.MinX <= e.MaxX && e.MinY <= o.MaxY && o.MinY <= e.MaxY
+}
+
+// Contains reports whether o lies entirely within e, like the ~ operator
+func (e Envelope) Contains(o Envelope) bool {
+	return !o.IsEmpty() && e.MinX <= o.MinX && o.MaxX <= e.MaxX && e.MinY <= o.MinY && o.MaxY <= e.MaxY
+}
+
+// ExpandToInclude returns the smallest envelope containing both e and o
+func (e Envelope) ExpandToInclude(o Envelope) Envelope {
+	return Envelope{
+		MinX: math.Min(e.MinX, o.MinX),
+		MinY: math.Min(e.MinY, o.MinY),
+		MaxX: math.Max(e.MaxX, o.MaxX),
+		MaxY: math.Max(e.MaxY, o.MaxY),
+	}
+}
+
+// Area returns the area of the envelope, zero when it is empty
+func (e Envelope) Area() float64 {
+	if e.IsEmpty() {
+		return 0
+	}
+	return (e.MaxX - e.MinX) * (e.MaxY - e.MinY)
+}
+
+// Distance returns the planar distance from p to the nearest point of the
+// envelope, zero when p lies inside it
+func (e Envelope) Distance(p Point) float64 {
+	dx := math.Max(0, math.Max(e.MinX-p.X, p.X-e.MaxX))
+	dy := math.Max(0, math.Max(e.MinY-p.Y, p.Y-e.MaxY))
+	return math.Hypot(dx, dy)
+}
+
+func (e Envelope) center() Point {
+	return Point{X: (e.MinX + e.MaxX) / 2, Y: (e.MinY + e.MaxY) / 2}
+}
+
+func (e Envelope) extendPoint(p Point) Envelope {
+	if !p.finite() {
+		return e
+	}
+	return Envelope{
+		MinX: math.Min(e.MinX, p.X),
+		MinY: math.Min(e.MinY, p.Y),
+		MaxX: math.Max(e.MaxX, p.X),
+		MaxY: math.Max(e.MaxY, p.Y),
+	}
+}
+
+// EnvelopeOf returns the 2D bounding box of a geometry of this package, like
+// ST_Envelope. Points with NaN coordinates (POINT EMPTY) are ignored, so an
+// empty geometry, or one implemented outside this package, gives an empty
+// envelope.
+func EnvelopeOf(g Geometry) Envelope {
+	if s, ok := g.(shaper); ok {
+		return s.planarShape().envelope()
+	}
+	return EmptyEnvelope()
+}
+
+// shape is the 2D linework of a geometry, shared by the operations that only
+// need X and Y
+type shape struct {
+	points   []Point
+	lines    [][]Point
+	polygons [][][]Point
+}
+
+// shaper is implemented by every geometry type of this package
+type shaper interface {
+	planarShape() shape
+}
+
+func (s shape) envelope() Envelope {
+	e := EmptyEnvelope()
+	for _, p := range s.points {
+		e = e.extendPoint(p)
+	}
+	for _, line := range s.lines {
+		for _, p := range line {
+			e = e.extendPoint(p)
+		}
+	}
+	// Holes lie within their shell, but invalid polygons are not rejected
+	for _, rings := range s.polygons {
+		for _, ring := range rings {
+			for _, p := range ring {
+				e = e.extendPoint(p)
+			}
+		}
+	}
+	return e
+}
+
+// distance returns the planar distance from p to the shape, zero when p lies
+// inside one of its polygons. Polygon rings are combined with the even-odd
+// rule. An empty shape is infinitely far away.
+func (s shape) distance(p Point) float64 {
+	d := math.Inf(1)
+	for _, q := range s.points {
+		if q.finite() {
+			d = math.Min(d, distance(p, q))
+		}
+	}
+	for _, line := range s.lines {
+		if len(line) == 1 {
+			d = math.Min(d, distance(p, line[0]))
+		}
+		for i := 0; i+1 < len(line); i++ {
+			d = math.Min(d, segmentDistance(p, line[i], line[i+1]))
+		}
+	}
+	for _, rings := range s.polygons {
+		signed := signedRingsDistance(p, rings)
+		if signed >= 0 {
+			return 0
+		}
+		d = math.Min(d, -signed)
+	}
+	return d
+}
+
+func membersShape[G Geometry](geometries []G) shape {
+	var s shape
+	for _, g := range geometries {
+		if member, ok := any(g).(shaper); ok {
+			m := member.planarShape()
+			s.points = append(s.points, m.points...)
+			s.lines = append(s.lines, m.lines...)
+			s.polygons = append(s.polygons, m.polygons...)
+		}
+	}
+	return s
+}
+
+// xyPoints returns the X and Y of points, without copying when they are
+// already 2D
+func xyPoints[P coordinate](points []P) []Point {
+	if xy, ok := any(points).([]Point); ok {
+		return xy
+	}
+	result := make([]Point, len(points))
+	for i, p := range points {
+		result[i] = p.xy()
+	}
+	return result
+}
+
+func xyRings[P coordinate](rings [][]P) [][]Point {
+	result := make([][]Point, len(rings))
+	for i, ring := range rings {
+		result[i] = xyPoints(ring)
+	}
+	return result
+}
+
+// Implement shaper interface for all types
+func (p *Point) planarShape() shape    { return shape{points: []Point{p.xy()}} }
+func (p *PointZ) planarShape() shape   { return shape{points: []Point{p.xy()}} }
+func (p *PointM) planarShape() shape   { return shape{points: []Point{p.xy()}} }
+func (p *PointZM) planarShape() shape  { return shape{points: []Point{p.xy()}} }
+func (p *PointS) planarShape() shape   { return shape{points: []Point{p.xy()}} }
+func (p *PointZS) planarShape() shape  { return shape{points: []Point{p.xy()}} }
+func (p *PointMS) planarShape() shape  { return shape{points: []Point{p.xy()}} }
+func (p *PointZMS) planarShape() shape { return shape{points: []Point{p.xy()}} }
+
+func (ls *LineStringOf[P]) planarShape() shape  { return shape{lines: [][]Point{xyPoints(ls.Points)}} }
+func (ls *LineStringSOf[P]) planarShape() shape { return shape{lines: [][]Point{xyPoints(ls.Points)}} }
+
+func (p *PolygonOf[P]) planarShape() shape  { return shape{polygons: [][][]Point{xyRings(p.Rings)}} }
+func (p *PolygonSOf[P]) planarShape() shape { return shape{polygons: [][][]Point{xyRings(p.Rings)}} }
+
+func (m *MultiPointOf[P]) planarShape() shape  { return shape{points: xyPoints(m.Points)} }
+func (m *MultiPointSOf[P]) planarShape() shape { return shape{points: xyPoints(m.Points)} }
+
+func (m *MultiLineStringOf[P]) planarShape() shape {
+	lines := make([][]Point, len(m.LineStrings))
+	for i, ls := range m.LineStrings {
+		lines[i] = xyPoints(ls.Points)
+	}
+	return shape{lines: lines}
+}
+
+func (m *MultiLineStringSOf[P]) planarShape() shape {
+	lines := make([][]Point, len(m.LineStrings))
+	for i, ls := range m.LineStrings {
+		lines[i] = xyPoints(ls.Points)
+	}
+	return shape{lines: lines}
+}
+
+func (m *MultiPolygonOf[P]) planarShape() shape {
+	return shape{polygons: multiPolygonShape(m.Polygons)}
+}
+
+func (m *MultiPolygonSOf[P]) planarShape() shape {
+	return shape{polygons: multiPolygonShape(m.Polygons)}
+}
+
+func (s *PolyhedralSurfaceOf[P]) planarShape() shape {
+	return shape{polygons: multiPolygonShape(s.Polygons)}
+}
+
+func (s *PolyhedralSurfaceSOf[P]) planarShape() shape {
+	return shape{polygons: multiPolygonShape(s.Polygons)}
+}
+
+func (m *TINOf[P]) planarShape() shape  { return shape{polygons: trianglesShape(m.Triangles)} }
+func (m *TINSOf[P]) planarShape() shape { return shape{polygons: trianglesShape(m.Triangles)} }
+
+func (t *TriangleOf[P]) planarShape() shape {
+	return shape{polygons: [][][]Point{xyRings(triangleRings(t.Points))}}
+}
+
+func (t *TriangleSOf[P]) planarShape() shape {
+	return shape{polygons: [][][]Point{xyRings(triangleRings(t.Points))}}
+}
+
+func multiPolygonShape[P PointLike](polygons []PolygonOf[P]) [][][]Point {
+	result := make([][][]Point, len(polygons))
+	for i, p := range polygons {
+		result[i] = xyRings(p.Rings)
+	}
+	return result
+}
+
+func trianglesShape[P PointLike](triangles []TriangleOf[P]) [][][]Point {
+	result := make([][][]Point, len(triangles))
+	for i, t := range triangles {
+		result[i] = xyRings(triangleRings(t.Points))
+	}
+	return result
+}
+
+// Curved types are shaped by their linearization with DefaultCurveSegments
+func (cs *CircularStringOf[P]) planarShape() shape { return curvesShape[P](cs) }
+func (cs *CircularStringSOf[P]) planarShape() shape {
+	return curvesShape[P](&CircularStringOf[P]{Points: cs.Points})
+}
+
+func (c *CompoundCurveOf[P]) planarShape() shape { return curvesShape[P](c) }
+func (c *CompoundCurveSOf[P]) planarShape() shape {
+	return curvesShape[P](&CompoundCurveOf[P]{Curves: c.Curves})
+}
+
+func (p *CurvePolygonOf[P]) planarShape() shape { return surfacesShape[P](p) }
+func (p *CurvePolygonSOf[P]) planarShape() shape {
+	return surfacesShape[P](&CurvePolygonOf[P]{Rings: p.Rings})
+}
+
+func (m *MultiCurveOf[P]) planarShape() shape  { return curvesShape(m.Curves...) }
+func (m *MultiCurveSOf[P]) planarShape() shape { return curvesShape(m.Curves...) }
+
+func (m *MultiSurfaceOf[P]) planarShape() shape  { return surfacesShape(m.Surfaces...) }
+func (m *MultiSurfaceSOf[P]) planarShape() shape { return surfacesShape(m.Surfaces...) }
+
+func curvesShape[P PointLike](curves ...Curve[P]) shape {
+	var s shape
+	for _, c := range curves {
+		if points, err := c.linearize(DefaultCurveSegments); err == nil {
+			s.lines = append(s.lines, xyPoints(points))
+		}
+	}
+	return s
+}
+
+func surfacesShape[P PointLike](surfaces ...Surface[P]) shape {
+	var s shape
+	for _, surface := range surfaces {
+		if rings, err := surface.linearizeRings(DefaultCurveSegments); err == nil {
+			s.polygons = append(s.polygons, xyRings(rings))
+		}
+	}
+	return s
+}
+
+func (gc *GeometryCollection) planarShape() shape  { return membersShape(gc.Geometries) }
+func (gc *GeometryCollectionS) planarShape() shape { return membersShape(gc.Geometries) }
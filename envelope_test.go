@@ -0,0 +1,65 @@
+package postgis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEnvelopeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry Geometry
+		expected Envelope
+	}{
+		{"Point", &PointS{SRID: 4326, X: 1, Y: 2}, Envelope{MinX: 1, MinY: 2, MaxX: 1, MaxY: 2}},
+		{"LineStringZ", &LineStringZ{Points: []PointZ{{X: 1, Y: 5, Z: 9}, {X: -2, Y: 3, Z: -9}}}, Envelope{MinX: -2, MinY: 3, MaxX: 1, MaxY: 5}},
+		{"Polygon", &Polygon{Rings: [][]Point{square(1, 1, 3)}}, Envelope{MinX: 1, MinY: 1, MaxX: 4, MaxY: 4}},
+		{"MultiPointM", &MultiPointM{Points: []PointM{{X: 0, Y: 0, M: 100}, {X: math.NaN(), Y: math.NaN()}, {X: 2, Y: -1}}}, Envelope{MinX: 0, MinY: -1, MaxX: 2, MaxY: 0}},
+		{"MultiLineStringS", &MultiLineStringS{LineStrings: []LineString{{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}, {Points: []Point{{X: 5, Y: 5}, {X: 6, Y: 7}}}}}, Envelope{MinX: 0, MinY: 0, MaxX: 6, MaxY: 7}},
+		{"MultiPolygonZMS", &MultiPolygonZMS{Polygons: []PolygonZM{{Rings: [][]PointZM{{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 0, Y: 3}, {X: 0, Y: 0}}}}}}, Envelope{MinX: 0, MinY: 0, MaxX: 3, MaxY: 3}},
+		{"GeometryCollection", &GeometryCollection{Geometries: []Geometry{&Point{X: -1, Y: -1}, &Polygon{Rings: [][]Point{square(0, 0, 2)}}}}, Envelope{MinX: -1, MinY: -1, MaxX: 2, MaxY: 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if env := EnvelopeOf(test.geometry); env != test.expected {
+				t.Errorf("Expected %+v, got %+v", test.expected, env)
+			}
+		})
+	}
+
+	if env := EnvelopeOf(&LineString{}); !env.IsEmpty() {
+		t.Errorf("Expected an empty envelope, got %+v", env)
+	}
+	if env := EnvelopeOf(&Point{X: math.NaN(), Y: math.NaN()}); !env.IsEmpty() {
+		t.Errorf("Expected an empty envelope for POINT EMPTY, got %+v", env)
+	}
+}
+
+func TestEnvelopePredicates(t *testing.T) {
+	a := Envelope{MinX: 0, MinY: 0, MaxX: 2, MaxY: 2}
+	b := Envelope{MinX: 2, MinY: 1, MaxX: 3, MaxY: 3}
+	c := Envelope{MinX: 0.5, MinY: 0.5, MaxX: 1, MaxY: 1}
+
+	if !a.Intersects(b) || !b.Intersects(a) {
+		t.Errorf("Expected touching envelopes to intersect")
+	}
+	if a.Intersects(EmptyEnvelope()) {
+		t.Errorf("Expected no intersection with an empty envelope")
+	}
+	if !a.Contains(c) || c.Contains(a) || a.Contains(b) {
+		t.Errorf("Unexpected containment result")
+	}
+	if e := a.ExpandToInclude(b); e != (Envelope{MinX: 0, MinY: 0, MaxX: 3, MaxY: 3}) {
+		t.Errorf("Unexpected expanded envelope %+v", e)
+	}
+	if e := EmptyEnvelope().ExpandToInclude(c); e != c {
+		t.Errorf("Expected an empty envelope to expand to %+v, got %+v", c, e)
+	}
+	if d := a.Distance(Point{X: 5, Y: 6}); d != 5 {
+		t.Errorf("Expected distance 5, got %f", d)
+	}
+	if d := a.Distance(Point{X: 1, Y: 1}); d != 0 {
+		t.Errorf("Expected distance 0 inside, got %f", d)
+	}
+}
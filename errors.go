@@ -0,0 +1,180 @@
+package postgis
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Errors returned while decoding EWKB. Failures wrap one of them inside a
+// *DecodeError, so they can be told apart with errors.Is: ErrCorruptEWKB and
+// the errors wrapping it mean the data is damaged, while ErrUnsupportedType
+// and ErrTypeMismatch mean valid data that this package or the destination
+// type cannot hold.
+var (
+	// ErrCorruptEWKB is returned when EWKB input is inconsistent, such as an
+	// element count larger than the data that follows it
+	ErrCorruptEWKB = errors.New("corrupt EWKB")
+	// ErrTruncatedEWKB is returned when the input ends inside a geometry. The
+	// DecodeError then also wraps io.EOF or io.ErrUnexpectedEOF.
+	ErrTruncatedEWKB = fmt.Errorf("%w: unexpected end of data", ErrCorruptEWKB)
+	// ErrUnsupportedByteOrder is returned for a byte order other than 0 (XDR)
+	// or 1 (NDR)
+	ErrUnsupportedByteOrder = fmt.Errorf("%w: unsupported byte order", ErrCorruptEWKB)
+	// ErrInvalidHex is returned by DecodeEWKB and Scan for values that are not
+	// valid hex
+	ErrInvalidHex = fmt.Errorf("%w: invalid hex encoding", ErrCorruptEWKB)
+	// ErrUnsupportedType is returned for a geometry type code this package
+	// cannot decode, such as the abstract Curve and Surface types or codes
+	// that PostGIS does not define
+	ErrUnsupportedType = errors.New("unsupported geometry type")
+	// ErrTypeMismatch is returned when the EWKB holds a different geometry
+	// type, dimension or SRID flag than the destination type, like a Polygon
+	// scanned into a *LineString
+	ErrTypeMismatch = errors.New("geometry type mismatch")
+	// ErrUnsupportedValue is returned by Scan for database values that are
+	// neither a string nor a []byte
+	ErrUnsupportedValue = errors.New("unsupported value type")
+)
+
+// DecodeError is returned by ReadEWKB, ReadEWKBBytes and Scan when decoding
+// fails. Err holds the cause, which wraps one of the sentinel errors above.
+// For a failure inside a member of a multi geometry, the fields describe the
+// member.
+type DecodeError struct {
+	// Offset is the position in the binary EWKB at which decoding failed
+	Offset int64
+	// Expected is the type code of the destination geometry
+	Expected uint32
+	// Actual is the type code read from the input, zero if the header could
+	// not be read
+	Actual uint32
+	// GeometryType is the Go type of the destination, like "*postgis.PointS"
+	GeometryType string
+	Err          error
+}
+
+func (e *DecodeError) Error() string {
+	if errors.Is(e.Err, ErrTypeMismatch) {
+		return fmt.Sprintf("decoding %s at byte %d: %v (expected type 0x%08X, got 0x%08X)", e.GeometryType, e.Offset, e.Err, e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("decoding %s at byte %d: %v", e.GeometryType, e.Offset, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError wraps a failure to decode g, classifying plain end of input
+// errors as ErrTruncatedEWKB. Errors from a nested geometry are already a
+// *DecodeError and are returned unchanged.
+func newDecodeError(reader io.Reader, g Geometry, wkbType uint32, err error) error {
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return err
+	}
+	return &DecodeError{
+		Offset:       readOffset(reader),
+		Expected:     g.GetType(),
+		Actual:       wkbType,
+		GeometryType: geometryTypeName(g),
+		Err:          classifyEOF(err),
+	}
+}
+
+// classifyEOF wraps the end of input errors of readers in ErrTruncatedEWKB
+func classifyEOF(err error) error {
+	if !errors.Is(err, ErrCorruptEWKB) && (errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)) {
+		return fmt.Errorf("%w: %w", ErrTruncatedEWKB, err)
+	}
+	return err
+}
+
+var baseTypeNames = map[uint32]string{
+	WKBPoint:              "Point",
+	WKBLineString:         "LineString",
+	WKBPolygon:            "Polygon",
+	WKBMultiPoint:         "MultiPoint",
+	WKBMultiLineString:    "MultiLineString",
+	WKBMultiPolygon:       "MultiPolygon",
+	WKBGeometryCollection: "GeometryCollection",
+	WKBCircularString:     "CircularString",
+	WKBCompoundCurve:      "CompoundCurve",
+	WKBCurvePolygon:       "CurvePolygon",
+	WKBMultiCurve:         "MultiCurve",
+	WKBMultiSurface:       "MultiSurface",
+	WKBPolyhedralSurface:  "PolyhedralSurface",
+	WKBTIN:                "TIN",
+	WKBTriangle:           "Triangle",
+}
+
+// geometryTypeName returns the name of the Go type of g, using the aliases
+// such as *postgis.LineStringZS for the generic types of this package
+func geometryTypeName(g Geometry) string {
+	name := fmt.Sprintf("%T", g)
+	pointer := strings.HasPrefix(name, "*")
+	if !strings.HasPrefix(strings.TrimPrefix(name, "*"), "postgis.") || !strings.Contains(name, "[") {
+		return name
+	}
+	info := GetGeometryInfo(g.GetType())
+	name = "postgis." + baseTypeNames[info.BaseType] + [...]string{"", "Z", "M", "ZM"}[info.CoordType]
+	if info.HasSRID {
+		name += "S"
+	}
+	if pointer {
+		name = "*" + name
+	}
+	return name
+}
+
+// countingReader tracks the offset of readers other than ewkbReader, so that
+// errors can report where decoding stopped
+type countingReader struct {
+	reader io.Reader
+	off    int64
+	depth  int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.off += int64(n)
+	return n, err
+}
+
+// trackOffset returns reader wrapped so that readOffset can report its
+// position, unless it already can
+func trackOffset(reader io.Reader) io.Reader {
+	switch reader.(type) {
+	case *ewkbReader, *countingReader:
+		return reader
+	}
+	return &countingReader{reader: reader}
+}
+
+func readOffset(reader io.Reader) int64 {
+	switch r := reader.(type) {
+	case *ewkbReader:
+		return int64(r.off)
+	case *countingReader:
+		return r.off
+	}
+	return -1
+}
+
+// checkType reports whether EWKB of the given type can be read into g. The
+// dimension of a GeometryCollection follows its members, so only its base
+// type is compared.
+func checkType(g Geometry, info GeometryInfo) error {
+	if _, ok := baseTypeNames[info.BaseType]; !ok {
+		return fmt.Errorf("%w: %d", ErrUnsupportedType, info.BaseType)
+	}
+	expected := GetGeometryInfo(g.GetType())
+	if expected.BaseType != info.BaseType || (expected.BaseType != WKBGeometryCollection && expected.CoordType != info.CoordType) {
+		return fmt.Errorf("%w: %s cannot hold type %d", ErrTypeMismatch, geometryTypeName(g), BuildWKBType(info.BaseType, info.CoordType, false))
+	}
+	if _, ok := g.(SRIDGeometry); info.HasSRID && !ok {
+		return fmt.Errorf("%w: %s does not support SRID but EWKB contains SRID", ErrTypeMismatch, geometryTypeName(g))
+	}
+	return nil
+}
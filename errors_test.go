@@ -0,0 +1,154 @@
+package postgis
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDecodeErrors(t *testing.T) {
+	lineString := mustWriteEWKB(t, &LineString{Points: randomPoints(3)})
+	badMember := hostileEWKB(WKBMultiLineString, 2)
+	badMember = append(badMember, lineString...)
+	badMember = append(badMember, mustWriteEWKB(t, &Point{X: 1, Y: 2})...)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		geometry Geometry
+		want     error
+		corrupt  bool
+		decode   DecodeError
+	}{
+		{
+			name:     "byte order",
+			data:     append([]byte{2}, lineString[1:]...),
+			geometry: &LineString{},
+			want:     ErrUnsupportedByteOrder,
+			corrupt:  true,
+			decode:   DecodeError{Offset: 1, Expected: WKBLineString, GeometryType: "*postgis.LineString"},
+		},
+		{
+			name:     "truncated",
+			data:     mustWriteEWKB(t, &Point{X: 1, Y: 2})[:20],
+			geometry: &Point{},
+			want:     ErrTruncatedEWKB,
+			corrupt:  true,
+			decode:   DecodeError{Offset: 5, Expected: WKBPoint, Actual: WKBPoint, GeometryType: "*postgis.Point"},
+		},
+		{
+			name:     "base type",
+			data:     mustWriteEWKB(t, &Polygon{}),
+			geometry: &LineString{},
+			want:     ErrTypeMismatch,
+			decode:   DecodeError{Offset: 5, Expected: WKBLineString, Actual: WKBPolygon, GeometryType: "*postgis.LineString"},
+		},
+		{
+			name:     "dimension",
+			data:     mustWriteEWKB(t, &LineStringZ{}),
+			geometry: &LineStringS{},
+			want:     ErrTypeMismatch,
+			decode:   DecodeError{Offset: 5, Expected: WKBLineString | WKBSRIDFlag, Actual: WKBLineString | WKBZFlag, GeometryType: "*postgis.LineStringS"},
+		},
+		{
+			name:     "SRID",
+			data:     mustWriteEWKB(t, &PointS{SRID: 4326}),
+			geometry: &Point{},
+			want:     ErrTypeMismatch,
+			decode:   DecodeError{Offset: 5, Expected: WKBPoint, Actual: WKBPoint | WKBSRIDFlag, GeometryType: "*postgis.Point"},
+		},
+		{
+			name:     "unknown type",
+			data:     hostileEWKB(99, 0),
+			geometry: &LineString{},
+			want:     ErrUnsupportedType,
+			decode:   DecodeError{Offset: 5, Expected: WKBLineString, Actual: 99, GeometryType: "*postgis.LineString"},
+		},
+		{
+			name:     "member",
+			data:     badMember,
+			geometry: &MultiLineString{},
+			want:     ErrTypeMismatch,
+			decode:   DecodeError{Offset: int64(9 + len(lineString) + 5), Expected: WKBLineString, Actual: WKBPoint, GeometryType: "*postgis.LineString"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ReadEWKBBytes(test.data, test.geometry)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("Expected %v, got %v", test.want, err)
+			}
+			if errors.Is(err, ErrCorruptEWKB) != test.corrupt {
+				t.Errorf("Expected errors.Is(err, ErrCorruptEWKB) to be %v for %v", test.corrupt, err)
+			}
+
+			var decodeErr *DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("Expected a *DecodeError, got %T", err)
+			}
+			got := *decodeErr
+			got.Err = nil
+			if got != test.decode {
+				t.Errorf("Expected %+v, got %+v", test.decode, got)
+			}
+
+			// Other readers fail with the same error
+			if err := ReadEWKB(bytes.NewReader(test.data), test.geometry); !errors.Is(err, test.want) || !errors.As(err, &decodeErr) {
+				t.Errorf("Expected a *DecodeError wrapping %v from ReadEWKB, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestDecodeErrorTruncatedWrapsEOF(t *testing.T) {
+	var p Point
+	if err := ReadEWKBBytes(nil, &p); !errors.Is(err, ErrTruncatedEWKB) || !errors.Is(err, io.EOF) {
+		t.Errorf("Expected ErrTruncatedEWKB wrapping io.EOF, got %v", err)
+	}
+	if err := ReadEWKBBytes([]byte{wkbNDR, 1}, &p); !errors.Is(err, ErrTruncatedEWKB) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected ErrTruncatedEWKB wrapping io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	var ls LineStringS
+	err := ls.Scan(nil)
+	if !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Expected ErrUnsupportedValue, got %v", err)
+	}
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.GeometryType != "*postgis.LineStringS" {
+		t.Errorf("Expected a *DecodeError for *postgis.LineStringS, got %v", err)
+	}
+
+	if err := ls.Scan("not hex"); !errors.Is(err, ErrInvalidHex) || !errors.Is(err, ErrCorruptEWKB) {
+		t.Errorf("Expected ErrInvalidHex, got %v", err)
+	}
+	if err := ls.Scan([]byte("0g")); !errors.Is(err, ErrInvalidHex) {
+		t.Errorf("Expected ErrInvalidHex, got %v", err)
+	}
+}
+
+func TestNewGeometryUnsupported(t *testing.T) {
+	if _, err := NewGeometry(99); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType, got %v", err)
+	}
+}
+
+func TestGeometryTypeName(t *testing.T) {
+	tests := map[Geometry]string{
+		&Point{}:                    "*postgis.Point",
+		&LineStringZS{}:             "*postgis.LineStringZS",
+		&MultiPolygonOf[PointM]{}:   "*postgis.MultiPolygonM",
+		&MultiPointSOf[PointZM]{}:   "*postgis.MultiPointZMS",
+		&GeometryCollectionS{}:      "*postgis.GeometryCollectionS",
+		&PolygonSOf[Point]{SRID: 1}: "*postgis.PolygonS",
+	}
+	for g, expected := range tests {
+		if got := geometryTypeName(g); got != expected {
+			t.Errorf("Expected %s, got %s", expected, got)
+		}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"io"
 )
@@ -17,12 +16,21 @@ const (
 
 // GeometryType constants for WKB geometry types
 const (
-	WKBPoint           uint32 = 1
-	WKBLineString      uint32 = 2
-	WKBPolygon         uint32 = 3
-	WKBMultiPoint      uint32 = 4
-	WKBMultiLineString uint32 = 5
-	WKBMultiPolygon    uint32 = 6
+	WKBPoint              uint32 = 1
+	WKBLineString         uint32 = 2
+	WKBPolygon            uint32 = 3
+	WKBMultiPoint         uint32 = 4
+	WKBMultiLineString    uint32 = 5
+	WKBMultiPolygon       uint32 = 6
+	WKBGeometryCollection uint32 = 7
+	WKBCircularString     uint32 = 8
+	WKBCompoundCurve      uint32 = 9
+	WKBCurvePolygon       uint32 = 10
+	WKBMultiCurve         uint32 = 11
+	WKBMultiSurface       uint32 = 12
+	WKBPolyhedralSurface  uint32 = 15
+	WKBTIN                uint32 = 16
+	WKBTriangle           uint32 = 17
 
 	// Flags for coordinate dimensions
 	WKBZFlag    uint32 = 0x80000000
@@ -102,19 +110,20 @@ func DecodeEWKB(value interface{}) (io.Reader, error) {
 		// For pgx, decode the hex-encoded string into bytes
 		ewkb, err = hex.DecodeString(v)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %w", ErrInvalidHex, err)
 		}
 	case []byte:
-		// For lib/pq, cast it to string and decode the hex-encoded string into bytes
-		ewkb, err = hex.DecodeString(string(v))
-		if err != nil {
-			return nil, err
+		// For lib/pq, decode the hex-encoded bytes without converting them to a string
+		ewkb = make([]byte, hex.DecodedLen(len(v)))
+		if _, err = hex.Decode(ewkb, v); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidHex, err)
 		}
 	default:
-		return nil, fmt.Errorf("unsupported type: %T", value)
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedValue, value)
 	}
 
-	return bytes.NewReader(ewkb), nil
+	// The decoders read coordinate blocks straight from the returned reader
+	return newEWKBReader(ewkb), nil
 }
 
 // EncodeEWKB encodes a buffer to hex string
@@ -124,42 +133,68 @@ func EncodeEWKB(buffer *bytes.Buffer) string {
 
 // WriteEWKB writes a geometry to EWKB format
 func WriteEWKB(g Geometry) (*bytes.Buffer, error) {
+	if a, ok := g.(ewkbAppender); ok {
+		encoded, err := a.appendEWKB(make([]byte, 0, a.EncodedSize()))
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewBuffer(encoded), nil
+	}
+
 	buffer := bytes.NewBuffer(nil)
+	if err := writeEWKB(buffer, g); err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
 
+// writeEWKB appends the EWKB of a geometry to buffer, also used for the
+// members of a GeometryCollection
+func writeEWKB(buffer *bytes.Buffer, g Geometry) error {
 	// Set our endianness
 	if err := binary.Write(buffer, binary.LittleEndian, wkbNDR); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Write geometry type
 	if err := binary.Write(buffer, binary.LittleEndian, g.GetType()); err != nil {
-		return nil, err
+		return err
 	}
 
 	// Write SRID if present
 	if sridGeom, ok := g.(SRIDGeometry); ok {
 		if err := binary.Write(buffer, binary.LittleEndian, sridGeom.GetSRID()); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// Write geometry data
-	if err := g.Write(buffer); err != nil {
-		return nil, err
-	}
-
-	return buffer, nil
+	return g.Write(buffer)
 }
 
-// ReadEWKB reads a geometry from EWKB format
+// ReadEWKB reads a geometry from EWKB format. Failures are returned as a
+// *DecodeError.
 func ReadEWKB(reader io.Reader, g Geometry) error {
+	reader = trackOffset(reader)
+	byteOrder, wkbType, err := readEWKBHeader(reader)
+	if err == nil {
+		err = readEWKBBody(reader, byteOrder, GetGeometryInfo(wkbType), g)
+	}
+	if err != nil {
+		return newDecodeError(reader, g, wkbType, err)
+	}
+	return nil
+}
+
+// readEWKBHeader reads the byte order and geometry type that start every
+// EWKB geometry
+func readEWKBHeader(reader io.Reader) (binary.ByteOrder, uint32, error) {
 	var byteOrder binary.ByteOrder
-	var wkbByteOrder byte
-	var wkbType uint32
 
 	// Read byte order
-	if err := binary.Read(reader, binary.LittleEndian, &wkbByteOrder); err != nil {
-		return err
+	wkbByteOrder, err := readByte(reader)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	// Decide byte order
@@ -169,31 +204,52 @@ func ReadEWKB(reader io.Reader, g Geometry) error {
 	case wkbNDR:
 		byteOrder = binary.LittleEndian
 	default:
-		return errors.New("unsupported byte order")
+		return nil, 0, fmt.Errorf("%w: %d", ErrUnsupportedByteOrder, wkbByteOrder)
 	}
 
 	// Read geometry type
-	if err := binary.Read(reader, byteOrder, &wkbType); err != nil {
-		return err
+	wkbType, err := readUint32(reader, byteOrder)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	info := GetGeometryInfo(wkbType)
+	return byteOrder, wkbType, nil
+}
+
+// readEWKBBody reads the optional SRID and the geometry data following the
+// header
+func readEWKBBody(reader io.Reader, byteOrder binary.ByteOrder, info GeometryInfo, g Geometry) error {
+	if err := checkType(g, info); err != nil {
+		return err
+	}
+	leave, err := enterNested(reader)
+	if err != nil {
+		return err
+	}
+	defer leave()
 
 	// Read SRID if present
 	if info.HasSRID {
-		if sridGeom, ok := g.(SRIDGeometry); ok {
-			var srid int32
-			if err := binary.Read(reader, byteOrder, &srid); err != nil {
-				return err
-			}
-			sridGeom.SetSRID(srid)
-		} else {
-			return fmt.Errorf("geometry type %T does not support SRID but EWKB contains SRID", g)
+		srid, err := readUint32(reader, byteOrder)
+		if err != nil {
+			return err
 		}
+		g.(SRIDGeometry).SetSRID(int32(srid))
 	}
 
 	// Read geometry data using specialized readers
-	return ReadGeometryData(reader, byteOrder, g, info)
+	if err := ReadGeometryData(reader, byteOrder, g, info); err != nil {
+		return err
+	}
+
+	// A collection takes the dimensions of its members, which must be those
+	// of its header
+	if collection, ok := g.(CollectionGeometry); ok && info.BaseType == WKBGeometryCollection && collection.GetElementCount() > 0 {
+		if coordType := GetGeometryInfo(g.GetType()).CoordType; coordType != info.CoordType {
+			return fmt.Errorf("%w: %s members in a collection of %s", ErrMixedDimensions, coordType, info.CoordType)
+		}
+	}
+	return nil
 }
 
 // ReadGeometryData reads geometry-specific data
@@ -207,19 +263,22 @@ func ReadGeometryData(reader io.Reader, byteOrder binary.ByteOrder, g Geometry,
 		// Fallback to binary.Read for simple point types
 		return binary.Read(reader, byteOrder, g)
 
-	case WKBLineString:
-		// LineStrings need to read point count first, then points
+	case WKBLineString, WKBPolygon, WKBMultiPoint, WKBMultiLineString, WKBMultiPolygon, WKBGeometryCollection,
+		WKBCircularString, WKBCompoundCurve, WKBCurvePolygon, WKBMultiCurve, WKBMultiSurface,
+		WKBPolyhedralSurface, WKBTIN, WKBTriangle:
+		// LineStrings, Polygons, curves, surfaces and collections need to read their
+		// element count first, then the points, rings or member geometries
 		if collGeom, ok := g.(CollectionGeometry); ok {
-			var count uint32
-			if err := binary.Read(reader, byteOrder, &count); err != nil {
+			count, err := readUint32(reader, byteOrder)
+			if err != nil {
 				return err
 			}
 			return collGeom.ReadElements(reader, byteOrder, count)
 		}
-		return fmt.Errorf("geometry type %T does not implement CollectionGeometry interface", g)
+		return fmt.Errorf("%w: %s does not implement CollectionGeometry", ErrUnsupportedType, geometryTypeName(g))
 
 	default:
-		return fmt.Errorf("unsupported geometry type: %d", info.BaseType)
+		return fmt.Errorf("%w: %d", ErrUnsupportedType, info.BaseType)
 	}
 }
 
@@ -238,42 +297,14 @@ func WriteGeometryCollection(buffer *bytes.Buffer, count uint32, writeElements f
 func ReadPointCollection(reader io.Reader, byteOrder binary.ByteOrder, count uint32, coordType CoordinateType) (interface{}, error) {
 	switch coordType {
 	case CoordXY:
-		points := make([]Point, count)
-		for i := uint32(0); i < count; i++ {
-			if err := binary.Read(reader, byteOrder, &points[i]); err != nil {
-				return nil, err
-			}
-		}
-		return points, nil
-
+		return readCoordinates[Point](reader, byteOrder, count)
 	case CoordXYZ:
-		points := make([]PointZ, count)
-		for i := uint32(0); i < count; i++ {
-			if err := binary.Read(reader, byteOrder, &points[i]); err != nil {
-				return nil, err
-			}
-		}
-		return points, nil
-
+		return readCoordinates[PointZ](reader, byteOrder, count)
 	case CoordXYM:
-		points := make([]PointM, count)
-		for i := uint32(0); i < count; i++ {
-			if err := binary.Read(reader, byteOrder, &points[i]); err != nil {
-				return nil, err
-			}
-		}
-		return points, nil
-
+		return readCoordinates[PointM](reader, byteOrder, count)
 	case CoordXYZM:
-		points := make([]PointZM, count)
-		for i := uint32(0); i < count; i++ {
-			if err := binary.Read(reader, byteOrder, &points[i]); err != nil {
-				return nil, err
-			}
-		}
-		return points, nil
-
+		return readCoordinates[PointZM](reader, byteOrder, count)
 	default:
-		return nil, fmt.Errorf("unsupported coordinate type: %d", coordType)
+		return nil, fmt.Errorf("%w: coordinate type %d", ErrUnsupportedType, coordType)
 	}
 }
@@ -0,0 +1,79 @@
+// Command gorm shows go-postgis types in GORM models with the gormpostgis
+// package: AutoMigrate creates typed geometry columns with a spatial index,
+// and the expressions of the postgis package serve as query clauses.
+//
+// Run it from examples/gorm, a module of its own so that the PostgreSQL
+// driver of GORM is not a dependency of go-postgis, against a database with
+// the PostGIS extension available:
+//
+//	DATABASE_URL=postgres://localhost/shops go run .
+//
+// sqlx needs no such integration: the types implement sql.Scanner and
+// driver.Valuer, so they can be used with Get, Select and NamedExec as they
+// are.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	postgis "github.com/cridenour/go-postgis"
+	"github.com/cridenour/go-postgis/gormpostgis"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Shop is a model with geometry columns. The srid and geography settings of
+// the gorm tag set the type of the columns, here geometry(Point,4326),
+// geography(Polygon,4326) and geometry(LineString,4326).
+type Shop struct {
+	ID       uint
+	Name     string
+	Location gormpostgis.Column[*postgis.PointS]      `gorm:"srid:4326;not null;index:idx_shops_location,type:gist"`
+	Area     gormpostgis.Column[*postgis.PolygonS]    `gorm:"geography;srid:4326"`
+	Delivery gormpostgis.Column[*postgis.LineStringS] `gorm:"srid:4326"`
+}
+
+func main() {
+	db, err := gorm.Open(postgres.Open(os.Getenv("DATABASE_URL")), &gorm.Config{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS postgis").Error; err != nil {
+		log.Fatal(err)
+	}
+	if err := db.AutoMigrate(&Shop{}); err != nil {
+		log.Fatal(err)
+	}
+
+	shops := []Shop{
+		{Name: "Bakery", Location: gormpostgis.NewColumn(&postgis.PointS{SRID: 4326, X: 2.3522, Y: 48.8566})},
+		{Name: "Florist", Location: gormpostgis.NewColumn(&postgis.PointS{SRID: 4326, X: 2.2945, Y: 48.8584})},
+		{Name: "Grocer", Location: gormpostgis.NewColumn(&postgis.PointS{SRID: 4326, X: 4.8357, Y: 45.7640})},
+	}
+	if err := db.Create(&shops).Error; err != nil {
+		log.Fatal(err)
+	}
+
+	// The shops within 10 km of a point, nearest first. The distance is
+	// measured on the geography, in meters; casting the column means the
+	// filter cannot use the index of the geometry column, which would need
+	// an index on (location::geography). The ordering compares the geometry
+	// column itself, so it uses the spatial index.
+	here := postgis.Param(&postgis.PointS{SRID: 4326, X: 2.3376, Y: 48.8606})
+	location := postgis.Column("location")
+	var nearby []Shop
+	err = db.
+		Where(gormpostgis.Expr(postgis.DWithin(postgis.AsGeography(location), postgis.AsGeography(here), 10000))).
+		Order(clause.OrderBy{Expression: gormpostgis.Expr(postgis.KNN(location, here))}).
+		Limit(10).
+		Find(&nearby).Error
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, shop := range nearby {
+		fmt.Printf("%s at %v, %v\n", shop.Name, shop.Location.Geometry.X, shop.Location.Geometry.Y)
+	}
+}
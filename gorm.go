@@ -0,0 +1,62 @@
+package postgis
+
+import "reflect"
+
+// gormDataType returns the data type for GORM of G, a pointer to a geometry
+// type, as the type and dimensions such as geometry(PointZ), or geometry for
+// the Geometry interface. Implementing GormDataType needs no import of GORM, so
+// AutoMigrate creates the same columns for these types as for gormpostgis
+// columns, which add the SRID and geography from the gorm tag, such as
+// geometry(PointZ,4326).
+func gormDataType[G Geometry]() string {
+	target := reflect.TypeFor[G]()
+	if target.Kind() != reflect.Pointer {
+		// The Geometry interface, for a column of any type
+		return ColumnType{}.String()
+	}
+	info := GetGeometryInfo(reflect.New(target.Elem()).Interface().(Geometry).GetType())
+	return ColumnType{Typmod: true, BaseType: info.BaseType, CoordType: info.CoordType}.String()
+}
+
+// GormDataType implements GORM's schema.GormDataTypeInterface
+func (n Null[T]) GormDataType() string { return gormDataType[T]() }
+
+// Implement GORM's schema.GormDataTypeInterface for all types
+func (p Point) GormDataType() string                  { return gormDataType[*Point]() }
+func (p PointZ) GormDataType() string                 { return gormDataType[*PointZ]() }
+func (p PointM) GormDataType() string                 { return gormDataType[*PointM]() }
+func (p PointZM) GormDataType() string                { return gormDataType[*PointZM]() }
+func (p PointS) GormDataType() string                 { return gormDataType[*PointS]() }
+func (p PointZS) GormDataType() string                { return gormDataType[*PointZS]() }
+func (p PointMS) GormDataType() string                { return gormDataType[*PointMS]() }
+func (p PointZMS) GormDataType() string               { return gormDataType[*PointZMS]() }
+func (gc GeometryCollection) GormDataType() string    { return gormDataType[*GeometryCollection]() }
+func (gc GeometryCollectionS) GormDataType() string   { return gormDataType[*GeometryCollectionS]() }
+func (ls LineStringOf[P]) GormDataType() string       { return gormDataType[*LineStringOf[P]]() }
+func (ls LineStringSOf[P]) GormDataType() string      { return gormDataType[*LineStringSOf[P]]() }
+func (p PolygonOf[P]) GormDataType() string           { return gormDataType[*PolygonOf[P]]() }
+func (p PolygonSOf[P]) GormDataType() string          { return gormDataType[*PolygonSOf[P]]() }
+func (m MultiPointOf[P]) GormDataType() string        { return gormDataType[*MultiPointOf[P]]() }
+func (m MultiPointSOf[P]) GormDataType() string       { return gormDataType[*MultiPointSOf[P]]() }
+func (m MultiLineStringOf[P]) GormDataType() string   { return gormDataType[*MultiLineStringOf[P]]() }
+func (m MultiLineStringSOf[P]) GormDataType() string  { return gormDataType[*MultiLineStringSOf[P]]() }
+func (m MultiPolygonOf[P]) GormDataType() string      { return gormDataType[*MultiPolygonOf[P]]() }
+func (m MultiPolygonSOf[P]) GormDataType() string     { return gormDataType[*MultiPolygonSOf[P]]() }
+func (cs CircularStringOf[P]) GormDataType() string   { return gormDataType[*CircularStringOf[P]]() }
+func (cs CircularStringSOf[P]) GormDataType() string  { return gormDataType[*CircularStringSOf[P]]() }
+func (c CompoundCurveOf[P]) GormDataType() string     { return gormDataType[*CompoundCurveOf[P]]() }
+func (c CompoundCurveSOf[P]) GormDataType() string    { return gormDataType[*CompoundCurveSOf[P]]() }
+func (p CurvePolygonOf[P]) GormDataType() string      { return gormDataType[*CurvePolygonOf[P]]() }
+func (p CurvePolygonSOf[P]) GormDataType() string     { return gormDataType[*CurvePolygonSOf[P]]() }
+func (m MultiCurveOf[P]) GormDataType() string        { return gormDataType[*MultiCurveOf[P]]() }
+func (m MultiCurveSOf[P]) GormDataType() string       { return gormDataType[*MultiCurveSOf[P]]() }
+func (m MultiSurfaceOf[P]) GormDataType() string      { return gormDataType[*MultiSurfaceOf[P]]() }
+func (m MultiSurfaceSOf[P]) GormDataType() string     { return gormDataType[*MultiSurfaceSOf[P]]() }
+func (s PolyhedralSurfaceOf[P]) GormDataType() string { return gormDataType[*PolyhedralSurfaceOf[P]]() }
+func (s PolyhedralSurfaceSOf[P]) GormDataType() string {
+	return gormDataType[*PolyhedralSurfaceSOf[P]]()
+}
+func (m TINOf[P]) GormDataType() string       { return gormDataType[*TINOf[P]]() }
+func (m TINSOf[P]) GormDataType() string      { return gormDataType[*TINSOf[P]]() }
+func (t TriangleOf[P]) GormDataType() string  { return gormDataType[*TriangleOf[P]]() }
+func (t TriangleSOf[P]) GormDataType() string { return gormDataType[*TriangleSOf[P]]() }
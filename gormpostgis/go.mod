@@ -0,0 +1,16 @@
+module github.com/cridenour/go-postgis/gormpostgis
+
+go 1.22
+
+require (
+	github.com/cridenour/go-postgis v0.0.0
+	gorm.io/gorm v1.31.2
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/text v0.21.0 // indirect
+)
+
+replace github.com/cridenour/go-postgis => ..
@@ -0,0 +1,95 @@
+// Package gormpostgis integrates go-postgis with GORM: Column gives
+// AutoMigrate typed geometry columns, and Expr turns the expressions of the
+// postgis package into query clauses. It is a module of its own so that
+// the postgis package does not depend on GORM.
+//
+//	type Shop struct {
+//		ID       uint
+//		Location gormpostgis.Column[*postgis.PointS]   `gorm:"srid:4326"`
+//		Area     gormpostgis.Column[*postgis.PolygonS] `gorm:"geography;srid:4326"`
+//	}
+//
+// The geometry types of the postgis package can also be used as fields
+// directly, for columns of their type without SRID, such as geometry(Point).
+package gormpostgis
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	postgis "github.com/cridenour/go-postgis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Column is a geometry field of a GORM model, for which AutoMigrate creates
+// a column of the type of T, such as geometry(PointZ,4326). Like
+// postgis.Null, whose fields and methods it shares, T is a pointer to a
+// geometry type, or the Geometry interface for a column of any type, and
+// the column may be NULL. The SRID and geography are read from the gorm tag
+// of the field.
+type Column[T postgis.Geometry] struct {
+	postgis.Null[T]
+}
+
+// NewColumn returns a valid Column holding g
+func NewColumn[T postgis.Geometry](g T) Column[T] {
+	return Column[T]{postgis.NewNull(g)}
+}
+
+// GormDBDataType implements GORM's migrator.GormDataTypeInterface. Other
+// dialects than PostgreSQL get an empty string, so that GORM uses
+// GormDataType.
+func (c Column[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db == nil || db.Config == nil || db.Dialector == nil || db.Dialector.Name() != "postgres" {
+		return ""
+	}
+	var column postgis.ColumnType
+	if target := reflect.TypeFor[T](); target.Kind() == reflect.Pointer {
+		info := postgis.GetGeometryInfo(reflect.New(target.Elem()).Interface().(postgis.Geometry).GetType())
+		column.BaseType, column.CoordType = info.BaseType, info.CoordType
+	}
+	if field != nil {
+		_, column.Geography = field.TagSettings["GEOGRAPHY"]
+		if value, ok := field.TagSettings["SRID"]; ok {
+			srid, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				_ = db.AddError(fmt.Errorf("gormpostgis: invalid srid %q for field %s: %w", value, field.Name, err))
+				return ""
+			}
+			column.SRID = int32(srid)
+		}
+	}
+	column.Typmod = column.BaseType != 0 || column.SRID != 0
+	return column.String()
+}
+
+// Expr returns e as a GORM clause expression, for Where, Order and Clauses,
+// whose parameters are written by the dialect:
+//
+//	here := postgis.Param(&location)
+//	db.Where(gormpostgis.Expr(postgis.DWithin(postgis.Column("location"), here, 500))).
+//		Order(clause.OrderBy{Expression: gormpostgis.Expr(postgis.KNN(postgis.Column("location"), here))}).
+//		Find(&shops)
+func Expr(e postgis.Expr) clause.Expression {
+	return expr(e)
+}
+
+// expr is a postgis.Expr as a GORM clause.Expression
+type expr postgis.Expr
+
+func (e expr) Build(builder clause.Builder) {
+	parts, args, err := postgis.Expr(e).Parts()
+	if err != nil {
+		_ = builder.AddError(err)
+		return
+	}
+	for i, part := range parts {
+		if i > 0 {
+			builder.AddVar(builder, args[i-1])
+		}
+		builder.WriteString(part)
+	}
+}
@@ -0,0 +1,146 @@
+package gormpostgis
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	postgis "github.com/cridenour/go-postgis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// dryRunDialector is a GORM dialector named postgres that only builds SQL
+type dryRunDialector struct{ name string }
+
+func (d dryRunDialector) Name() string { return d.name }
+
+func (d dryRunDialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+
+func (d dryRunDialector) Migrator(*gorm.DB) gorm.Migrator                { return nil }
+func (d dryRunDialector) DataTypeOf(field *schema.Field) string          { return string(field.DataType) }
+func (d dryRunDialector) DefaultValueOf(*schema.Field) clause.Expression { return nil }
+func (d dryRunDialector) Explain(sql string, _ ...interface{}) string    { return sql }
+func (d dryRunDialector) QuoteTo(writer clause.Writer, name string) {
+	writer.WriteString(`"` + name + `"`)
+}
+func (d dryRunDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, _ interface{}) {
+	writer.WriteString("$" + strconv.Itoa(len(stmt.Vars)))
+}
+
+func openDryRun(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(dryRunDialector{name: name}, &gorm.Config{DryRun: true, Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("Failed to open: %v", err)
+	}
+	return db
+}
+
+type gormShop struct {
+	ID       uint
+	Location Column[*postgis.PointS]           `gorm:"srid:4326"`
+	Entrance *Column[*postgis.PointZ]          `gorm:"srid:3857"`
+	Area     Column[*postgis.PolygonS]         `gorm:"geography;srid:4326"`
+	Route    Column[*postgis.MultiLineStringM] `gorm:"not null"`
+	Parcels  Column[*postgis.TINZS]            `gorm:"srid:2154"`
+	Anything Column[postgis.Geometry]          `gorm:"srid:4326"`
+	Items    Column[*postgis.GeometryCollection]
+	Plain    postgis.PointS
+	Badly    Column[*postgis.PointS] `gorm:"srid:x"`
+}
+
+func TestGormDBDataType(t *testing.T) {
+	db := openDryRun(t, "postgres")
+	shops, err := schema.Parse(&gormShop{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	// The data type is that of the geometry type, and the type for
+	// PostgreSQL adds the SRID and geography of the tag
+	expected := map[string][2]string{
+		"Location": {"geometry(Point)", "geometry(Point,4326)"},
+		"Entrance": {"geometry(PointZ)", "geometry(PointZ,3857)"},
+		"Area":     {"geometry(Polygon)", "geography(Polygon,4326)"},
+		"Route":    {"geometry(MultiLineStringM)", "geometry(MultiLineStringM)"},
+		"Parcels":  {"geometry(TINZ)", "geometry(TINZ,2154)"},
+		"Anything": {"geometry", "geometry(Geometry,4326)"},
+		"Items":    {"geometry(GeometryCollection)", "geometry(GeometryCollection)"},
+	}
+	for name, types := range expected {
+		field := shops.LookUpField(name)
+		if string(field.DataType) != types[0] {
+			t.Errorf("Expected data type %s for %s, got %s", types[0], name, field.DataType)
+		}
+		// As GORM's migrator does
+		typer, ok := reflect.New(field.IndirectFieldType).Interface().(interface {
+			GormDBDataType(*gorm.DB, *schema.Field) string
+		})
+		if !ok {
+			t.Errorf("%s does not implement GormDBDataType", name)
+			continue
+		}
+		if got := typer.GormDBDataType(db, field); got != types[1] {
+			t.Errorf("Expected %s for %s, got %s", types[1], name, got)
+		}
+	}
+
+	// The types of the postgis package are columns of their type, without SRID
+	if field := shops.LookUpField("Plain"); field.DataType != "geometry(Point)" {
+		t.Errorf("Expected data type geometry(Point) for Plain, got %s", field.DataType)
+	}
+
+	if typ := (Column[*postgis.PointS]{}).GormDBDataType(db, shops.LookUpField("Badly")); typ != "" || db.Error == nil {
+		t.Errorf("Expected an error for an invalid srid, got %q (%v)", typ, db.Error)
+	}
+	if typ := (Column[*postgis.PointS]{}).GormDBDataType(openDryRun(t, "mysql"), shops.LookUpField("Location")); typ != "" {
+		t.Errorf("Expected no type for other dialects, got %s", typ)
+	}
+}
+
+func TestExpr(t *testing.T) {
+	here := &postgis.PointS{SRID: 4326, X: 1, Y: 2}
+	var shops []gormShop
+	stmt := openDryRun(t, "postgres").
+		Where(Expr(postgis.DWithin(postgis.Column("location"), postgis.Param(here), 500))).
+		Where("id > ?", 10).
+		Order(clause.OrderBy{Expression: Expr(postgis.KNN(postgis.Column("location"), postgis.Param(here)))}).
+		Find(&shops).Statement
+	expected := `SELECT * FROM "gorm_shops" WHERE ST_DWithin("location", $1, $2) AND id > $3 ORDER BY "location" <-> $4`
+	if sql := stmt.SQL.String(); sql != expected {
+		t.Errorf("Expected %s, got %s", expected, sql)
+	}
+	if len(stmt.Vars) != 4 || stmt.Vars[0] != here || stmt.Vars[1] != 500.0 || stmt.Vars[3] != here {
+		t.Errorf("Unexpected vars %v", stmt.Vars)
+	}
+
+	if err := openDryRun(t, "postgres").Where(Expr(postgis.Format("%s"))).Find(&shops).Error; err == nil {
+		t.Error("Expected the error of an invalid expression")
+	}
+}
+
+func TestColumn(t *testing.T) {
+	column := NewColumn(&postgis.PointS{SRID: 4326, X: 1, Y: 2})
+	value, err := column.Value()
+	if err != nil {
+		t.Fatalf("Value() failed: %v", err)
+	}
+	var scanned Column[*postgis.PointS]
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	if !scanned.Valid || *scanned.Geometry != *column.Geometry {
+		t.Errorf("Expected %v, got %v", column.Geometry, scanned.Geometry)
+	}
+
+	if err := scanned.Scan(nil); err != nil || scanned.Valid {
+		t.Errorf("Expected NULL to scan as invalid, got %v (%v)", scanned, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/binary"
 	"io"
+	"math"
 )
 
 // Generic helper functions to reduce code duplication across geometry types
@@ -13,18 +14,23 @@ import (
 func scanGeometryHelper(g Geometry, value interface{}) error {
 	reader, err := DecodeEWKB(value)
 	if err != nil {
-		return err
+		return &DecodeError{Expected: g.GetType(), GeometryType: geometryTypeName(g), Err: err}
 	}
 	return ReadEWKB(reader, g)
 }
 
 // valueGeometryHelper provides common Value implementation for all geometry types
 func valueGeometryHelper(g Geometry) (driver.Value, error) {
-	buffer, err := WriteEWKB(g)
+	if validateOnValue.Load() {
+		if err := ValidateDetail(g).Err(); err != nil {
+			return nil, err
+		}
+	}
+	encoded, err := AppendHexEWKB(nil, g)
 	if err != nil {
 		return nil, err
 	}
-	return EncodeEWKB(buffer), nil
+	return string(encoded), nil
 }
 
 // writeElementsHelper provides common WriteElements implementation for collection types
@@ -39,16 +45,103 @@ func writeElementsHelper[T interface{ Write(*bytes.Buffer) error }](points []T,
 
 // readElementsHelper provides common ReadElements implementation for collection types
 func readElementsHelper[T any](reader io.Reader, byteOrder binary.ByteOrder, count uint32) ([]T, error) {
-	points := make([]T, count)
+	return readCoordinates[T](reader, byteOrder, count)
+}
+
+// getElementCountHelper provides common GetElementCount implementation
+func getElementCountHelper[T any](points []T) uint32 {
+	return uint32(len(points))
+}
+
+// writeRingsHelper provides common WriteElements implementation for polygon types
+func writeRingsHelper[T interface{ Write(*bytes.Buffer) error }](rings [][]T, buffer *bytes.Buffer) error {
+	for _, ring := range rings {
+		err := WriteGeometryCollection(buffer, getElementCountHelper(ring), func(buf *bytes.Buffer) error {
+			return writeElementsHelper(ring, buf)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readRingsHelper provides common ReadElements implementation for polygon types
+func readRingsHelper[T any](reader io.Reader, byteOrder binary.ByteOrder, count uint32) ([][]T, error) {
+	// Every ring takes at least its point count
+	capacity, err := checkCount(reader, count, 4)
+	if err != nil {
+		return nil, err
+	}
+	rings := make([][]T, 0, capacity)
 	for i := uint32(0); i < count; i++ {
-		if err := binary.Read(reader, byteOrder, &points[i]); err != nil {
+		pointCount, err := readUint32(reader, byteOrder)
+		if err != nil {
+			return nil, err
+		}
+		ring, err := readElementsHelper[T](reader, byteOrder, pointCount)
+		if err != nil {
 			return nil, err
 		}
+		rings = append(rings, ring)
 	}
-	return points, nil
+	return rings, nil
 }
 
-// getElementCountHelper provides common GetElementCount implementation
-func getElementCountHelper[T any](points []T) uint32 {
-	return uint32(len(points))
+// writeGeometriesHelper provides common WriteElements implementation for multi geometry types,
+// whose elements are complete WKB geometries
+func writeGeometriesHelper[T interface {
+	GetType() uint32
+	Write(*bytes.Buffer) error
+}](geometries []T, buffer *bytes.Buffer) error {
+	for _, g := range geometries {
+		if err := binary.Write(buffer, binary.LittleEndian, wkbNDR); err != nil {
+			return err
+		}
+		if err := binary.Write(buffer, binary.LittleEndian, g.GetType()); err != nil {
+			return err
+		}
+		if err := g.Write(buffer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minGeometrySize is the size of the smallest EWKB geometry: a header and an
+// element count of zero
+const minGeometrySize = 9
+
+// readGeometriesHelper provides common ReadElements implementation for multi geometry types.
+// *T must implement Geometry, which generic callers cannot express for point types.
+func readGeometriesHelper[T any](reader io.Reader, byteOrder binary.ByteOrder, count uint32) ([]T, error) {
+	capacity, err := checkCount(reader, count, minGeometrySize)
+	if err != nil {
+		return nil, err
+	}
+	geometries := make([]T, 0, capacity)
+	for i := uint32(0); i < count; i++ {
+		var g T
+		if err := ReadEWKB(reader, any(&g).(Geometry)); err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, g)
+	}
+	return geometries, nil
+}
+
+// coordinate is implemented by every point type
+type coordinate interface {
+	xy() Point
+	finite() bool
+}
+
+// isFinite reports whether none of the values is NaN or infinite
+func isFinite(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
 }
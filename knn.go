@@ -0,0 +1,199 @@
+package postgis
+
+import (
+	"math"
+	"sync"
+)
+
+// DistanceMetric selects how a PointIndex measures distances
+type DistanceMetric int
+
+const (
+	// Planar is the Euclidean distance in coordinate units, like the <->
+	// operator on geometry
+	Planar DistanceMetric = iota
+	// Geodesic is the great-circle distance in meters between points whose X
+	// is the longitude and Y the latitude in degrees, like the <-> operator on
+	// geography, which also uses a sphere
+	Geodesic
+)
+
+// EarthRadius is the radius in meters of the sphere used for Geodesic
+// distances, the mean radius of the WGS 84 ellipsoid used by PostGIS
+const EarthRadius = 6371008.7714150598
+
+// Neighbor is a point found by a nearest neighbour search and its distance
+// to the query point
+type Neighbor[P Point | PointS] struct {
+	Point    P
+	Distance float64
+}
+
+// KNNOptions restricts a nearest neighbour search. The zero value returns
+// the nearest points whatever their distance.
+type KNNOptions[P Point | PointS] struct {
+	// MaxDistance excludes points farther than this, like ST_DWithin. Zero
+	// means no limit.
+	MaxDistance float64
+	// Filter, when set, excludes the points for which it returns false
+	Filter func(P) bool
+}
+
+// PointIndex finds the nearest points to a location, for Point or PointS
+// values. It is an R-tree searched best first, so a query only visits the
+// points close to the answer. A PointIndex is safe for concurrent use.
+//
+// The index does not look at SRIDs: all points, and the query locations,
+// must use the same coordinate system.
+type PointIndex[P Point | PointS] struct {
+	mu     sync.RWMutex
+	metric DistanceMetric
+	tree   rtree[P]
+}
+
+// NewPointIndex returns an index measuring distances with metric, bulk
+// loaded with points
+func NewPointIndex[P Point | PointS](metric DistanceMetric, points []P) *PointIndex[P] {
+	entries := make([]rtreeEntry[P], len(points))
+	for i, p := range points {
+		entries[i] = rtreeEntry[P]{env: EmptyEnvelope().extendPoint(pointXY(p)), value: p}
+	}
+	idx := &PointIndex[P]{metric: metric}
+	idx.tree.load(entries)
+	return idx
+}
+
+// Insert adds a point to the index
+func (idx *PointIndex[P]) Insert(p P) {
+	env := EmptyEnvelope().extendPoint(pointXY(p))
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.tree.insert(env, p)
+}
+
+// Delete removes one point equal to p and reports whether it was found
+func (idx *PointIndex[P]) Delete(p P) bool {
+	env := EmptyEnvelope().extendPoint(pointXY(p))
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	return idx.tree.remove(env, func(other P) bool { return other == p })
+}
+
+// Len returns the number of points in the index
+func (idx *PointIndex[P]) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.tree.size
+}
+
+// Nearest returns up to k points closest to q that pass the options, nearest
+// first. Geodesic distances are in meters.
+func (idx *PointIndex[P]) Nearest(q Point, k int, opts KNNOptions[P]) []Neighbor[P] {
+	var result []Neighbor[P]
+	if k <= 0 {
+		return result
+	}
+	idx.NearestFunc(q, opts, func(n Neighbor[P]) bool {
+		result = append(result, n)
+		return len(result) < k
+	})
+	return result
+}
+
+// NearestFunc calls fn with the points that pass the options in order of
+// increasing distance to q, until fn returns false. fn runs with the index
+// locked for reading and must not modify it.
+func (idx *PointIndex[P]) NearestFunc(q Point, opts KNNOptions[P], fn func(Neighbor[P]) bool) {
+	maxDistance := opts.MaxDistance
+	if maxDistance <= 0 {
+		maxDistance = math.Inf(1)
+	}
+	boxDistance := func(env Envelope) float64 { return env.Distance(q) }
+	itemDistance := func(_ Envelope, p P) float64 { return distance(q, pointXY(p)) }
+	if idx.metric == Geodesic {
+		boxDistance = func(env Envelope) float64 { return geodesicBoxDistance(q, env) }
+		itemDistance = func(_ Envelope, p P) float64 { return haversine(q, pointXY(p)) }
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	idx.tree.nearest(boxDistance, itemDistance, maxDistance, func(p P, d float64) bool {
+		if opts.Filter != nil && !opts.Filter(p) {
+			return true
+		}
+		return fn(Neighbor[P]{Point: p, Distance: d})
+	})
+}
+
+func pointXY[P Point | PointS](p P) Point {
+	switch p := any(p).(type) {
+	case Point:
+		return p
+	case PointS:
+		return p.xy()
+	}
+	return emptyPoint
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// haversine returns the great-circle distance in meters between two
+// longitude/latitude points
+func haversine(a, b Point) float64 {
+	return EarthRadius * centralAngle(radians(a.Y), radians(b.Y), radians(b.X-a.X))
+}
+
+// centralAngle returns the angle between two points on the sphere given
+// their latitudes and the difference of their longitudes, in radians
+func centralAngle(lat1, lat2, dLon float64) float64 {
+	h := math.Pow(math.Sin((lat2-lat1)/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// geodesicBoxDistance returns the great-circle distance in meters from p to
+// the nearest point of a longitude/latitude box, which is never more than the
+// distance to any point inside it
+func geodesicBoxDistance(p Point, env Envelope) float64 {
+	lat := radians(p.Y)
+	minLat, maxLat := radians(env.MinY), radians(env.MaxY)
+
+	withinLon := false
+	for _, lon := range []float64{p.X - 360, p.X, p.X + 360} {
+		withinLon = withinLon || (env.MinX <= lon && lon <= env.MaxX)
+	}
+	if withinLon {
+		// p lies within the longitudes of the box, so the nearest point is
+		// straight north or south
+		switch {
+		case lat < minLat:
+			return EarthRadius * (minLat - lat)
+		case lat > maxLat:
+			return EarthRadius * (lat - maxLat)
+		}
+		return 0
+	}
+
+	// Otherwise the nearest point lies on the closer meridian edge. Along a
+	// meridian the distance has a single minimum, at the latitude where the
+	// meridian comes closest to p when it faces p, or at the poles otherwise.
+	dLon := radians(math.Min(lonDifference(p.X, env.MinX), lonDifference(p.X, env.MaxX)))
+	angle := math.Min(centralAngle(lat, minLat, dLon), centralAngle(lat, maxLat, dLon))
+	if math.Cos(dLon) > 0 {
+		closest := math.Atan(math.Tan(lat) / math.Cos(dLon))
+		closest = math.Max(minLat, math.Min(maxLat, closest))
+		angle = math.Min(angle, centralAngle(lat, closest, dLon))
+	}
+	return EarthRadius * angle
+}
+
+// lonDifference returns the absolute difference between two longitudes in
+// degrees, going the short way around
+func lonDifference(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
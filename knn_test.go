@@ -0,0 +1,134 @@
+package postgis
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestHaversine(t *testing.T) {
+	paris := Point{X: 2.3522, Y: 48.8566}
+	london := Point{X: -0.1276, Y: 51.5072}
+	if d := haversine(paris, london); math.Abs(d-343923) > 1000 {
+		t.Errorf("Expected about 344 km from Paris to London, got %f", d)
+	}
+	// Across the antimeridian
+	if d := haversine(Point{X: 179.5, Y: 0}, Point{X: -179.5, Y: 0}); math.Abs(d-EarthRadius*math.Pi/180) > 1e-6 {
+		t.Errorf("Expected one degree along the equator, got %f", d)
+	}
+}
+
+func TestGeodesicBoxDistance(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		p := Point{X: r.Float64()*360 - 180, Y: r.Float64()*180 - 90}
+		minX, minY := r.Float64()*360-180, r.Float64()*180-90
+		env := Envelope{MinX: minX, MinY: minY, MaxX: math.Min(180, minX+r.Float64()*200), MaxY: math.Min(90, minY+r.Float64()*60)}
+
+		// The bound must not exceed the distance to any point of the box and
+		// must be reached on its boundary
+		bound := geodesicBoxDistance(p, env)
+		best := math.Inf(1)
+		for j := 0; j <= 200; j++ {
+			f := float64(j) / 200
+			for _, q := range []Point{
+				{X: env.MinX, Y: env.MinY + f*(env.MaxY-env.MinY)},
+				{X: env.MaxX, Y: env.MinY + f*(env.MaxY-env.MinY)},
+				{X: env.MinX + f*(env.MaxX-env.MinX), Y: env.MinY},
+				{X: env.MinX + f*(env.MaxX-env.MinX), Y: env.MaxY},
+			} {
+				best = math.Min(best, haversine(p, q))
+			}
+		}
+		if bound > best+1e-6 {
+			t.Fatalf("Bound %f exceeds distance %f from %v to %+v", bound, best, p, env)
+		}
+		if bound > 0 && best-bound > 0.002*EarthRadius {
+			t.Fatalf("Bound %f is far below distance %f from %v to %+v", bound, best, p, env)
+		}
+	}
+}
+
+func TestPointIndexNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	points := make([]PointS, 5000)
+	for i := range points {
+		points[i] = PointS{SRID: 4326, X: r.Float64()*360 - 180, Y: r.Float64()*170 - 85}
+	}
+
+	for _, metric := range []DistanceMetric{Planar, Geodesic} {
+		measure := func(a, b Point) float64 { return distance(a, b) }
+		if metric == Geodesic {
+			measure = haversine
+		}
+		idx := NewPointIndex(metric, points)
+
+		for i := 0; i < 20; i++ {
+			q := Point{X: r.Float64()*360 - 180, Y: r.Float64()*170 - 85}
+			distances := make([]float64, len(points))
+			for j, p := range points {
+				distances[j] = measure(q, p.xy())
+			}
+			sort.Float64s(distances)
+
+			neighbors := idx.Nearest(q, 10, KNNOptions[PointS]{})
+			if len(neighbors) != 10 {
+				t.Fatalf("Expected 10 neighbors, got %d", len(neighbors))
+			}
+			for j, n := range neighbors {
+				if math.Abs(n.Distance-distances[j]) > 1e-6 {
+					t.Fatalf("Metric %d: expected distance %f at rank %d, got %f", metric, distances[j], j, n.Distance)
+				}
+				if n.Point.SRID != 4326 {
+					t.Fatalf("Expected SRID 4326, got %d", n.Point.SRID)
+				}
+			}
+		}
+	}
+}
+
+func TestPointIndexOptions(t *testing.T) {
+	depots := []Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}, {X: 10, Y: 0}}
+	idx := NewPointIndex(Planar, depots)
+
+	within := idx.Nearest(Point{X: 0, Y: 0}, 10, KNNOptions[Point]{MaxDistance: 2.5})
+	if len(within) != 3 {
+		t.Errorf("Expected 3 points within 2.5, got %v", within)
+	}
+
+	odd := idx.Nearest(Point{X: 0, Y: 0}, 2, KNNOptions[Point]{Filter: func(p Point) bool { return int(p.X)%2 == 1 }})
+	if len(odd) != 2 || odd[0].Point.X != 1 || odd[1].Point.X != 3 {
+		t.Errorf("Expected the points at 1 and 3, got %v", odd)
+	}
+
+	idx.Insert(Point{X: 0.5, Y: 0})
+	if !idx.Delete(Point{X: 0, Y: 0}) || idx.Delete(Point{X: 0, Y: 0}) {
+		t.Errorf("Expected the point to be deleted once")
+	}
+	if nearest := idx.Nearest(Point{X: 0, Y: 0}, 1, KNNOptions[Point]{}); len(nearest) != 1 || nearest[0].Point.X != 0.5 {
+		t.Errorf("Expected the inserted point, got %v", nearest)
+	}
+	if idx.Len() != 5 {
+		t.Errorf("Expected 5 points, got %d", idx.Len())
+	}
+
+	// Across the antimeridian
+	geo := NewPointIndex(Geodesic, []PointS{{SRID: 4326, X: 179.9, Y: 0}, {SRID: 4326, X: 170, Y: 0}})
+	if nearest := geo.Nearest(Point{X: -179.9, Y: 0}, 1, KNNOptions[PointS]{MaxDistance: 50000}); len(nearest) != 1 || nearest[0].Point.X != 179.9 {
+		t.Errorf("Expected the point across the antimeridian, got %v", nearest)
+	}
+}
+
+func BenchmarkPointIndexNearest(b *testing.B) {
+	r := rand.New(rand.NewSource(3))
+	points := make([]PointS, 100000)
+	for i := range points {
+		points[i] = PointS{SRID: 4326, X: r.Float64()*360 - 180, Y: r.Float64()*170 - 85}
+	}
+	idx := NewPointIndex(Geodesic, points)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx.Nearest(Point{X: r.Float64()*360 - 180, Y: r.Float64()*170 - 85}, 5, KNNOptions[PointS]{})
+	}
+}
@@ -208,3 +208,66 @@ func TestEmptyLineString(t *testing.T) {
 		t.Errorf("Expected 0 points, got %d", len(ls2.Points))
 	}
 }
+
+// pathLength is written once for every coordinate type
+func pathLength[P PointLike](ls LineStringOf[P]) float64 {
+	var total float64
+	for i := 1; i < len(ls.Points); i++ {
+		total += distance(ls.Points[i-1].xy(), ls.Points[i].xy())
+	}
+	return total
+}
+
+func TestLineStringOf(t *testing.T) {
+	// The named types are instantiations of the generic ones
+	var ls LineStringOf[PointZ] = LineStringZ{Points: []PointZ{{X: 0, Y: 0, Z: 1}, {X: 3, Y: 4, Z: 2}}}
+	if got := pathLength(ls); got != 5 {
+		t.Errorf("Expected length 5, got %f", got)
+	}
+
+	types := map[Geometry]uint32{
+		&LineStringOf[Point]{}:        WKBLineString,
+		&LineStringOf[PointZ]{}:       WKBLineString | WKBZFlag,
+		&LineStringOf[PointM]{}:       WKBLineString | WKBMFlag,
+		&LineStringOf[PointZM]{}:      WKBLineString | WKBZFlag | WKBMFlag,
+		&LineStringSOf[Point]{}:       WKBLineString | WKBSRIDFlag,
+		&LineStringSOf[PointZM]{}:     WKBLineString | WKBZFlag | WKBMFlag | WKBSRIDFlag,
+		&PolygonSOf[PointM]{}:         WKBPolygon | WKBMFlag | WKBSRIDFlag,
+		&MultiPointOf[PointZ]{}:       WKBMultiPoint | WKBZFlag,
+		&MultiLineStringSOf[PointZ]{}: WKBMultiLineString | WKBZFlag | WKBSRIDFlag,
+		&MultiPolygonOf[PointZM]{}:    WKBMultiPolygon | WKBZFlag | WKBMFlag,
+	}
+	for g, expected := range types {
+		if got := g.GetType(); got != expected {
+			t.Errorf("%s: expected type 0x%08X, got 0x%08X", geometryTypeName(g), expected, got)
+		}
+	}
+
+	// Only the SRID variants carry an SRID
+	if _, ok := Geometry(&LineStringOf[PointM]{}).(SRIDGeometry); ok {
+		t.Errorf("Expected LineStringOf not to implement SRIDGeometry")
+	}
+	if _, ok := Geometry(&LineStringSOf[PointM]{}).(SRIDGeometry); !ok {
+		t.Errorf("Expected LineStringSOf to implement SRIDGeometry")
+	}
+}
+
+func TestLineStringOfRoundTrip(t *testing.T) {
+	ls := LineStringSOf[PointZM]{SRID: 4326, Points: []PointZM{{X: 1, Y: 2, Z: 3, M: 4}, {X: 5, Y: 6, Z: 7, M: 8}}}
+	value, err := ls.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	var decoded LineStringZMS
+	if err := decoded.Scan(value); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if decoded.SRID != ls.SRID || len(decoded.Points) != 2 || decoded.Points[1] != ls.Points[1] {
+		t.Errorf("Expected %v, got %v", ls, decoded)
+	}
+
+	// Planar operations use X and Y of any coordinate type
+	if c := decoded.Centroid(); c.SRID != 4326 || c.X != 3 || c.Y != 4 {
+		t.Errorf("Expected centroid (3 4) with SRID 4326, got %v", c)
+	}
+}
@@ -0,0 +1,275 @@
+package postgis
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotRepairable is returned by MakeValid for geometries it cannot repair
+var ErrNotRepairable = errors.New("geometry cannot be made valid")
+
+// MakeValid repairs a 2D geometry the way ST_MakeValid does with its default
+// "linework" method. Repeated vertices and vertices with NaN or infinite
+// coordinates are dropped, unclosed rings are closed and polygon rings are
+// re-noded so that self-intersections, overlapping rings and holes outside
+// their shell are resolved with the even-odd rule. Exterior rings of the
+// result are counter-clockwise and holes clockwise.
+//
+// Parts that collapse keep their lower dimension: a ring folded onto itself
+// becomes a line and a line whose points are all equal becomes a point. The
+// result therefore has the type of the input (a Polygon may become a
+// MultiPolygon when it splits) or is a GeometryCollection mixing the pieces.
+// The SRID of the input is kept.
+//
+// Points, lines and polygons with SRID and without are supported, as well as
+// their Multi* types and GeometryCollections of them. Other types, and points
+// with invalid coordinates, return ErrNotRepairable. So do geometries with Z
+// or M, since re-noding would have to invent the ordinates of new vertices;
+// ForceXY drops them for a 2D repair.
+func MakeValid(g Geometry) (Geometry, error) {
+	switch g := g.(type) {
+	case *Point:
+		if !g.finite() {
+			return nil, fmt.Errorf("%w: point has invalid coordinates", ErrNotRepairable)
+		}
+		p := *g
+		return &p, nil
+	case *PointS:
+		if !g.finite() {
+			return nil, fmt.Errorf("%w: point has invalid coordinates", ErrNotRepairable)
+		}
+		p := *g
+		return &p, nil
+	case *MultiPoint:
+		return &MultiPoint{Points: finitePoints(g.Points)}, nil
+	case *MultiPointS:
+		return &MultiPointS{SRID: g.SRID, Points: finitePoints(g.Points)}, nil
+	case *LineString:
+		return repairedResult(repairLines([][]Point{g.Points}, false), 0, false), nil
+	case *LineStringS:
+		return repairedResult(repairLines([][]Point{g.Points}, false), g.SRID, true), nil
+	case *MultiLineString:
+		return repairedResult(repairLines(lineStringParts(g.LineStrings), true), 0, false), nil
+	case *MultiLineStringS:
+		return repairedResult(repairLines(lineStringParts(g.LineStrings), true), g.SRID, true), nil
+	case *Polygon:
+		return repairedResult(repairPolygons([][][]Point{g.Rings}, false), 0, false), nil
+	case *PolygonS:
+		return repairedResult(repairPolygons([][][]Point{g.Rings}, false), g.SRID, true), nil
+	case *MultiPolygon:
+		return repairedResult(repairPolygons(polygonRings(g.Polygons, func(p Polygon) [][]Point { return p.Rings }), true), 0, false), nil
+	case *MultiPolygonS:
+		return repairedResult(repairPolygons(polygonRings(g.Polygons, func(p Polygon) [][]Point { return p.Rings }), true), g.SRID, true), nil
+	case *GeometryCollection:
+		members, err := makeValidMembers(g.Geometries)
+		if err != nil {
+			return nil, err
+		}
+		return &GeometryCollection{Geometries: members}, nil
+	case *GeometryCollectionS:
+		members, err := makeValidMembers(g.Geometries)
+		if err != nil {
+			return nil, err
+		}
+		return &GeometryCollectionS{SRID: g.SRID, Geometries: members}, nil
+	default:
+		if g != nil && GetGeometryInfo(g.GetType()).CoordType != CoordXY {
+			return nil, fmt.Errorf("%w: %s has Z or M, only 2D geometries are supported", ErrNotRepairable, geometryTypeName(g))
+		}
+		return nil, fmt.Errorf("%w: geometry type %s is not supported", ErrNotRepairable, geometryTypeName(g))
+	}
+}
+
+func makeValidMembers(geometries []Geometry) ([]Geometry, error) {
+	members := make([]Geometry, len(geometries))
+	for i, g := range geometries {
+		member, err := MakeValid(g)
+		if err != nil {
+			return nil, err
+		}
+		members[i] = member
+	}
+	return members, nil
+}
+
+// repaired holds the pieces of a repaired geometry by dimension
+type repaired struct {
+	polygons []Polygon
+	lines    [][]Point
+	points   []Point
+	// multi is set when the input was a Multi* type, which is kept even when
+	// a single part remains
+	multi bool
+	// areal is set for polygonal input, so that an empty result stays polygonal
+	areal bool
+}
+
+// repairedResult wraps repaired pieces in the type matching the input
+func repairedResult(r repaired, srid int32, hasSRID bool) Geometry {
+	var parts []Geometry
+	if len(r.polygons) > 0 || (r.areal && len(r.lines) == 0 && len(r.points) == 0) {
+		switch {
+		case len(r.polygons) == 1 && !r.multi && hasSRID:
+			parts = append(parts, &PolygonS{SRID: srid, Rings: r.polygons[0].Rings})
+		case len(r.polygons) == 1 && !r.multi:
+			parts = append(parts, &r.polygons[0])
+		case len(r.polygons) == 0 && !r.multi && hasSRID:
+			parts = append(parts, &PolygonS{SRID: srid})
+		case len(r.polygons) == 0 && !r.multi:
+			parts = append(parts, &Polygon{})
+		case hasSRID:
+			parts = append(parts, &MultiPolygonS{SRID: srid, Polygons: r.polygons})
+		default:
+			parts = append(parts, &MultiPolygon{Polygons: r.polygons})
+		}
+	}
+	if len(r.lines) > 0 || (!r.areal && len(r.points) == 0) {
+		lineStrings := make([]LineString, len(r.lines))
+		for i, line := range r.lines {
+			lineStrings[i] = LineString{Points: line}
+		}
+		switch {
+		case len(lineStrings) <= 1 && !r.multi && hasSRID:
+			ls := LineStringS{SRID: srid}
+			if len(lineStrings) == 1 {
+				ls.Points = lineStrings[0].Points
+			}
+			parts = append(parts, &ls)
+		case len(lineStrings) <= 1 && !r.multi:
+			ls := LineString{}
+			if len(lineStrings) == 1 {
+				ls.Points = lineStrings[0].Points
+			}
+			parts = append(parts, &ls)
+		case hasSRID:
+			parts = append(parts, &MultiLineStringS{SRID: srid, LineStrings: lineStrings})
+		default:
+			parts = append(parts, &MultiLineString{LineStrings: lineStrings})
+		}
+	}
+	if len(r.points) > 0 {
+		switch {
+		case len(r.points) == 1 && !r.multi && hasSRID:
+			parts = append(parts, &PointS{SRID: srid, X: r.points[0].X, Y: r.points[0].Y})
+		case len(r.points) == 1 && !r.multi:
+			parts = append(parts, &r.points[0])
+		case hasSRID:
+			parts = append(parts, &MultiPointS{SRID: srid, Points: r.points})
+		default:
+			parts = append(parts, &MultiPoint{Points: r.points})
+		}
+	}
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	if hasSRID {
+		return &GeometryCollectionS{SRID: srid, Geometries: parts}
+	}
+	return &GeometryCollection{Geometries: parts}
+}
+
+// cleanPoints drops vertices with invalid coordinates and repeated vertices
+func cleanPoints(points []Point) []Point {
+	cleaned := make([]Point, 0, len(points))
+	for _, p := range points {
+		if !p.finite() {
+			continue
+		}
+		if n := len(cleaned); n == 0 || cleaned[n-1] != p {
+			cleaned = append(cleaned, p)
+		}
+	}
+	return cleaned
+}
+
+func finitePoints(points []Point) []Point {
+	finite := make([]Point, 0, len(points))
+	for _, p := range points {
+		if p.finite() {
+			finite = append(finite, p)
+		}
+	}
+	return finite
+}
+
+func repairLines(lines [][]Point, multi bool) repaired {
+	r := repaired{multi: multi}
+	for _, line := range lines {
+		switch cleaned := cleanPoints(line); len(cleaned) {
+		case 0:
+		case 1:
+			r.points = append(r.points, cleaned[0])
+		default:
+			r.lines = append(r.lines, cleaned)
+		}
+	}
+	return r
+}
+
+func repairPolygons(polygons [][][]Point, multi bool) repaired {
+	r := repaired{multi: multi, areal: true}
+
+	cleanedPolygons := make([][][]Point, 0, len(polygons))
+	var rings [][]Point
+	for _, polygon := range polygons {
+		var cleanedRings [][]Point
+		for _, ring := range polygon {
+			cleaned := cleanPoints(ring)
+			if len(cleaned) > 1 && cleaned[0] == cleaned[len(cleaned)-1] {
+				cleaned = cleaned[:len(cleaned)-1]
+			}
+			switch len(cleaned) {
+			case 0:
+				continue
+			case 1:
+				r.points = append(r.points, cleaned[0])
+				continue
+			}
+			cleaned = append(cleaned, cleaned[0])
+			cleanedRings = append(cleanedRings, cleaned)
+			rings = append(rings, cleaned)
+		}
+		if len(cleanedRings) > 0 {
+			cleanedPolygons = append(cleanedPolygons, cleanedRings)
+		}
+	}
+
+	// Valid input only needs its rings oriented
+	if len(r.points) == 0 && validatePolygons(cleanedPolygons).Valid {
+		for _, polygon := range cleanedPolygons {
+			for i, ring := range polygon {
+				if (i == 0) != (ringArea(ring) > 0) {
+					reverseRing(ring)
+				}
+			}
+			r.polygons = append(r.polygons, Polygon{Rings: polygon})
+		}
+		return r
+	}
+
+	// The rings are added once as area, read with the even-odd rule, and once
+	// as lines so that edges where a ring folds back onto itself survive as
+	// collapsed linework
+	g := newPlanarGraph(rings)
+	g.addRings(0, rings)
+	g.addLines(1, rings)
+	g.node()
+	g.buildEdges()
+	g.label()
+
+	inside := func(winding [maxGraphSources]int) bool {
+		return fillEvenOdd.inside(winding[0])
+	}
+	r.polygons = g.polygons(inside)
+	r.lines = g.lines(func(e *graphEdge) bool {
+		return e.lines[1] > 0 && !fillEvenOdd.inside(e.winding[0][0]) && !fillEvenOdd.inside(e.winding[0][1])
+	})
+	return r
+}
+
+func reverseRing(ring []Point) {
+	for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+		ring[i], ring[j] = ring[j], ring[i]
+	}
+}
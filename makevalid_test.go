@@ -0,0 +1,146 @@
+package postgis
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMakeValidPolygons(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry Geometry
+		area     float64
+		polygons int
+	}{
+		{"Bowtie", &PolygonS{SRID: 4326, Rings: [][]Point{{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 0}}}}, 2, 2},
+		{"Hole outside shell", &Polygon{Rings: [][]Point{square(0, 0, 1), square(5, 5, 1)}}, 2, 2},
+		{"Hole crossing shell", &Polygon{Rings: [][]Point{square(0, 0, 4), square(2, 2, 4)}}, 24, 2},
+		{"Overlapping MultiPolygon", &MultiPolygon{Polygons: []Polygon{{Rings: [][]Point{square(0, 0, 2)}}, {Rings: [][]Point{square(1, 1, 2)}}}}, 6, 2},
+		{"Self touching ring", &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 2}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 2, Y: 2}, {X: 0, Y: 0}}}}, 8, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := MakeValid(test.geometry)
+			if err != nil {
+				t.Fatalf("MakeValid failed: %v", err)
+			}
+			if detail := ValidateDetail(result); !detail.Valid {
+				t.Fatalf("Result is invalid: %s at %v", detail.Reason, detail.Location)
+			}
+
+			var polygons []Polygon
+			switch r := result.(type) {
+			case *MultiPolygon:
+				polygons = r.Polygons
+			case *MultiPolygonS:
+				polygons = r.Polygons
+				if r.SRID != 4326 {
+					t.Errorf("Expected SRID 4326, got %d", r.SRID)
+				}
+			default:
+				t.Fatalf("Expected a MultiPolygon, got %T", result)
+			}
+			if len(polygons) != test.polygons {
+				t.Errorf("Expected %d polygons, got %d", test.polygons, len(polygons))
+			}
+			var area float64
+			for _, p := range polygons {
+				area += polygonArea(p.Rings)
+			}
+			if math.Abs(area-test.area) > 1e-9 {
+				t.Errorf("Expected area %f, got %f", test.area, area)
+			}
+		})
+	}
+}
+
+func TestMakeValidCleansValidPolygon(t *testing.T) {
+	// A clockwise shell with a repeated vertex and a missing closing point
+	p := &PolygonS{SRID: 3857, Rings: [][]Point{{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}}}}
+
+	result, err := MakeValid(p)
+	if err != nil {
+		t.Fatalf("MakeValid failed: %v", err)
+	}
+	fixed, ok := result.(*PolygonS)
+	if !ok {
+		t.Fatalf("Expected *PolygonS, got %T", result)
+	}
+	if fixed.SRID != 3857 {
+		t.Errorf("Expected SRID 3857, got %d", fixed.SRID)
+	}
+	ring := fixed.Rings[0]
+	if len(ring) != 5 || ring[0] != ring[4] {
+		t.Errorf("Expected a closed ring of 5 points, got %v", ring)
+	}
+	if ringArea(ring) <= 0 {
+		t.Errorf("Expected a counter-clockwise shell, got %v", ring)
+	}
+}
+
+func TestMakeValidCollapsed(t *testing.T) {
+	// A ring with a spike keeps the spike as a line
+	spike := &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 4, Y: 8}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}}}}
+	result, err := MakeValid(spike)
+	if err != nil {
+		t.Fatalf("MakeValid failed: %v", err)
+	}
+	gc, ok := result.(*GeometryCollection)
+	if !ok || len(gc.Geometries) != 2 {
+		t.Fatalf("Expected a polygon and a line, got %#v", result)
+	}
+	if _, ok := gc.Geometries[0].(*Polygon); !ok {
+		t.Errorf("Expected *Polygon, got %T", gc.Geometries[0])
+	}
+	if ls, ok := gc.Geometries[1].(*LineString); !ok || len(ls.Points) != 2 {
+		t.Errorf("Expected the spike as a line, got %#v", gc.Geometries[1])
+	}
+
+	// A ring folded flat becomes a line
+	result, err = MakeValid(&Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 0, Y: 0}}}})
+	if err != nil {
+		t.Fatalf("MakeValid failed: %v", err)
+	}
+	if _, ok := result.(*LineString); !ok {
+		t.Errorf("Expected *LineString, got %#v", result)
+	}
+
+	// A line with repeated and invalid points becomes a point
+	result, err = MakeValid(&LineStringS{SRID: 4326, Points: []Point{{X: 1, Y: 2}, {X: math.NaN(), Y: 0}, {X: 1, Y: 2}}})
+	if err != nil {
+		t.Fatalf("MakeValid failed: %v", err)
+	}
+	if p, ok := result.(*PointS); !ok || p.SRID != 4326 || p.X != 1 || p.Y != 2 {
+		t.Errorf("Expected POINT(1 2) with SRID 4326, got %#v", result)
+	}
+}
+
+func TestMakeValidUnsupported(t *testing.T) {
+	// Only 2D geometries are repaired
+	bowtie := [][]PointZ{{{X: 0, Y: 0, Z: 1}, {X: 2, Y: 2, Z: 1}, {X: 2, Y: 0, Z: 1}, {X: 0, Y: 2, Z: 1}, {X: 0, Y: 0, Z: 1}}}
+	for _, g := range []Geometry{
+		&PolygonZ{},
+		&PolygonZS{SRID: 4326, Rings: bowtie},
+		&PointM{X: 1, Y: 2, M: 3},
+		&LineStringZM{Points: []PointZM{{X: 1, Y: 2}, {X: 3, Y: 4}}},
+		&MultiPolygonM{},
+		&GeometryCollection{Geometries: []Geometry{&PointZ{X: 1, Y: 2, Z: 3}}},
+	} {
+		if _, err := MakeValid(g); !errors.Is(err, ErrNotRepairable) {
+			t.Errorf("Expected ErrNotRepairable for %T, got %v", g, err)
+		}
+	}
+	flat, err := ForceXY(&PolygonZS{SRID: 4326, Rings: bowtie})
+	if err != nil {
+		t.Fatalf("ForceXY failed: %v", err)
+	}
+	if result, err := MakeValid(flat); err != nil || !IsValid(result) {
+		t.Errorf("Expected a valid 2D repair, got %v (%v)", result, err)
+	}
+
+	if _, err := MakeValid(&Point{X: math.NaN()}); !errors.Is(err, ErrNotRepairable) {
+		t.Errorf("Expected ErrNotRepairable, got %v", err)
+	}
+}
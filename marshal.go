@@ -0,0 +1,371 @@
+package postgis
+
+import (
+	"encoding/hex"
+	"reflect"
+)
+
+// marshalBinaryHelper provides common MarshalBinary implementation for all
+// geometry types
+func marshalBinaryHelper(g Geometry) ([]byte, error) {
+	return AppendEWKB(nil, g)
+}
+
+// unmarshalBinaryHelper provides common UnmarshalBinary implementation for
+// all geometry types
+func unmarshalBinaryHelper(g Geometry, data []byte) error {
+	return ReadEWKBBytes(data, g)
+}
+
+// marshalTextHelper provides common MarshalText implementation for all
+// geometry types, writing EWKT as ST_AsEWKT does. Like PostGIS, it leaves
+// out the SRIDs of the members of collections.
+func marshalTextHelper(g Geometry) ([]byte, error) {
+	return appendWKT(nil, g, true)
+}
+
+// unmarshalTextHelper provides common UnmarshalText implementation for all
+// geometry types, reading hex EWKB or EWKT
+func unmarshalTextHelper(g Geometry, text []byte) error {
+	if isHexText(text) {
+		return scanGeometryHelper(g, text)
+	}
+	// EWKT only marks M, so an empty geometry takes the dimensions of g
+	_, data, err := parseWKT(string(text), GetGeometryInfo(g.GetType()).CoordType)
+	if err != nil {
+		return err
+	}
+	return ReadEWKBBytes(data, g)
+}
+
+// isHexText reports whether text is hex encoded EWKB rather than EWKT, whose
+// keywords all have letters that are not hex digits
+func isHexText(text []byte) bool {
+	if len(text) == 0 {
+		return false
+	}
+	for _, c := range text {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning an empty
+// slice for NULL
+func (n Null[T]) MarshalBinary() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return marshalBinaryHelper(n.Geometry)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, reading an empty
+// slice as NULL
+func (n *Null[T]) UnmarshalBinary(data []byte) error {
+	var zero T
+	n.Geometry, n.Valid = zero, false
+	if len(data) == 0 {
+		return nil
+	}
+	return n.readEWKB(newEWKBReader(data))
+}
+
+// MarshalText implements encoding.TextMarshaler, returning an empty slice
+// for NULL
+func (n Null[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return marshalTextHelper(n.Geometry)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, reading empty text as
+// NULL
+func (n *Null[T]) UnmarshalText(text []byte) error {
+	var zero T
+	n.Geometry, n.Valid = zero, false
+	if len(text) == 0 {
+		return nil
+	}
+	if isHexText(text) {
+		return n.Scan(text)
+	}
+	empty := CoordXY
+	if target := nullTargetType[T](); target.Kind() == reflect.Pointer {
+		empty = GetGeometryInfo(reflect.New(target.Elem()).Interface().(Geometry).GetType()).CoordType
+	}
+	_, data, err := parseWKT(string(text), empty)
+	if err != nil {
+		return err
+	}
+	return n.readEWKB(newEWKBReader(data))
+}
+
+// HexEWKB is a geometry whose text form is hex encoded EWKB, as Value
+// returns, rather than the EWKT of MarshalText, for caches and JSON that
+// should hold the bytes PostGIS sends. Like Null, whose fields and methods
+// it shares, T is a pointer to a geometry type or the Geometry interface,
+// and the geometry may be NULL.
+type HexEWKB[T Geometry] struct {
+	Null[T]
+}
+
+// NewHexEWKB returns a valid HexEWKB holding g
+func NewHexEWKB[T Geometry](g T) HexEWKB[T] {
+	return HexEWKB[T]{NewNull(g)}
+}
+
+// MarshalText implements encoding.TextMarshaler, returning an empty slice
+// for NULL. UnmarshalText reads both hex EWKB and EWKT.
+func (h HexEWKB[T]) MarshalText() ([]byte, error) {
+	if !h.Valid {
+		return []byte{}, nil
+	}
+	return AppendHexEWKB(nil, h.Geometry)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning raster WKB
+func (r Raster) MarshalBinary() ([]byte, error) {
+	return AppendRaster(nil, &r)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (r *Raster) UnmarshalBinary(data []byte) error {
+	return ReadRasterBytes(data, r)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning hex raster WKB,
+// as rasters have no text format of their own
+func (r Raster) MarshalText() ([]byte, error) {
+	data, err := AppendRaster(nil, &r)
+	if err != nil {
+		return nil, err
+	}
+	return hex.AppendEncode(nil, data), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, reading hex raster WKB
+func (r *Raster) UnmarshalText(text []byte) error {
+	return r.Scan(string(text))
+}
+
+// Implement encoding.BinaryMarshaler, encoding.BinaryUnmarshaler,
+// encoding.TextMarshaler and encoding.TextUnmarshaler for all types, for
+// gob, caches and JSON map keys. The binary form is EWKB and the text form
+// EWKT, or hex EWKB through HexEWKB. encoding/json writes geometries as
+// strings of that form.
+func (p Point) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *Point) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p Point) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *Point) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p PointZ) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PointZ) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PointZ) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PointZ) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p PointM) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PointM) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PointM) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PointM) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p PointZM) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PointZM) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PointZM) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PointZM) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p PointS) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PointS) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PointS) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PointS) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p PointZS) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PointZS) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PointZS) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PointZS) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p PointMS) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PointMS) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PointMS) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PointMS) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p PointZMS) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PointZMS) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PointZMS) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PointZMS) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (gc GeometryCollection) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&gc) }
+func (gc *GeometryCollection) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(gc, data)
+}
+func (gc GeometryCollection) MarshalText() ([]byte, error)     { return marshalTextHelper(&gc) }
+func (gc *GeometryCollection) UnmarshalText(text []byte) error { return unmarshalTextHelper(gc, text) }
+
+func (gc GeometryCollectionS) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&gc) }
+func (gc *GeometryCollectionS) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(gc, data)
+}
+func (gc GeometryCollectionS) MarshalText() ([]byte, error)     { return marshalTextHelper(&gc) }
+func (gc *GeometryCollectionS) UnmarshalText(text []byte) error { return unmarshalTextHelper(gc, text) }
+
+func (ls LineStringOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&ls) }
+func (ls *LineStringOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(ls, data) }
+func (ls LineStringOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&ls) }
+func (ls *LineStringOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(ls, text) }
+
+func (ls LineStringSOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&ls) }
+func (ls *LineStringSOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(ls, data)
+}
+func (ls LineStringSOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&ls) }
+func (ls *LineStringSOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(ls, text) }
+
+func (p PolygonOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PolygonOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PolygonOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PolygonOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p PolygonSOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *PolygonSOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p PolygonSOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *PolygonSOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (m MultiPointOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&m) }
+func (m *MultiPointOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(m, data) }
+func (m MultiPointOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&m) }
+func (m *MultiPointOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(m, text) }
+
+func (m MultiPointSOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&m) }
+func (m *MultiPointSOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(m, data) }
+func (m MultiPointSOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&m) }
+func (m *MultiPointSOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(m, text) }
+
+func (m MultiLineStringOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&m) }
+func (m *MultiLineStringOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(m, data)
+}
+func (m MultiLineStringOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&m) }
+func (m *MultiLineStringOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(m, text) }
+
+func (m MultiLineStringSOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&m) }
+func (m *MultiLineStringSOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(m, data)
+}
+func (m MultiLineStringSOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&m) }
+func (m *MultiLineStringSOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(m, text) }
+
+func (m MultiPolygonOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&m) }
+func (m *MultiPolygonOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(m, data) }
+func (m MultiPolygonOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&m) }
+func (m *MultiPolygonOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(m, text) }
+
+func (m MultiPolygonSOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&m) }
+func (m *MultiPolygonSOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(m, data)
+}
+func (m MultiPolygonSOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&m) }
+func (m *MultiPolygonSOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(m, text) }
+
+func (cs CircularStringOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&cs) }
+func (cs *CircularStringOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(cs, data)
+}
+func (cs CircularStringOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&cs) }
+func (cs *CircularStringOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(cs, text) }
+
+func (cs CircularStringSOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&cs) }
+func (cs *CircularStringSOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(cs, data)
+}
+func (cs CircularStringSOf[P]) MarshalText() ([]byte, error) { return marshalTextHelper(&cs) }
+func (cs *CircularStringSOf[P]) UnmarshalText(text []byte) error {
+	return unmarshalTextHelper(cs, text)
+}
+
+func (c CompoundCurveOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&c) }
+func (c *CompoundCurveOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(c, data)
+}
+func (c CompoundCurveOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&c) }
+func (c *CompoundCurveOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(c, text) }
+
+func (c CompoundCurveSOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&c) }
+func (c *CompoundCurveSOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(c, data)
+}
+func (c CompoundCurveSOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&c) }
+func (c *CompoundCurveSOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(c, text) }
+
+func (p CurvePolygonOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&p) }
+func (p *CurvePolygonOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(p, data) }
+func (p CurvePolygonOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&p) }
+func (p *CurvePolygonOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(p, text) }
+
+func (p CurvePolygonSOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&p) }
+func (p *CurvePolygonSOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(p, data)
+}
+func (p CurvePolygonSOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&p) }
+func (p *CurvePolygonSOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(p, text) }
+
+func (m MultiCurveOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&m) }
+func (m *MultiCurveOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(m, data) }
+func (m MultiCurveOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&m) }
+func (m *MultiCurveOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(m, text) }
+
+func (m MultiCurveSOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&m) }
+func (m *MultiCurveSOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(m, data) }
+func (m MultiCurveSOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&m) }
+func (m *MultiCurveSOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(m, text) }
+
+func (m MultiSurfaceOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&m) }
+func (m *MultiSurfaceOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(m, data) }
+func (m MultiSurfaceOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&m) }
+func (m *MultiSurfaceOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(m, text) }
+
+func (m MultiSurfaceSOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&m) }
+func (m *MultiSurfaceSOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(m, data)
+}
+func (m MultiSurfaceSOf[P]) MarshalText() ([]byte, error)     { return marshalTextHelper(&m) }
+func (m *MultiSurfaceSOf[P]) UnmarshalText(text []byte) error { return unmarshalTextHelper(m, text) }
+
+func (s PolyhedralSurfaceOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&s) }
+func (s *PolyhedralSurfaceOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(s, data)
+}
+func (s PolyhedralSurfaceOf[P]) MarshalText() ([]byte, error) { return marshalTextHelper(&s) }
+func (s *PolyhedralSurfaceOf[P]) UnmarshalText(text []byte) error {
+	return unmarshalTextHelper(s, text)
+}
+
+func (s PolyhedralSurfaceSOf[P]) MarshalBinary() ([]byte, error) { return marshalBinaryHelper(&s) }
+func (s *PolyhedralSurfaceSOf[P]) UnmarshalBinary(data []byte) error {
+	return unmarshalBinaryHelper(s, data)
+}
+func (s PolyhedralSurfaceSOf[P]) MarshalText() ([]byte, error) { return marshalTextHelper(&s) }
+func (s *PolyhedralSurfaceSOf[P]) UnmarshalText(text []byte) error {
+	return unmarshalTextHelper(s, text)
+}
+
+func (m TINOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&m) }
+func (m *TINOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(m, data) }
+func (m TINOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&m) }
+func (m *TINOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(m, text) }
+
+func (m TINSOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&m) }
+func (m *TINSOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(m, data) }
+func (m TINSOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&m) }
+func (m *TINSOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(m, text) }
+
+func (t TriangleOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&t) }
+func (t *TriangleOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(t, data) }
+func (t TriangleOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&t) }
+func (t *TriangleOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(t, text) }
+
+func (t TriangleSOf[P]) MarshalBinary() ([]byte, error)     { return marshalBinaryHelper(&t) }
+func (t *TriangleSOf[P]) UnmarshalBinary(data []byte) error { return unmarshalBinaryHelper(t, data) }
+func (t TriangleSOf[P]) MarshalText() ([]byte, error)       { return marshalTextHelper(&t) }
+func (t *TriangleSOf[P]) UnmarshalText(text []byte) error   { return unmarshalTextHelper(t, text) }
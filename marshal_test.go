@@ -0,0 +1,163 @@
+package postgis
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	for _, g := range sampleGeometries() {
+		ewkb := mustWriteEWKB(t, g)
+		binary, err := g.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil || !bytes.Equal(binary, ewkb) {
+			t.Fatalf("Expected the EWKB of %T, got %x (%v)", g, binary, err)
+		}
+		decoded := reflect.New(reflect.TypeOf(g).Elem()).Interface().(Geometry)
+		if err := decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(binary); err != nil {
+			t.Fatalf("UnmarshalBinary(%T) failed: %v", g, err)
+		}
+		if again := mustWriteEWKB(t, decoded); !bytes.Equal(again, ewkb) {
+			t.Errorf("Expected %x for %T, got %x", ewkb, g, again)
+		}
+
+		text, err := g.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%T) failed: %v", g, err)
+		}
+		hexText, err := NewHexEWKB(g).MarshalText()
+		if err != nil {
+			t.Fatalf("HexEWKB.MarshalText(%T) failed: %v", g, err)
+		}
+		for _, text := range [][]byte{text, hexText} {
+			decoded := reflect.New(reflect.TypeOf(g).Elem()).Interface().(Geometry)
+			if err := decoded.(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+				t.Fatalf("UnmarshalText(%T, %s) failed: %v", g, text, err)
+			}
+			if again := mustWriteEWKB(t, decoded); !bytes.Equal(again, ewkb) {
+				t.Errorf("Expected %x for %T read from %s, got %x", ewkb, g, text, again)
+			}
+		}
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	p := PointS{SRID: 4326, X: 1, Y: 2}
+	if text, _ := p.MarshalText(); string(text) != "SRID=4326;POINT(1 2)" {
+		t.Errorf("Expected EWKT, got %s", text)
+	}
+	hexEWKB, _ := p.Value()
+	if text, _ := NewHexEWKB(&p).MarshalText(); string(text) != hexEWKB {
+		t.Errorf("Expected %s, got %s", hexEWKB, text)
+	}
+	if text, err := (HexEWKB[*PointS]{}).MarshalText(); err != nil || len(text) != 0 {
+		t.Errorf("Expected no text for NULL, got %s (%v)", text, err)
+	}
+
+	var q PointS
+	if err := q.UnmarshalText([]byte("SRID=3857;POINT(3 4)")); err != nil || q != (PointS{SRID: 3857, X: 3, Y: 4}) {
+		t.Errorf("Expected the parsed point, got %v (%v)", q, err)
+	}
+	var ls LineString
+	if err := ls.UnmarshalText([]byte("POINT(1 2)")); err == nil {
+		t.Error("Expected an error for a point read into a LineString")
+	}
+	if err := ls.UnmarshalText([]byte("LINESTRING(1 2,")); !errors.Is(err, ErrInvalidWKT) {
+		t.Errorf("Expected ErrInvalidWKT, got %v", err)
+	}
+}
+
+func TestMarshalNull(t *testing.T) {
+	var n Null[Geometry]
+	if data, err := n.MarshalBinary(); err != nil || len(data) != 0 {
+		t.Errorf("Expected no data for NULL, got %x (%v)", data, err)
+	}
+	if err := n.UnmarshalText([]byte("SRID=4326;LINESTRING(1 2,3 4)")); err != nil || !n.Valid {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	expected := &LineStringS{SRID: 4326, Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+	if !reflect.DeepEqual(n.Geometry, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, n.Geometry)
+	}
+	data, _ := n.MarshalBinary()
+	var m Null[*LineStringS]
+	if err := m.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(m.Geometry, expected) {
+		t.Errorf("Expected %#v, got %#v (%v)", expected, m.Geometry, err)
+	}
+	if err := m.UnmarshalBinary(nil); err != nil || m.Valid {
+		t.Errorf("Expected NULL, got %v (%v)", m, err)
+	}
+}
+
+func TestMarshalRaster(t *testing.T) {
+	r := sampleRaster()
+	for _, marshal := range []func() ([]byte, error){r.MarshalBinary, r.MarshalText} {
+		data, err := marshal()
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var decoded Raster
+		if bytes.HasPrefix(data, []byte{wkbNDR}) {
+			err = decoded.UnmarshalBinary(data)
+		} else {
+			err = decoded.UnmarshalText(data)
+		}
+		if err != nil || !reflect.DeepEqual(decoded, r) {
+			t.Errorf("Expected %#v, got %#v (%v)", r, decoded, err)
+		}
+	}
+}
+
+func TestGobAndJSON(t *testing.T) {
+	type cached struct {
+		Location PointS
+		Area     *PolygonS
+		Route    Null[*LineString]
+	}
+	value := cached{
+		Location: PointS{SRID: 4326, X: 1, Y: 2},
+		Area:     &PolygonS{SRID: 4326, Rings: [][]Point{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}},
+		Route:    NewNull(&LineString{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}),
+	}
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(value); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	var decoded cached
+	if err := gob.NewDecoder(&buffer).Decode(&decoded); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, value) {
+		t.Errorf("Expected %#v, got %#v", value, decoded)
+	}
+
+	counts := map[PointS]int{{SRID: 4326, X: 1, Y: 2}: 3}
+	data, err := json.Marshal(counts)
+	if err != nil || string(data) != `{"SRID=4326;POINT(1 2)":3}` {
+		t.Fatalf("Unexpected JSON %s (%v)", data, err)
+	}
+	var decodedCounts map[PointS]int
+	if err := json.Unmarshal(data, &decodedCounts); err != nil || !reflect.DeepEqual(decodedCounts, counts) {
+		t.Errorf("Expected %v, got %v (%v)", counts, decodedCounts, err)
+	}
+
+	// Values are strings too, of EWKT or hex EWKB
+	type shop struct {
+		Location PointS
+		Entrance HexEWKB[*PointS]
+	}
+	located := shop{Location: PointS{SRID: 4326, X: 1, Y: 2}, Entrance: NewHexEWKB(&PointS{SRID: 4326, X: 1, Y: 2})}
+	data, err = json.Marshal(located)
+	expected := `{"Location":"SRID=4326;POINT(1 2)","Entrance":"0101000020e6100000000000000000f03f0000000000000040"}`
+	if err != nil || string(data) != expected {
+		t.Fatalf("Expected %s, got %s (%v)", expected, data, err)
+	}
+	var decodedShop shop
+	if err := json.Unmarshal(data, &decodedShop); err != nil || !reflect.DeepEqual(decodedShop, located) {
+		t.Errorf("Expected %#v, got %#v (%v)", located, decodedShop, err)
+	}
+}
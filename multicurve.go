@@ -0,0 +1,106 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// MultiCurveOf is a MultiCurve whose coordinate type P sets its dimensions.
+// Its members are LineStrings, CircularStrings or CompoundCurves stored as
+// pointers, without SRID.
+type MultiCurveOf[P PointLike] struct {
+	Curves []Curve[P]
+}
+
+// MultiCurveSOf is a MultiCurve with an SRID
+type MultiCurveSOf[P PointLike] struct {
+	SRID   int32
+	Curves []Curve[P]
+}
+
+// Varying types of MultiCurves
+type (
+	MultiCurve   = MultiCurveOf[Point]
+	MultiCurveZ  = MultiCurveOf[PointZ]
+	MultiCurveM  = MultiCurveOf[PointM]
+	MultiCurveZM = MultiCurveOf[PointZM]
+
+	MultiCurveS   = MultiCurveSOf[Point]
+	MultiCurveZS  = MultiCurveSOf[PointZ]
+	MultiCurveMS  = MultiCurveSOf[PointM]
+	MultiCurveZMS = MultiCurveSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (m *MultiCurveSOf[P]) GetSRID() int32     { return m.SRID }
+func (m *MultiCurveSOf[P]) SetSRID(srid int32) { m.SRID = srid }
+
+// Implement CollectionGeometry interface for all MultiCurve types
+func (m *MultiCurveOf[P]) GetElementCount() uint32  { return getElementCountHelper(m.Curves) }
+func (m *MultiCurveSOf[P]) GetElementCount() uint32 { return getElementCountHelper(m.Curves) }
+
+func (m *MultiCurveOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeCollectionHelper(m.Curves, buffer)
+}
+
+func (m *MultiCurveSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeCollectionHelper(m.Curves, buffer)
+}
+
+func (m *MultiCurveOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readMembersHelper(reader, count, newCurve[P])
+	if err != nil {
+		return err
+	}
+	m.Curves = geometries
+	return nil
+}
+
+func (m *MultiCurveSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readMembersHelper(reader, count, newCurve[P])
+	if err != nil {
+		return err
+	}
+	m.Curves = geometries
+	return nil
+}
+
+/** MultiCurveOf functions **/
+func (m *MultiCurveOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiCurveOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiCurveOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiCurveOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiCurve, coordinateType[P](), false)
+}
+
+/** MultiCurveSOf functions **/
+func (m *MultiCurveSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiCurveSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiCurveSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiCurveSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiCurve, coordinateType[P](), true)
+}
@@ -0,0 +1,105 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// MultiLineStringOf is a MultiLineString whose coordinate type P sets its
+// dimensions
+type MultiLineStringOf[P PointLike] struct {
+	LineStrings []LineStringOf[P]
+}
+
+// MultiLineStringSOf is a MultiLineString with an SRID
+type MultiLineStringSOf[P PointLike] struct {
+	SRID        int32
+	LineStrings []LineStringOf[P]
+}
+
+// Varying types of MultiLineStrings
+type (
+	MultiLineString   = MultiLineStringOf[Point]
+	MultiLineStringZ  = MultiLineStringOf[PointZ]
+	MultiLineStringM  = MultiLineStringOf[PointM]
+	MultiLineStringZM = MultiLineStringOf[PointZM]
+
+	MultiLineStringS   = MultiLineStringSOf[Point]
+	MultiLineStringZS  = MultiLineStringSOf[PointZ]
+	MultiLineStringMS  = MultiLineStringSOf[PointM]
+	MultiLineStringZMS = MultiLineStringSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (m *MultiLineStringSOf[P]) GetSRID() int32     { return m.SRID }
+func (m *MultiLineStringSOf[P]) SetSRID(srid int32) { m.SRID = srid }
+
+// Implement CollectionGeometry interface for all MultiLineString types
+func (m *MultiLineStringOf[P]) GetElementCount() uint32  { return getElementCountHelper(m.LineStrings) }
+func (m *MultiLineStringSOf[P]) GetElementCount() uint32 { return getElementCountHelper(m.LineStrings) }
+
+func (m *MultiLineStringOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(m.LineStrings, buffer)
+}
+
+func (m *MultiLineStringSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(m.LineStrings, buffer)
+}
+
+func (m *MultiLineStringOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[LineStringOf[P]](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	m.LineStrings = geometries
+	return nil
+}
+
+func (m *MultiLineStringSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[LineStringOf[P]](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	m.LineStrings = geometries
+	return nil
+}
+
+/** MultiLineStringOf functions **/
+func (m *MultiLineStringOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiLineStringOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiLineStringOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiLineStringOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiLineString, coordinateType[P](), false)
+}
+
+/** MultiLineStringSOf functions **/
+func (m *MultiLineStringSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiLineStringSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiLineStringSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiLineStringSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiLineString, coordinateType[P](), true)
+}
@@ -0,0 +1,104 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// MultiPointOf is a MultiPoint whose coordinate type P sets its dimensions
+type MultiPointOf[P PointLike] struct {
+	Points []P
+}
+
+// MultiPointSOf is a MultiPoint with an SRID
+type MultiPointSOf[P PointLike] struct {
+	SRID   int32
+	Points []P
+}
+
+// Varying types of MultiPoints
+type (
+	MultiPoint   = MultiPointOf[Point]
+	MultiPointZ  = MultiPointOf[PointZ]
+	MultiPointM  = MultiPointOf[PointM]
+	MultiPointZM = MultiPointOf[PointZM]
+
+	MultiPointS   = MultiPointSOf[Point]
+	MultiPointZS  = MultiPointSOf[PointZ]
+	MultiPointMS  = MultiPointSOf[PointM]
+	MultiPointZMS = MultiPointSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (m *MultiPointSOf[P]) GetSRID() int32     { return m.SRID }
+func (m *MultiPointSOf[P]) SetSRID(srid int32) { m.SRID = srid }
+
+// Implement CollectionGeometry interface for all MultiPoint types
+func (m *MultiPointOf[P]) GetElementCount() uint32  { return getElementCountHelper(m.Points) }
+func (m *MultiPointSOf[P]) GetElementCount() uint32 { return getElementCountHelper(m.Points) }
+
+func (m *MultiPointOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(m.Points, buffer)
+}
+
+func (m *MultiPointSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(m.Points, buffer)
+}
+
+func (m *MultiPointOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[P](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	m.Points = geometries
+	return nil
+}
+
+func (m *MultiPointSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[P](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	m.Points = geometries
+	return nil
+}
+
+/** MultiPointOf functions **/
+func (m *MultiPointOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiPointOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiPointOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiPointOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiPoint, coordinateType[P](), false)
+}
+
+/** MultiPointSOf functions **/
+func (m *MultiPointSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiPointSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiPointSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiPointSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiPoint, coordinateType[P](), true)
+}
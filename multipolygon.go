@@ -0,0 +1,105 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// MultiPolygonOf is a MultiPolygon whose coordinate type P sets its
+// dimensions
+type MultiPolygonOf[P PointLike] struct {
+	Polygons []PolygonOf[P]
+}
+
+// MultiPolygonSOf is a MultiPolygon with an SRID
+type MultiPolygonSOf[P PointLike] struct {
+	SRID     int32
+	Polygons []PolygonOf[P]
+}
+
+// Varying types of MultiPolygons
+type (
+	MultiPolygon   = MultiPolygonOf[Point]
+	MultiPolygonZ  = MultiPolygonOf[PointZ]
+	MultiPolygonM  = MultiPolygonOf[PointM]
+	MultiPolygonZM = MultiPolygonOf[PointZM]
+
+	MultiPolygonS   = MultiPolygonSOf[Point]
+	MultiPolygonZS  = MultiPolygonSOf[PointZ]
+	MultiPolygonMS  = MultiPolygonSOf[PointM]
+	MultiPolygonZMS = MultiPolygonSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (m *MultiPolygonSOf[P]) GetSRID() int32     { return m.SRID }
+func (m *MultiPolygonSOf[P]) SetSRID(srid int32) { m.SRID = srid }
+
+// Implement CollectionGeometry interface for all MultiPolygon types
+func (m *MultiPolygonOf[P]) GetElementCount() uint32  { return getElementCountHelper(m.Polygons) }
+func (m *MultiPolygonSOf[P]) GetElementCount() uint32 { return getElementCountHelper(m.Polygons) }
+
+func (m *MultiPolygonOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(m.Polygons, buffer)
+}
+
+func (m *MultiPolygonSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(m.Polygons, buffer)
+}
+
+func (m *MultiPolygonOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[PolygonOf[P]](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	m.Polygons = geometries
+	return nil
+}
+
+func (m *MultiPolygonSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[PolygonOf[P]](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	m.Polygons = geometries
+	return nil
+}
+
+/** MultiPolygonOf functions **/
+func (m *MultiPolygonOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiPolygonOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiPolygonOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiPolygonOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiPolygon, coordinateType[P](), false)
+}
+
+/** MultiPolygonSOf functions **/
+func (m *MultiPolygonSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiPolygonSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiPolygonSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiPolygonSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiPolygon, coordinateType[P](), true)
+}
@@ -0,0 +1,106 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// MultiSurfaceOf is a MultiSurface whose coordinate type P sets its
+// dimensions. Its members are Polygons or CurvePolygons stored as pointers,
+// without SRID.
+type MultiSurfaceOf[P PointLike] struct {
+	Surfaces []Surface[P]
+}
+
+// MultiSurfaceSOf is a MultiSurface with an SRID
+type MultiSurfaceSOf[P PointLike] struct {
+	SRID     int32
+	Surfaces []Surface[P]
+}
+
+// Varying types of MultiSurfaces
+type (
+	MultiSurface   = MultiSurfaceOf[Point]
+	MultiSurfaceZ  = MultiSurfaceOf[PointZ]
+	MultiSurfaceM  = MultiSurfaceOf[PointM]
+	MultiSurfaceZM = MultiSurfaceOf[PointZM]
+
+	MultiSurfaceS   = MultiSurfaceSOf[Point]
+	MultiSurfaceZS  = MultiSurfaceSOf[PointZ]
+	MultiSurfaceMS  = MultiSurfaceSOf[PointM]
+	MultiSurfaceZMS = MultiSurfaceSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (m *MultiSurfaceSOf[P]) GetSRID() int32     { return m.SRID }
+func (m *MultiSurfaceSOf[P]) SetSRID(srid int32) { m.SRID = srid }
+
+// Implement CollectionGeometry interface for all MultiSurface types
+func (m *MultiSurfaceOf[P]) GetElementCount() uint32  { return getElementCountHelper(m.Surfaces) }
+func (m *MultiSurfaceSOf[P]) GetElementCount() uint32 { return getElementCountHelper(m.Surfaces) }
+
+func (m *MultiSurfaceOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeCollectionHelper(m.Surfaces, buffer)
+}
+
+func (m *MultiSurfaceSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeCollectionHelper(m.Surfaces, buffer)
+}
+
+func (m *MultiSurfaceOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readMembersHelper(reader, count, newSurface[P])
+	if err != nil {
+		return err
+	}
+	m.Surfaces = geometries
+	return nil
+}
+
+func (m *MultiSurfaceSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readMembersHelper(reader, count, newSurface[P])
+	if err != nil {
+		return err
+	}
+	m.Surfaces = geometries
+	return nil
+}
+
+/** MultiSurfaceOf functions **/
+func (m *MultiSurfaceOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiSurfaceOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiSurfaceOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiSurfaceOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiSurface, coordinateType[P](), false)
+}
+
+/** MultiSurfaceSOf functions **/
+func (m *MultiSurfaceSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m MultiSurfaceSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m MultiSurfaceSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m MultiSurfaceSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBMultiSurface, coordinateType[P](), true)
+}
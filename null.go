@@ -0,0 +1,114 @@
+package postgis
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// Null is a geometry that may be NULL, like sql.Null. T is a pointer to a
+// geometry type, such as Null[*PointS], or the Geometry interface itself to
+// scan a column holding any type, creating the geometry from its type code.
+//
+//	var location postgis.Null[*postgis.PointS]
+//	err := db.QueryRow("SELECT location FROM shops WHERE id = $1", id).Scan(&location)
+//	if location.Valid {
+//		fmt.Println(location.Geometry.X, location.Geometry.Y)
+//	}
+type Null[T Geometry] struct {
+	Geometry T
+	// Valid is true if Geometry is not NULL. Geometry must not be a nil
+	// pointer when Valid is true.
+	Valid bool
+}
+
+// NewNull returns a valid Null holding g
+func NewNull[T Geometry](g T) Null[T] {
+	return Null[T]{Geometry: g, Valid: true}
+}
+
+// Scan implements the sql.Scanner interface. A NULL value sets Valid to
+// false; any other value is decoded into a new geometry.
+func (n *Null[T]) Scan(value interface{}) error {
+	var zero T
+	n.Geometry, n.Valid = zero, false
+	if value == nil {
+		return nil
+	}
+
+	reader, err := DecodeEWKB(value)
+	if err != nil {
+		return &DecodeError{GeometryType: nullTargetName[T](), Err: err}
+	}
+	return n.readEWKB(reader.(*ewkbReader))
+}
+
+// readEWKB decodes the geometry of reader into a new geometry
+func (n *Null[T]) readEWKB(reader *ewkbReader) error {
+	g, err := newNullTarget[T](reader)
+	if err != nil {
+		return err
+	}
+	if err := ReadEWKB(reader, g); err != nil {
+		return err
+	}
+	n.Geometry, n.Valid = g, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning nil for NULL
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Geometry.Value()
+}
+
+func nullTargetType[T Geometry]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// nullTargetName returns the name of T for errors
+func nullTargetName[T Geometry]() string {
+	target := nullTargetType[T]()
+	if target.Kind() == reflect.Pointer {
+		return geometryTypeName(reflect.New(target.Elem()).Interface().(Geometry))
+	}
+	return target.String()
+}
+
+// newNullTarget returns an empty geometry to decode into. For pointer types
+// it is a new value; for interfaces it is created from the type code at the
+// start of reader, which is left unchanged.
+func newNullTarget[T Geometry](reader *ewkbReader) (T, error) {
+	var zero T
+	target := nullTargetType[T]()
+	if target.Kind() == reflect.Pointer {
+		return reflect.New(target.Elem()).Interface().(T), nil
+	}
+	name := target.String()
+
+	start := reader.off
+	_, wkbType, err := readEWKBHeader(reader)
+	if err != nil {
+		return zero, &DecodeError{Offset: readOffset(reader), GeometryType: name, Err: classifyEOF(err)}
+	}
+	reader.off = start
+
+	g, err := NewGeometry(wkbType)
+	if err != nil {
+		return zero, &DecodeError{Offset: int64(start + 5), Actual: wkbType, GeometryType: name, Err: err}
+	}
+	t, ok := g.(T)
+	if !ok {
+		err := fmt.Errorf("%w: %s cannot hold %s", ErrTypeMismatch, name, geometryTypeName(g))
+		return zero, &DecodeError{Offset: int64(start + 5), Actual: wkbType, GeometryType: name, Err: err}
+	}
+	return t, nil
+}
+
+// geometry returns the geometry of a valid Null, for encoders that bypass
+// Value such as CopyFrom
+func (n Null[T]) geometry() (Geometry, bool) {
+	return n.Geometry, n.Valid
+}
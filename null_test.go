@@ -0,0 +1,115 @@
+package postgis
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Null types must work wherever database/sql expects a nullable column
+var (
+	_ sql.Scanner   = (*Null[*PointS])(nil)
+	_ driver.Valuer = Null[*PointS]{}
+)
+
+func TestNullScan(t *testing.T) {
+	point := &PointS{SRID: 4326, X: -84.5014, Y: 39.1064}
+	value, err := point.Value()
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+
+	var n Null[*PointS]
+	if err := n.Scan(value); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if !n.Valid || !reflect.DeepEqual(n.Geometry, point) {
+		t.Errorf("Expected valid %v, got %+v", point, n)
+	}
+	scanned := n.Geometry
+
+	// Every scan decodes into a new geometry
+	if err := n.Scan([]byte(value.(string))); err != nil {
+		t.Fatalf("Scan of []byte failed: %v", err)
+	}
+	if n.Geometry == scanned {
+		t.Errorf("Expected a new geometry for each scan")
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan of NULL failed: %v", err)
+	}
+	if n.Valid || n.Geometry != nil {
+		t.Errorf("Expected NULL to clear the geometry, got %+v", n)
+	}
+}
+
+func TestNullScanError(t *testing.T) {
+	value, err := (&Polygon{Rings: [][]Point{square(0, 0, 1)}}).Value()
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+
+	n := NewNull(&LineString{})
+	if err := n.Scan(value); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+	if n.Valid {
+		t.Errorf("Expected a failed scan to leave the value invalid")
+	}
+}
+
+func TestNullValue(t *testing.T) {
+	var n Null[*LineStringS]
+	if value, err := n.Value(); value != nil || err != nil {
+		t.Errorf("Expected NULL, got %v, %v", value, err)
+	}
+
+	ls := &LineStringS{SRID: 4326, Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}
+	value, err := NewNull(ls).Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	expected, _ := ls.Value()
+	if value != expected {
+		t.Errorf("Expected %v, got %v", expected, value)
+	}
+}
+
+func TestNullGeometryInterface(t *testing.T) {
+	geometries := []Geometry{
+		&PointZMS{SRID: 4326, X: 1, Y: 2, Z: 3, M: 4},
+		&MultiLineString{LineStrings: []LineString{{Points: randomPoints(3)}}},
+		&GeometryCollectionS{SRID: 3857, Geometries: []Geometry{&Point{X: 1, Y: 2}}},
+	}
+	for _, g := range geometries {
+		value, err := g.Value()
+		if err != nil {
+			t.Fatalf("Failed to encode %T: %v", g, err)
+		}
+		var n Null[Geometry]
+		if err := n.Scan(value); err != nil {
+			t.Fatalf("Scan of %T failed: %v", g, err)
+		}
+		if !n.Valid || !reflect.DeepEqual(n.Geometry, g) {
+			t.Errorf("Expected %v, got %+v", g, n)
+		}
+	}
+
+	// An interface narrower than Geometry only accepts the types implementing it
+	point, _ := (&Point{X: 1, Y: 2}).Value()
+	var withSRID Null[interface {
+		Geometry
+		GetSRID() int32
+	}]
+	if err := withSRID.Scan(point); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+
+	var truncated Null[Geometry]
+	if err := truncated.Scan("01"); !errors.Is(err, ErrTruncatedEWKB) {
+		t.Errorf("Expected ErrTruncatedEWKB, got %v", err)
+	}
+}
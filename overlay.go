@@ -0,0 +1,314 @@
+package postgis
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSRIDMismatch is returned when an operation combines geometries with
+// different SRIDs. Geometries without an SRID field count as SRID 0.
+var ErrSRIDMismatch = errors.New("operation on mixed SRID geometries")
+
+type overlayOp int
+
+const (
+	opIntersection overlayOp = iota
+	opUnion
+	opDifference
+	opSymDifference
+)
+
+func (op overlayOp) apply(a, b bool) bool {
+	switch op {
+	case opIntersection:
+		return a && b
+	case opUnion:
+		return a || b
+	case opDifference:
+		return a && !b
+	default:
+		return a != b
+	}
+}
+
+// overlayInput is the 2D linework of one overlay operand
+type overlayInput struct {
+	dim     int       // 0 for points, 1 for lines, 2 for polygons
+	parts   [][]Point // rings for polygons, lines otherwise
+	points  []Point
+	shape   shape
+	srid    int32
+	hasSRID bool
+}
+
+func newOverlayInput(g Geometry) (overlayInput, error) {
+	var in overlayInput
+	s, ok := g.(shaper)
+	if !ok {
+		return in, fmt.Errorf("%w: %s is not supported by overlay operations", ErrUnsupportedType, geometryTypeName(g))
+	}
+	in.shape = s.planarShape()
+	switch GetGeometryInfo(g.GetType()).BaseType {
+	case WKBPoint, WKBMultiPoint:
+		for _, p := range in.shape.points {
+			if p.finite() {
+				in.points = append(in.points, p)
+			}
+		}
+	case WKBLineString, WKBMultiLineString:
+		in.dim, in.parts = 1, in.shape.lines
+	case WKBPolygon, WKBMultiPolygon:
+		in.dim = 2
+		for _, rings := range in.shape.polygons {
+			in.parts = append(in.parts, rings...)
+		}
+	default:
+		return in, fmt.Errorf("%w: %s is not supported by overlay operations", ErrUnsupportedType, geometryTypeName(g))
+	}
+	if sg, ok := g.(SRIDGeometry); ok {
+		in.srid, in.hasSRID = sg.GetSRID(), true
+	}
+	return in, nil
+}
+
+func lineStringParts[P PointLike](lineStrings []LineStringOf[P]) [][]Point {
+	parts := make([][]Point, len(lineStrings))
+	for i, ls := range lineStrings {
+		parts[i] = xyPoints(ls.Points)
+	}
+	return parts
+}
+
+func polygonParts[P PointLike](polygons []PolygonOf[P]) [][]Point {
+	var parts [][]Point
+	for _, p := range polygons {
+		parts = append(parts, xyRings(p.Rings)...)
+	}
+	return parts
+}
+
+// Intersection returns the part of the plane covered by both a and b, like
+// ST_Intersection. The operands may be any Point, MultiPoint, LineString,
+// MultiLineString, Polygon or MultiPolygon (as pointers) of any dimensions;
+// like ST_Union, the operations are planar and the result has no Z or M.
+// Polygons are read with the even-odd rule so ring orientation does not
+// matter.
+//
+// The result is a MultiPolygon, MultiLineString or MultiPoint (with an SRID
+// when either operand has one), or a GeometryCollection when it mixes
+// dimensions, e.g. two polygons sharing both an area and a separate edge.
+func Intersection(a, b Geometry) (Geometry, error) {
+	return overlay(a, b, opIntersection)
+}
+
+// Union returns the part of the plane covered by a or b, like ST_Union. See
+// Intersection for the supported operands and result types.
+func Union(a, b Geometry) (Geometry, error) {
+	return overlay(a, b, opUnion)
+}
+
+// Difference returns the part of a not covered by b, like ST_Difference. See
+// Intersection for the supported operands and result types.
+func Difference(a, b Geometry) (Geometry, error) {
+	return overlay(a, b, opDifference)
+}
+
+// SymDifference returns the parts of a and b not covered by the other, like
+// ST_SymDifference. See Intersection for the supported operands and result
+// types.
+func SymDifference(a, b Geometry) (Geometry, error) {
+	return overlay(a, b, opSymDifference)
+}
+
+func overlay(a, b Geometry, op overlayOp) (Geometry, error) {
+	inA, err := newOverlayInput(a)
+	if err != nil {
+		return nil, err
+	}
+	inB, err := newOverlayInput(b)
+	if err != nil {
+		return nil, err
+	}
+	if inA.srid != inB.srid {
+		return nil, fmt.Errorf("%w: %d and %d", ErrSRIDMismatch, inA.srid, inB.srid)
+	}
+
+	if inA.dim == 0 || inB.dim == 0 {
+		return overlayPoints(inA, inB, op), nil
+	}
+	polygons, lines, points := overlayGraph(inA, inB, op)
+	return overlayResult(polygons, lines, points, inA, inB, op), nil
+}
+
+// overlayPoints overlays operands of which at least one is points. Points
+// have no length or area, so the linework of the other operand is either
+// kept whole or dropped, and only the points themselves need testing.
+func overlayPoints(a, b overlayInput, op overlayOp) Geometry {
+	inputs := [maxGraphSources]overlayInput{a, b}
+	tol := snapTolerance(a.parts, b.parts, [][]Point{a.points, b.points})
+
+	// kept reports whether the whole of an operand that is not points belongs
+	// to the result
+	kept := func(src int) bool {
+		var in [maxGraphSources]bool
+		in[src] = true
+		return inputs[src].dim > 0 && op.apply(in[0], in[1])
+	}
+
+	var polygons []Polygon
+	var lines [][]Point
+	var points []Point
+	seen := make(map[Point]bool)
+	for src, in := range inputs {
+		if in.dim > 0 {
+			if kept(src) {
+				polygons, lines, points = overlayGraph(in, overlayInput{}, opUnion)
+			}
+			continue
+		}
+		other := 1 - src
+		for _, p := range in.points {
+			covered := inputs[other].shape.distance(p) <= tol
+			var within [maxGraphSources]bool
+			within[src], within[other] = true, covered
+			// A point on linework that is kept is already part of the result
+			if !op.apply(within[0], within[1]) || (covered && kept(other)) || seen[p] {
+				continue
+			}
+			seen[p] = true
+			points = append(points, p)
+		}
+	}
+	return overlayResult(polygons, lines, points, a, b, op)
+}
+
+// overlayGraph overlays the linework of two operands that are not points,
+// returning the pieces of the result
+func overlayGraph(inA, inB overlayInput, op overlayOp) ([]Polygon, [][]Point, []Point) {
+	g := newPlanarGraph(inA.parts, inB.parts)
+	for src, in := range []overlayInput{inA, inB} {
+		if in.dim == 2 {
+			g.addRings(src, in.parts)
+		} else {
+			g.addLines(src, in.parts)
+		}
+	}
+	g.node()
+	g.buildEdges()
+	g.label()
+
+	areal := [maxGraphSources]bool{inA.dim == 2, inB.dim == 2}
+	inside := func(winding [maxGraphSources]int) bool {
+		return op.apply(areal[0] && fillEvenOdd.inside(winding[0]), areal[1] && fillEvenOdd.inside(winding[1]))
+	}
+	// covered reports whether an edge belongs to the closure of each operand
+	covered := func(e *graphEdge) (in [maxGraphSources]bool) {
+		for src := range in {
+			if areal[src] {
+				in[src] = fillEvenOdd.inside(e.winding[src][0]) || fillEvenOdd.inside(e.winding[src][1])
+			} else {
+				in[src] = e.lines[src] > 0
+			}
+		}
+		return in
+	}
+	sides := func(e *graphEdge) (left, right [maxGraphSources]int) {
+		for src := range e.winding {
+			left[src], right[src] = e.winding[src][0], e.winding[src][1]
+		}
+		return left, right
+	}
+	bordersArea := func(e *graphEdge) bool {
+		left, right := sides(e)
+		return inside(left) || inside(right)
+	}
+
+	polygons := g.polygons(inside)
+	lineEdge := func(e *graphEdge) bool {
+		in := covered(e)
+		return !bordersArea(e) && op.apply(in[0], in[1])
+	}
+	lines := g.lines(lineEdge)
+
+	// Isolated points come from vertices that are in the result but do not
+	// touch any resulting edge or area, such as two lines crossing
+	used := make(map[int]bool)
+	vertexIn := make(map[int][maxGraphSources]bool)
+	for i := range g.edges {
+		e := &g.edges[i]
+		if bordersArea(e) || lineEdge(e) {
+			used[e.a], used[e.b] = true, true
+		}
+		in := covered(e)
+		for _, v := range []int{e.a, e.b} {
+			prev := vertexIn[v]
+			vertexIn[v] = [maxGraphSources]bool{prev[0] || in[0], prev[1] || in[1]}
+		}
+	}
+	var points []Point
+	for v, p := range g.vertices {
+		if in, ok := vertexIn[v]; ok && !used[v] && op.apply(in[0], in[1]) {
+			points = append(points, p)
+		}
+	}
+
+	return polygons, lines, points
+}
+
+// overlayResult wraps the pieces of an overlay in the simplest geometry type
+// that can hold them
+func overlayResult(polygons []Polygon, lines [][]Point, points []Point, a, b overlayInput, op overlayOp) Geometry {
+	srid, hasSRID := a.srid, a.hasSRID || b.hasSRID
+
+	kinds := 0
+	for _, n := range []int{len(polygons), len(lines), len(points)} {
+		if n > 0 {
+			kinds++
+		}
+	}
+
+	if kinds > 1 {
+		var members []Geometry
+		for i := range polygons {
+			members = append(members, &polygons[i])
+		}
+		for _, line := range lines {
+			members = append(members, &LineString{Points: line})
+		}
+		for i := range points {
+			members = append(members, &points[i])
+		}
+		if hasSRID {
+			return &GeometryCollectionS{SRID: srid, Geometries: members}
+		}
+		return &GeometryCollection{Geometries: members}
+	}
+
+	lineStrings := make([]LineString, len(lines))
+	for i, line := range lines {
+		lineStrings[i] = LineString{Points: line}
+	}
+
+	// An empty result takes the dimension the operation would normally produce
+	dim := min(a.dim, b.dim)
+	if op != opIntersection {
+		dim = max(a.dim, b.dim)
+	}
+	switch {
+	case len(points) > 0 || (len(lines) == 0 && len(polygons) == 0 && dim == 0):
+		if hasSRID {
+			return &MultiPointS{SRID: srid, Points: points}
+		}
+		return &MultiPoint{Points: points}
+	case len(lines) > 0 || (len(polygons) == 0 && dim == 1):
+		if hasSRID {
+			return &MultiLineStringS{SRID: srid, LineStrings: lineStrings}
+		}
+		return &MultiLineString{LineStrings: lineStrings}
+	default:
+		if hasSRID {
+			return &MultiPolygonS{SRID: srid, Polygons: polygons}
+		}
+		return &MultiPolygon{Polygons: polygons}
+	}
+}
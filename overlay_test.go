@@ -0,0 +1,244 @@
+package postgis
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func square(x, y, size float64) []Point {
+	return []Point{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}, {X: x, Y: y}}
+}
+
+func multiPolygonArea(t *testing.T, g Geometry) float64 {
+	t.Helper()
+	mp, ok := g.(*MultiPolygonS)
+	if !ok {
+		t.Fatalf("Expected *MultiPolygonS, got %T", g)
+	}
+	var area float64
+	for _, p := range mp.Polygons {
+		area += polygonArea(p.Rings)
+	}
+	return area
+}
+
+func TestOverlayPolygons(t *testing.T) {
+	a := &PolygonS{SRID: 4326, Rings: [][]Point{square(0, 0, 10)}}
+	b := &PolygonS{SRID: 4326, Rings: [][]Point{square(5, 5, 10)}}
+
+	tests := []struct {
+		name     string
+		op       func(a, b Geometry) (Geometry, error)
+		expected float64
+		parts    int
+	}{
+		{"Intersection", Intersection, 25, 1},
+		{"Union", Union, 175, 1},
+		{"Difference", Difference, 75, 1},
+		{"SymDifference", SymDifference, 150, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.op(a, b)
+			if err != nil {
+				t.Fatalf("%s failed: %v", test.name, err)
+			}
+			if area := multiPolygonArea(t, result); math.Abs(area-test.expected) > 1e-9 {
+				t.Errorf("Expected area %f, got %f", test.expected, area)
+			}
+			if parts := len(result.(*MultiPolygonS).Polygons); parts != test.parts {
+				t.Errorf("Expected %d polygons, got %d", test.parts, parts)
+			}
+			if srid := result.(*MultiPolygonS).SRID; srid != 4326 {
+				t.Errorf("Expected SRID 4326, got %d", srid)
+			}
+		})
+	}
+}
+
+func TestOverlayHoles(t *testing.T) {
+	// Clipping a polygon with a hole keeps the hole, whatever the ring orientation
+	hole := square(4, 4, 2)
+	for i, j := 0, len(hole)-1; i < j; i, j = i+1, j-1 {
+		hole[i], hole[j] = hole[j], hole[i]
+	}
+	a := &PolygonS{Rings: [][]Point{square(0, 0, 10), hole}}
+	b := &PolygonS{Rings: [][]Point{square(3, 3, 10)}}
+
+	result, err := Intersection(a, b)
+	if err != nil {
+		t.Fatalf("Intersection failed: %v", err)
+	}
+	if area := multiPolygonArea(t, result); math.Abs(area-(49-4)) > 1e-9 {
+		t.Errorf("Expected area 45, got %f", area)
+	}
+	if rings := len(result.(*MultiPolygonS).Polygons[0].Rings); rings != 2 {
+		t.Errorf("Expected the hole to be kept, got %d rings", rings)
+	}
+}
+
+func TestOverlayLowerDimensions(t *testing.T) {
+	// Squares sharing an edge intersect in a line
+	result, err := Intersection(&Polygon{Rings: [][]Point{square(0, 0, 1)}}, &Polygon{Rings: [][]Point{square(1, 0, 1)}})
+	if err != nil {
+		t.Fatalf("Intersection failed: %v", err)
+	}
+	mls, ok := result.(*MultiLineString)
+	if !ok || len(mls.LineStrings) != 1 || len(mls.LineStrings[0].Points) != 2 {
+		t.Fatalf("Expected a single shared edge, got %#v", result)
+	}
+
+	// Crossing lines intersect in a point
+	result, err = Intersection(
+		&LineString{Points: []Point{{X: 0, Y: 0}, {X: 2, Y: 2}}},
+		&LineString{Points: []Point{{X: 0, Y: 2}, {X: 2, Y: 0}}})
+	if err != nil {
+		t.Fatalf("Intersection failed: %v", err)
+	}
+	mp, ok := result.(*MultiPoint)
+	if !ok || len(mp.Points) != 1 || mp.Points[0] != (Point{X: 1, Y: 1}) {
+		t.Fatalf("Expected POINT(1 1), got %#v", result)
+	}
+}
+
+func TestOverlayLineWithPolygon(t *testing.T) {
+	line := &LineStringS{SRID: 3857, Points: []Point{{X: -5, Y: 5}, {X: 15, Y: 5}}}
+	region := &PolygonS{SRID: 3857, Rings: [][]Point{square(0, 0, 10)}}
+
+	// Clipping keeps the part inside the region
+	result, err := Intersection(line, region)
+	if err != nil {
+		t.Fatalf("Intersection failed: %v", err)
+	}
+	clipped, ok := result.(*MultiLineStringS)
+	if !ok || len(clipped.LineStrings) != 1 {
+		t.Fatalf("Expected one clipped line, got %#v", result)
+	}
+	expected := []Point{{X: 0, Y: 5}, {X: 10, Y: 5}}
+	if got := clipped.LineStrings[0].Points; len(got) != 2 || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	// The difference keeps both ends, in the direction of the input
+	result, err = Difference(line, region)
+	if err != nil {
+		t.Fatalf("Difference failed: %v", err)
+	}
+	outside, ok := result.(*MultiLineStringS)
+	if !ok || len(outside.LineStrings) != 2 {
+		t.Fatalf("Expected two lines, got %#v", result)
+	}
+	for _, ls := range outside.LineStrings {
+		if ls.Points[0].X > ls.Points[1].X {
+			t.Errorf("Expected line to run west to east, got %v", ls.Points)
+		}
+	}
+
+	// The union mixes dimensions
+	result, err = Union(line, region)
+	if err != nil {
+		t.Fatalf("Union failed: %v", err)
+	}
+	gc, ok := result.(*GeometryCollectionS)
+	if !ok || len(gc.Geometries) != 3 {
+		t.Fatalf("Expected a polygon and two lines, got %#v", result)
+	}
+	if _, err := gc.Value(); err != nil {
+		t.Errorf("GeometryCollectionS.Value() failed: %v", err)
+	}
+}
+
+func TestOverlaySRIDMismatch(t *testing.T) {
+	a := &PolygonS{SRID: 4326, Rings: [][]Point{square(0, 0, 1)}}
+	b := &PolygonS{SRID: 3857, Rings: [][]Point{square(0, 0, 1)}}
+
+	if _, err := Intersection(a, b); !errors.Is(err, ErrSRIDMismatch) {
+		t.Errorf("Expected ErrSRIDMismatch, got %v", err)
+	}
+	if _, err := Union(a, &Polygon{Rings: [][]Point{square(0, 0, 1)}}); !errors.Is(err, ErrSRIDMismatch) {
+		t.Errorf("Expected ErrSRIDMismatch against a geometry without SRID, got %v", err)
+	}
+}
+
+func TestOverlayPoints(t *testing.T) {
+	points := &MultiPointS{SRID: 4326, Points: []Point{{X: 1, Y: 1}, {X: 20, Y: 20}, {X: 1, Y: 1}}}
+	region := &PolygonS{SRID: 4326, Rings: [][]Point{square(0, 0, 10)}}
+
+	// Points are kept when they fall inside the region
+	result, err := Intersection(points, region)
+	if err != nil {
+		t.Fatalf("Intersection failed: %v", err)
+	}
+	if mp, ok := result.(*MultiPointS); !ok || len(mp.Points) != 1 || mp.Points[0] != (Point{X: 1, Y: 1}) {
+		t.Errorf("Expected MULTIPOINT(1 1), got %#v", result)
+	}
+
+	result, err = Difference(points, region)
+	if err != nil {
+		t.Fatalf("Difference failed: %v", err)
+	}
+	if mp, ok := result.(*MultiPointS); !ok || len(mp.Points) != 1 || mp.Points[0] != (Point{X: 20, Y: 20}) {
+		t.Errorf("Expected MULTIPOINT(20 20), got %#v", result)
+	}
+
+	// Points have no area to take away
+	if area := multiPolygonArea(t, mustOverlay(t, Difference, region, points)); area != 100 {
+		t.Errorf("Expected the region to be unchanged, got an area of %v", area)
+	}
+
+	// The union drops the point already inside the region
+	gc, ok := mustOverlay(t, Union, region, points).(*GeometryCollectionS)
+	if !ok || len(gc.Geometries) != 2 {
+		t.Fatalf("Expected a polygon and a point, got %#v", gc)
+	}
+	if p, ok := gc.Geometries[1].(*Point); !ok || *p != (Point{X: 20, Y: 20}) {
+		t.Errorf("Expected POINT(20 20), got %#v", gc.Geometries[1])
+	}
+
+	// Two point sets combine as sets
+	other := &PointS{SRID: 4326, X: 1, Y: 1}
+	if mp, ok := mustOverlay(t, Union, points, other).(*MultiPointS); !ok || len(mp.Points) != 2 {
+		t.Errorf("Expected two points, got %#v", mp)
+	}
+	if mp, ok := mustOverlay(t, SymDifference, points, other).(*MultiPointS); !ok || len(mp.Points) != 1 || mp.Points[0] != (Point{X: 20, Y: 20}) {
+		t.Errorf("Expected MULTIPOINT(20 20), got %#v", mp)
+	}
+
+	// An empty result has the dimension of the points
+	if mp, ok := mustOverlay(t, Intersection, &PointS{SRID: 4326, X: 50, Y: 50}, region).(*MultiPointS); !ok || len(mp.Points) != 0 {
+		t.Errorf("Expected an empty MultiPointS, got %#v", mp)
+	}
+}
+
+func TestOverlayDropsZM(t *testing.T) {
+	a := &PolygonZ{Rings: [][]PointZ{{{X: 0, Y: 0, Z: 1}, {X: 10, Y: 0, Z: 2}, {X: 10, Y: 10, Z: 3}, {X: 0, Y: 10, Z: 4}, {X: 0, Y: 0, Z: 1}}}}
+	b := &LineStringM{Points: []PointM{{X: -5, Y: 5, M: 1}, {X: 15, Y: 5, M: 2}}}
+
+	result, err := Intersection(a, b)
+	if err != nil {
+		t.Fatalf("Intersection failed: %v", err)
+	}
+	mls, ok := result.(*MultiLineString)
+	if !ok || len(mls.LineStrings) != 1 {
+		t.Fatalf("Expected one 2D line, got %#v", result)
+	}
+	expected := []Point{{X: 0, Y: 5}, {X: 10, Y: 5}}
+	if got := mls.LineStrings[0].Points; len(got) != 2 || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if _, err := Union(a, &TIN{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Expected ErrUnsupportedType for a TIN operand, got %v", err)
+	}
+}
+
+func mustOverlay(t *testing.T, op func(a, b Geometry) (Geometry, error), a, b Geometry) Geometry {
+	t.Helper()
+	result, err := op(a, b)
+	if err != nil {
+		t.Fatalf("Overlay failed: %v", err)
+	}
+	return result
+}
@@ -0,0 +1,684 @@
+package postgis
+
+import (
+	"math"
+	"sort"
+)
+
+// Planar graph machinery shared by the geometric operations (buffer, overlay
+// and friends). Input rings and lines are noded against each other, coincident
+// edges are merged and every edge is labelled with the winding number of each
+// areal input on both of its sides. Callers then keep the edges separating area
+// they want from area they don't and the graph traces those back into polygons;
+// linear results are merged back into lines.
+
+// fillRule decides which winding numbers count as being inside an input
+type fillRule int
+
+const (
+	// fillNonZero treats any non-zero winding as inside; used for generated
+	// shapes such as buffer pieces that are all oriented counter-clockwise
+	fillNonZero fillRule = iota
+	// fillEvenOdd treats odd windings as inside; used for user supplied rings
+	// whose orientation cannot be trusted
+	fillEvenOdd
+)
+
+func (r fillRule) inside(winding int) bool {
+	if r == fillEvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// maxGraphSources is the number of inputs a planar graph can label at once
+const maxGraphSources = 2
+
+// maxNodingPasses bounds the iterative noding; each pass only adds vertices
+// that were created by the previous one so it converges quickly in practice
+const maxNodingPasses = 16
+
+type graphSegment struct {
+	a, b int // vertex ids, directed a->b
+	src  int
+}
+
+type graphEdge struct {
+	a, b    int                     // vertex ids with a < b
+	count   [maxGraphSources]int    // signed multiplicity per areal source, positive when running a->b
+	lines   [maxGraphSources]int    // multiplicity per linear source
+	forward int                     // direction of the first line running along the edge, 1 for a->b
+	winding [maxGraphSources][2]int // winding number per source on the left [0] and right [1] of a->b
+	labeled bool
+}
+
+type planarGraph struct {
+	tol      float64
+	linear   [maxGraphSources]bool
+	vertices []Point
+	cells    map[[2]int64][]int
+	segments []graphSegment
+	edges    []graphEdge
+}
+
+// newPlanarGraph creates a graph whose snapping tolerance is derived from the
+// magnitude of the coordinates of the given rings
+func newPlanarGraph(sources ...[][]Point) *planarGraph {
+	return &planarGraph{
+		tol:   snapTolerance(sources...),
+		cells: make(map[[2]int64][]int),
+	}
+}
+
+// snapTolerance returns the distance under which two points of the sources
+// are the same point, relative to the magnitude of their coordinates
+func snapTolerance(sources ...[][]Point) float64 {
+	maxAbs := 1.0
+	for _, rings := range sources {
+		for _, ring := range rings {
+			for _, p := range ring {
+				maxAbs = math.Max(maxAbs, math.Max(math.Abs(p.X), math.Abs(p.Y)))
+			}
+		}
+	}
+	return maxAbs * 1e-10
+}
+
+// addVertex returns the id of the vertex at p, reusing an existing vertex
+// within the snapping tolerance
+func (g *planarGraph) addVertex(p Point) int {
+	cx, cy := int64(math.Floor(p.X/g.tol)), int64(math.Floor(p.Y/g.tol))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, id := range g.cells[[2]int64{cx + dx, cy + dy}] {
+				if distance(g.vertices[id], p) <= g.tol {
+					return id
+				}
+			}
+		}
+	}
+	id := len(g.vertices)
+	g.vertices = append(g.vertices, p)
+	g.cells[[2]int64{cx, cy}] = append(g.cells[[2]int64{cx, cy}], id)
+	return id
+}
+
+// addRings adds the rings of a source as directed segments. Rings may be
+// given open or closed.
+func (g *planarGraph) addRings(src int, rings [][]Point) {
+	for _, ring := range rings {
+		n := len(ring)
+		if n > 1 && ring[0] == ring[n-1] {
+			n--
+		}
+		if n < 2 {
+			continue
+		}
+		first := g.addVertex(ring[0])
+		prev := first
+		for i := 1; i <= n; i++ {
+			cur := first
+			if i < n {
+				cur = g.addVertex(ring[i])
+			}
+			if cur != prev {
+				g.segments = append(g.segments, graphSegment{a: prev, b: cur, src: src})
+			}
+			prev = cur
+		}
+	}
+}
+
+// addLines adds the lines of a linear source as directed segments
+func (g *planarGraph) addLines(src int, lines [][]Point) {
+	g.linear[src] = true
+	for _, line := range lines {
+		for i := 0; i+1 < len(line); i++ {
+			a, b := g.addVertex(line[i]), g.addVertex(line[i+1])
+			if a != b {
+				g.segments = append(g.segments, graphSegment{a: a, b: b, src: src})
+			}
+		}
+	}
+}
+
+// node splits the segments at every intersection so that afterwards segments
+// only meet at their end points
+func (g *planarGraph) node() {
+	for pass := 0; pass < maxNodingPasses; pass++ {
+		splits := g.findSplits()
+		if len(splits) == 0 {
+			return
+		}
+		g.splitSegments(splits)
+	}
+}
+
+func (g *planarGraph) findSplits() map[int][]int {
+	order := make([]int, len(g.segments))
+	for i := range order {
+		order[i] = i
+	}
+	minX := func(i int) float64 {
+		s := g.segments[i]
+		return math.Min(g.vertices[s.a].X, g.vertices[s.b].X)
+	}
+	sort.Slice(order, func(i, j int) bool { return minX(order[i]) < minX(order[j]) })
+
+	splits := make(map[int][]int)
+	for i, si := range order {
+		s := g.segments[si]
+		sa, sb := g.vertices[s.a], g.vertices[s.b]
+		maxX := math.Max(sa.X, sb.X) + g.tol
+		sMinY, sMaxY := math.Min(sa.Y, sb.Y)-g.tol, math.Max(sa.Y, sb.Y)+g.tol
+		for _, ti := range order[i+1:] {
+			if minX(ti) > maxX {
+				break
+			}
+			t := g.segments[ti]
+			ta, tb := g.vertices[t.a], g.vertices[t.b]
+			if math.Max(ta.Y, tb.Y) < sMinY || math.Min(ta.Y, tb.Y) > sMaxY {
+				continue
+			}
+			g.intersect(si, ti, splits)
+		}
+	}
+	return splits
+}
+
+// intersect records the vertices at which segments si and ti must be split
+func (g *planarGraph) intersect(si, ti int, splits map[int][]int) {
+	s, t := g.segments[si], g.segments[ti]
+	if (s.a == t.a && s.b == t.b) || (s.a == t.b && s.b == t.a) {
+		return
+	}
+
+	touched := false
+	onSegment := func(v int, seg graphSegment, segIndex int) {
+		if v == seg.a || v == seg.b {
+			return
+		}
+		if segmentDistance(g.vertices[v], g.vertices[seg.a], g.vertices[seg.b]) <= g.tol {
+			splits[segIndex] = append(splits[segIndex], v)
+			touched = true
+		}
+	}
+	onSegment(t.a, s, si)
+	onSegment(t.b, s, si)
+	onSegment(s.a, t, ti)
+	onSegment(s.b, t, ti)
+	if touched || s.a == t.a || s.a == t.b || s.b == t.a || s.b == t.b {
+		return
+	}
+
+	pa, pb := g.vertices[s.a], g.vertices[s.b]
+	qa, qb := g.vertices[t.a], g.vertices[t.b]
+	o1, o2 := orientation(pa, pb, qa), orientation(pa, pb, qb)
+	o3, o4 := orientation(qa, qb, pa), orientation(qa, qb, pb)
+	if !((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) || !((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0)) {
+		return
+	}
+
+	f := o3 / (o3 - o4)
+	v := g.addVertex(Point{X: pa.X + f*(pb.X-pa.X), Y: pa.Y + f*(pb.Y-pa.Y)})
+	if v != s.a && v != s.b {
+		splits[si] = append(splits[si], v)
+	}
+	if v != t.a && v != t.b {
+		splits[ti] = append(splits[ti], v)
+	}
+}
+
+func (g *planarGraph) splitSegments(splits map[int][]int) {
+	segments := make([]graphSegment, 0, len(g.segments)+len(splits))
+	for i, s := range g.segments {
+		vs, ok := splits[i]
+		if !ok {
+			segments = append(segments, s)
+			continue
+		}
+		a, b := g.vertices[s.a], g.vertices[s.b]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		param := func(v int) float64 {
+			p := g.vertices[v]
+			return (p.X-a.X)*dx + (p.Y-a.Y)*dy
+		}
+		sort.Slice(vs, func(i, j int) bool { return param(vs[i]) < param(vs[j]) })
+
+		prev := s.a
+		for _, v := range append(vs, s.b) {
+			if v == prev {
+				continue
+			}
+			segments = append(segments, graphSegment{a: prev, b: v, src: s.src})
+			prev = v
+		}
+	}
+	g.segments = segments
+}
+
+// buildEdges merges coincident segments into undirected edges
+func (g *planarGraph) buildEdges() {
+	index := make(map[[2]int]int)
+	for _, s := range g.segments {
+		a, b, dir := s.a, s.b, 1
+		if a > b {
+			a, b, dir = b, a, -1
+		}
+		i, ok := index[[2]int{a, b}]
+		if !ok {
+			i = len(g.edges)
+			index[[2]int{a, b}] = i
+			g.edges = append(g.edges, graphEdge{a: a, b: b})
+		}
+		e := &g.edges[i]
+		if g.linear[s.src] {
+			e.lines[s.src]++
+			if e.forward == 0 {
+				e.forward = dir
+			}
+		} else {
+			e.count[s.src] += dir
+		}
+	}
+}
+
+// bandIndex buckets edges by the range they cover along one axis so that ray
+// casts only visit edges that can possibly cross the ray
+type bandIndex struct {
+	min, size float64
+	bands     [][]int
+}
+
+func newBandIndex(g *planarGraph, vertical bool) *bandIndex {
+	coord := func(p Point) float64 {
+		if vertical {
+			return p.Y
+		}
+		return p.X
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, p := range g.vertices {
+		lo, hi = math.Min(lo, coord(p)), math.Max(hi, coord(p))
+	}
+	n := int(math.Sqrt(float64(len(g.edges)))) + 1
+	idx := &bandIndex{min: lo, size: (hi - lo) / float64(n), bands: make([][]int, n)}
+	if idx.size == 0 {
+		idx.size = 1
+	}
+	for i, e := range g.edges {
+		a, b := coord(g.vertices[e.a]), coord(g.vertices[e.b])
+		for band := idx.band(math.Min(a, b)); band <= idx.band(math.Max(a, b)); band++ {
+			idx.bands[band] = append(idx.bands[band], i)
+		}
+	}
+	return idx
+}
+
+func (idx *bandIndex) band(v float64) int {
+	b := int((v - idx.min) / idx.size)
+	if b < 0 {
+		return 0
+	}
+	if b >= len(idx.bands) {
+		return len(idx.bands) - 1
+	}
+	return b
+}
+
+// label computes the winding numbers on both sides of every edge. The
+// winding is measured with a ray cast from the edge midpoint (to +X, or to +Y
+// for horizontal edges) that ignores the edge itself; the far side of the edge
+// then follows from its own multiplicity. Edges only carrying lines are
+// labelled too, which tells whether the lines lie inside the areal inputs.
+func (g *planarGraph) label() {
+	byY, byX := newBandIndex(g, true), newBandIndex(g, false)
+	for i := range g.edges {
+		e := &g.edges[i]
+		a, b := g.vertices[e.a], g.vertices[e.b]
+		m := Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+
+		horizontal := a.Y == b.Y
+		var w [maxGraphSources]int
+		if horizontal {
+			w = g.windingUp(m, i, byX)
+		} else {
+			w = g.windingRight(m, i, byY)
+		}
+		for src, c := range e.count {
+			switch {
+			case !horizontal && b.Y > a.Y, horizontal && b.X < a.X:
+				// The ray leaves the edge on its right
+				e.winding[src] = [2]int{w[src] + c, w[src]}
+			default:
+				e.winding[src] = [2]int{w[src], w[src] - c}
+			}
+		}
+		e.labeled = true
+	}
+}
+
+// windingRight computes the winding numbers at m with a ray towards +X
+func (g *planarGraph) windingRight(m Point, skip int, idx *bandIndex) [maxGraphSources]int {
+	var w [maxGraphSources]int
+	for _, i := range idx.bands[idx.band(m.Y)] {
+		e := g.edges[i]
+		if i == skip || e.count == [maxGraphSources]int{} {
+			continue
+		}
+		u, v := g.vertices[e.a], g.vertices[e.b]
+		switch {
+		case u.Y <= m.Y && v.Y > m.Y && orientation(u, v, m) > 0:
+			for src, c := range e.count {
+				w[src] += c
+			}
+		case v.Y <= m.Y && u.Y > m.Y && orientation(u, v, m) < 0:
+			for src, c := range e.count {
+				w[src] -= c
+			}
+		}
+	}
+	return w
+}
+
+// windingUp computes the winding numbers at m with a ray towards +Y
+func (g *planarGraph) windingUp(m Point, skip int, idx *bandIndex) [maxGraphSources]int {
+	var w [maxGraphSources]int
+	for _, i := range idx.bands[idx.band(m.X)] {
+		e := g.edges[i]
+		if i == skip || e.count == [maxGraphSources]int{} {
+			continue
+		}
+		u, v := g.vertices[e.a], g.vertices[e.b]
+		switch {
+		case u.X <= m.X && v.X > m.X && orientation(u, v, m) < 0:
+			for src, c := range e.count {
+				w[src] -= c
+			}
+		case v.X <= m.X && u.X > m.X && orientation(u, v, m) > 0:
+			for src, c := range e.count {
+				w[src] += c
+			}
+		}
+	}
+	return w
+}
+
+// polygons traces the edges separating kept area from discarded area into
+// polygons. Exterior rings are counter-clockwise and holes clockwise.
+func (g *planarGraph) polygons(keep func(left [maxGraphSources]int) bool) []Polygon {
+	type halfEdge struct {
+		from, to int
+		angle    float64
+		visited  bool
+	}
+	var halfEdges []halfEdge
+	outgoing := make(map[int][]int)
+	for _, e := range g.edges {
+		if !e.labeled {
+			continue
+		}
+		var left, right [maxGraphSources]int
+		for src := range e.winding {
+			left[src], right[src] = e.winding[src][0], e.winding[src][1]
+		}
+		inLeft, inRight := keep(left), keep(right)
+		if inLeft == inRight {
+			continue
+		}
+		from, to := e.a, e.b
+		if inRight {
+			from, to = e.b, e.a
+		}
+		p, q := g.vertices[from], g.vertices[to]
+		outgoing[from] = append(outgoing[from], len(halfEdges))
+		halfEdges = append(halfEdges, halfEdge{from: from, to: to, angle: math.Atan2(q.Y-p.Y, q.X-p.X)})
+	}
+	for _, out := range outgoing {
+		sort.Slice(out, func(i, j int) bool { return halfEdges[out[i]].angle < halfEdges[out[j]].angle })
+	}
+
+	// next picks the outgoing edge that is the first one clockwise from the
+	// reversed incoming edge, which keeps the traced face as small as possible
+	next := func(in int) int {
+		out := outgoing[halfEdges[in].to]
+		p, q := g.vertices[halfEdges[in].to], g.vertices[halfEdges[in].from]
+		back := math.Atan2(q.Y-p.Y, q.X-p.X)
+		pick := out[len(out)-1]
+		for _, o := range out {
+			if halfEdges[o].angle >= back {
+				break
+			}
+			pick = o
+		}
+		return pick
+	}
+
+	var shells, holes [][]Point
+	for start := range halfEdges {
+		if halfEdges[start].visited {
+			continue
+		}
+		var walk []int
+		for e := start; !halfEdges[e].visited; e = next(e) {
+			halfEdges[e].visited = true
+			walk = append(walk, halfEdges[e].from)
+		}
+		for _, loop := range splitWalk(walk) {
+			ring := make([]Point, 0, len(loop)+1)
+			for _, v := range loop {
+				ring = append(ring, g.vertices[v])
+			}
+			ring = append(ring, ring[0])
+			switch area := ringArea(ring); {
+			case area > 0:
+				shells = append(shells, ring)
+			case area < 0:
+				holes = append(holes, ring)
+			}
+		}
+	}
+	return assemblePolygons(shells, holes)
+}
+
+// lines merges the edges for which keep returns true into maximal lines,
+// joining edges at vertices where exactly two of them meet. Lines follow the
+// direction of the input lines where possible.
+func (g *planarGraph) lines(keep func(e *graphEdge) bool) [][]Point {
+	incident := make(map[int][]int)
+	var kept []int
+	for i := range g.edges {
+		e := &g.edges[i]
+		if !keep(e) {
+			continue
+		}
+		kept = append(kept, i)
+		incident[e.a] = append(incident[e.a], i)
+		incident[e.b] = append(incident[e.b], i)
+	}
+
+	visited := make(map[int]bool)
+	walk := func(start, edge int) []Point {
+		line := []Point{g.vertices[start]}
+		forward := 0
+		v := start
+		for {
+			visited[edge] = true
+			e := g.edges[edge]
+			if v == e.a {
+				forward += e.forward
+				v = e.b
+			} else {
+				forward -= e.forward
+				v = e.a
+			}
+			line = append(line, g.vertices[v])
+			if v == start || len(incident[v]) != 2 {
+				break
+			}
+			next := incident[v][0]
+			if next == edge {
+				next = incident[v][1]
+			}
+			if visited[next] {
+				break
+			}
+			edge = next
+		}
+		if forward < 0 {
+			for i, j := 0, len(line)-1; i < j; i, j = i+1, j-1 {
+				line[i], line[j] = line[j], line[i]
+			}
+		}
+		return line
+	}
+
+	var lines [][]Point
+	for _, i := range kept {
+		e := g.edges[i]
+		for _, v := range []int{e.a, e.b} {
+			if !visited[i] && len(incident[v]) != 2 {
+				lines = append(lines, walk(v, i))
+			}
+		}
+	}
+	// Whatever is left forms closed loops
+	for _, i := range kept {
+		if !visited[i] {
+			lines = append(lines, walk(g.edges[i].a, i))
+		}
+	}
+	return lines
+}
+
+// splitWalk splits a closed walk of vertex ids that visits some vertices
+// more than once into simple loops
+func splitWalk(walk []int) [][]int {
+	var loops [][]int
+	var stack []int
+	position := make(map[int]int)
+	for _, v := range walk {
+		if at, ok := position[v]; ok {
+			loop := append([]int(nil), stack[at:]...)
+			for _, u := range loop {
+				delete(position, u)
+			}
+			if len(loop) > 2 {
+				loops = append(loops, loop)
+			}
+			stack = stack[:at]
+		}
+		position[v] = len(stack)
+		stack = append(stack, v)
+	}
+	if len(stack) > 2 {
+		loops = append(loops, stack)
+	}
+	return loops
+}
+
+// assemblePolygons assigns every hole to the smallest shell containing it
+func assemblePolygons(shells, holes [][]Point) []Polygon {
+	polygons := make([]Polygon, len(shells))
+	areas := make([]float64, len(shells))
+	for i, shell := range shells {
+		polygons[i].Rings = [][]Point{shell}
+		areas[i] = ringArea(shell)
+	}
+	for _, hole := range holes {
+		owner := -1
+		for i, shell := range shells {
+			if owner >= 0 && areas[i] >= areas[owner] {
+				continue
+			}
+			if ringContainsRing(shell, hole) {
+				owner = i
+			}
+		}
+		if owner >= 0 {
+			polygons[owner].Rings = append(polygons[owner].Rings, hole)
+		}
+	}
+	return polygons
+}
+
+// ringContainsRing reports whether inner lies inside outer, judged by the
+// first vertex of inner that is not on the boundary of outer
+func ringContainsRing(outer, inner []Point) bool {
+	for _, p := range inner {
+		switch pointInRing(p, outer) {
+		case 1:
+			return true
+		case -1:
+			return false
+		}
+	}
+	return false
+}
+
+// pointInRing returns 1 when p is inside ring, 0 when it is on its boundary
+// and -1 when it is outside. The ring must be closed.
+func pointInRing(p Point, ring []Point) int {
+	inside := false
+	for i := 0; i+1 < len(ring); i++ {
+		a, b := ring[i], ring[i+1]
+		if orientation(a, b, p) == 0 &&
+			p.X >= math.Min(a.X, b.X) && p.X <= math.Max(a.X, b.X) &&
+			p.Y >= math.Min(a.Y, b.Y) && p.Y <= math.Max(a.Y, b.Y) {
+			return 0
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	if inside {
+		return 1
+	}
+	return -1
+}
+
+// ringArea returns the signed area of a closed ring, positive when the ring
+// is counter-clockwise
+func ringArea(ring []Point) float64 {
+	var sum float64
+	for i := 0; i+1 < len(ring); i++ {
+		sum += ring[i].X*ring[i+1].Y - ring[i+1].X*ring[i].Y
+	}
+	return sum / 2
+}
+
+// orientation is positive when c lies to the left of a->b, negative when it
+// lies to the right and zero when the three points are collinear
+func orientation(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+func distance(a, b Point) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
+
+// segmentDistance returns the distance from p to the segment a-b
+func segmentDistance(p, a, b Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l := dx*dx + dy*dy
+	if l == 0 {
+		return distance(p, a)
+	}
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / l
+	t = math.Max(0, math.Min(1, t))
+	return distance(p, Point{X: a.X + t*dx, Y: a.Y + t*dy})
+}
+
+// unionRings returns the polygons covering the union of the given
+// counter-clockwise rings
+func unionRings(rings [][]Point) []Polygon {
+	g := newPlanarGraph(rings)
+	g.addRings(0, rings)
+	g.node()
+	g.buildEdges()
+	g.label()
+	return g.polygons(func(left [maxGraphSources]int) bool {
+		return fillNonZero.inside(left[0])
+	})
+}
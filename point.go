@@ -44,6 +44,22 @@ type PointZMS struct {
 	X, Y, Z, M float64
 }
 
+// PointLike is the set of coordinate types: Point, PointZ, PointM and
+// PointZM. The coordinate type of a generic geometry such as LineStringOf
+// sets its dimensions.
+type PointLike interface {
+	Point | PointZ | PointM | PointZM
+	GetType() uint32
+	Write(*bytes.Buffer) error
+	coordinate
+}
+
+// coordinateType returns the CoordinateType of a point type
+func coordinateType[P PointLike]() CoordinateType {
+	var zero P
+	return GetGeometryInfo(zero.GetType()).CoordType
+}
+
 // Implement SRIDGeometry interface for SRID types
 func (p *PointS) GetSRID() int32     { return p.SRID }
 func (p *PointS) SetSRID(srid int32) { p.SRID = srid }
@@ -57,45 +73,58 @@ func (p *PointMS) SetSRID(srid int32) { p.SRID = srid }
 func (p *PointZMS) GetSRID() int32     { return p.SRID }
 func (p *PointZMS) SetSRID(srid int32) { p.SRID = srid }
 
-// Implement PointReader interface for SRID types (they need special handling)
+// xy returns the planar coordinates of a point, used by the 2D algorithms
+func (p Point) xy() Point    { return p }
+func (p PointZ) xy() Point   { return Point{X: p.X, Y: p.Y} }
+func (p PointM) xy() Point   { return Point{X: p.X, Y: p.Y} }
+func (p PointZM) xy() Point  { return Point{X: p.X, Y: p.Y} }
+func (p PointS) xy() Point   { return Point{X: p.X, Y: p.Y} }
+func (p PointZS) xy() Point  { return Point{X: p.X, Y: p.Y} }
+func (p PointMS) xy() Point  { return Point{X: p.X, Y: p.Y} }
+func (p PointZMS) xy() Point { return Point{X: p.X, Y: p.Y} }
+
+// finite reports whether none of the coordinates of a point is NaN or infinite
+func (p Point) finite() bool    { return isFinite(p.X, p.Y) }
+func (p PointZ) finite() bool   { return isFinite(p.X, p.Y, p.Z) }
+func (p PointM) finite() bool   { return isFinite(p.X, p.Y, p.M) }
+func (p PointZM) finite() bool  { return isFinite(p.X, p.Y, p.Z, p.M) }
+func (p PointS) finite() bool   { return isFinite(p.X, p.Y) }
+func (p PointZS) finite() bool  { return isFinite(p.X, p.Y, p.Z) }
+func (p PointMS) finite() bool  { return isFinite(p.X, p.Y, p.M) }
+func (p PointZMS) finite() bool { return isFinite(p.X, p.Y, p.Z, p.M) }
+
+// Implement PointReader interface for all types, decoding the coordinates
+// without reflection
+func (p *Point) ReadPoint(reader io.Reader, byteOrder binary.ByteOrder) error {
+	return readFloats(reader, byteOrder, &p.X, &p.Y)
+}
+
+func (p *PointZ) ReadPoint(reader io.Reader, byteOrder binary.ByteOrder) error {
+	return readFloats(reader, byteOrder, &p.X, &p.Y, &p.Z)
+}
+
+func (p *PointM) ReadPoint(reader io.Reader, byteOrder binary.ByteOrder) error {
+	return readFloats(reader, byteOrder, &p.X, &p.Y, &p.M)
+}
+
+func (p *PointZM) ReadPoint(reader io.Reader, byteOrder binary.ByteOrder) error {
+	return readFloats(reader, byteOrder, &p.X, &p.Y, &p.Z, &p.M)
+}
+
 func (p *PointS) ReadPoint(reader io.Reader, byteOrder binary.ByteOrder) error {
-	if err := binary.Read(reader, byteOrder, &p.X); err != nil {
-		return err
-	}
-	return binary.Read(reader, byteOrder, &p.Y)
+	return readFloats(reader, byteOrder, &p.X, &p.Y)
 }
 
 func (p *PointZS) ReadPoint(reader io.Reader, byteOrder binary.ByteOrder) error {
-	if err := binary.Read(reader, byteOrder, &p.X); err != nil {
-		return err
-	}
-	if err := binary.Read(reader, byteOrder, &p.Y); err != nil {
-		return err
-	}
-	return binary.Read(reader, byteOrder, &p.Z)
+	return readFloats(reader, byteOrder, &p.X, &p.Y, &p.Z)
 }
 
 func (p *PointMS) ReadPoint(reader io.Reader, byteOrder binary.ByteOrder) error {
-	if err := binary.Read(reader, byteOrder, &p.X); err != nil {
-		return err
-	}
-	if err := binary.Read(reader, byteOrder, &p.Y); err != nil {
-		return err
-	}
-	return binary.Read(reader, byteOrder, &p.M)
+	return readFloats(reader, byteOrder, &p.X, &p.Y, &p.M)
 }
 
 func (p *PointZMS) ReadPoint(reader io.Reader, byteOrder binary.ByteOrder) error {
-	if err := binary.Read(reader, byteOrder, &p.X); err != nil {
-		return err
-	}
-	if err := binary.Read(reader, byteOrder, &p.Y); err != nil {
-		return err
-	}
-	if err := binary.Read(reader, byteOrder, &p.Z); err != nil {
-		return err
-	}
-	return binary.Read(reader, byteOrder, &p.M)
+	return readFloats(reader, byteOrder, &p.X, &p.Y, &p.Z, &p.M)
 }
 
 /** Point functions **/
@@ -0,0 +1,106 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// PolygonOf is a Polygon whose coordinate type P sets its dimensions. Each
+// ring is a closed sequence of points; the first ring is the exterior and
+// the rest are holes.
+type PolygonOf[P PointLike] struct {
+	Rings [][]P
+}
+
+// PolygonSOf is a Polygon with an SRID
+type PolygonSOf[P PointLike] struct {
+	SRID  int32
+	Rings [][]P
+}
+
+// Varying types of Polygons
+type (
+	Polygon   = PolygonOf[Point]
+	PolygonZ  = PolygonOf[PointZ]
+	PolygonM  = PolygonOf[PointM]
+	PolygonZM = PolygonOf[PointZM]
+
+	PolygonS   = PolygonSOf[Point]
+	PolygonZS  = PolygonSOf[PointZ]
+	PolygonMS  = PolygonSOf[PointM]
+	PolygonZMS = PolygonSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (p *PolygonSOf[P]) GetSRID() int32     { return p.SRID }
+func (p *PolygonSOf[P]) SetSRID(srid int32) { p.SRID = srid }
+
+// Implement CollectionGeometry interface for all Polygon types
+func (p *PolygonOf[P]) GetElementCount() uint32  { return getElementCountHelper(p.Rings) }
+func (p *PolygonSOf[P]) GetElementCount() uint32 { return getElementCountHelper(p.Rings) }
+
+func (p *PolygonOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeRingsHelper(p.Rings, buffer)
+}
+
+func (p *PolygonSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeRingsHelper(p.Rings, buffer)
+}
+
+func (p *PolygonOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	rings, err := readRingsHelper[P](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	p.Rings = rings
+	return nil
+}
+
+func (p *PolygonSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	rings, err := readRingsHelper[P](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	p.Rings = rings
+	return nil
+}
+
+/** PolygonOf functions **/
+func (p *PolygonOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(p, value)
+}
+
+func (p PolygonOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&p)
+}
+
+func (p PolygonOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, p.GetElementCount(), func(buf *bytes.Buffer) error {
+		return p.WriteElements(buf)
+	})
+}
+
+func (p PolygonOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBPolygon, coordinateType[P](), false)
+}
+
+/** PolygonSOf functions **/
+func (p *PolygonSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(p, value)
+}
+
+func (p PolygonSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&p)
+}
+
+func (p PolygonSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, p.GetElementCount(), func(buf *bytes.Buffer) error {
+		return p.WriteElements(buf)
+	})
+}
+
+func (p PolygonSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBPolygon, coordinateType[P](), true)
+}
@@ -0,0 +1,137 @@
+package postgis
+
+import (
+	"testing"
+)
+
+func TestPolygon(t *testing.T) {
+	// Test Polygon with a hole
+	p := Polygon{
+		Rings: [][]Point{
+			{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}, {X: 0, Y: 0}},
+			{{X: 2, Y: 2}, {X: 2, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 2}, {X: 2, Y: 2}},
+		},
+	}
+
+	// Test Value() method
+	value, err := p.Value()
+	if err != nil {
+		t.Errorf("Polygon.Value() failed: %v", err)
+	}
+
+	// Test Scan() method
+	var p2 Polygon
+	err = p2.Scan(value)
+	if err != nil {
+		t.Errorf("Polygon.Scan() failed: %v", err)
+	}
+
+	// Verify the data
+	if len(p2.Rings) != 2 {
+		t.Fatalf("Expected 2 rings, got %d", len(p2.Rings))
+	}
+
+	for i, ring := range p2.Rings {
+		if len(ring) != len(p.Rings[i]) {
+			t.Fatalf("Ring %d: expected %d points, got %d", i, len(p.Rings[i]), len(ring))
+		}
+		for j, point := range ring {
+			if point != p.Rings[i][j] {
+				t.Errorf("Ring %d point %d mismatch: expected %v, got %v", i, j, p.Rings[i][j], point)
+			}
+		}
+	}
+}
+
+func TestPolygonZS_4326(t *testing.T) {
+	// Test Polygon with Z coordinates and SRID 4326
+	p := PolygonZS{
+		SRID: 4326,
+		Rings: [][]PointZ{
+			{
+				{X: -122.42, Y: 37.77, Z: 10},
+				{X: -122.40, Y: 37.77, Z: 20},
+				{X: -122.40, Y: 37.79, Z: 30},
+				{X: -122.42, Y: 37.77, Z: 10},
+			},
+		},
+	}
+
+	// Test Value() method
+	value, err := p.Value()
+	if err != nil {
+		t.Errorf("PolygonZS.Value() failed: %v", err)
+	}
+
+	// Test Scan() method
+	var p2 PolygonZS
+	err = p2.Scan(value)
+	if err != nil {
+		t.Errorf("PolygonZS.Scan() failed: %v", err)
+	}
+
+	// Verify SRID
+	if p2.SRID != 4326 {
+		t.Errorf("Expected SRID 4326, got %d", p2.SRID)
+	}
+
+	// Verify the data
+	if len(p2.Rings) != 1 || len(p2.Rings[0]) != 4 {
+		t.Fatalf("Expected 1 ring of 4 points, got %v", p2.Rings)
+	}
+
+	for i, point := range p2.Rings[0] {
+		if point != p.Rings[0][i] {
+			t.Errorf("Point %d mismatch: expected %v, got %v", i, p.Rings[0][i], point)
+		}
+	}
+}
+
+func TestPolygonGetType(t *testing.T) {
+	// Test GetType() methods for different Polygon variants
+	tests := []struct {
+		name     string
+		geometry Geometry
+		expected uint32
+	}{
+		{"Polygon", &Polygon{}, 3},
+		{"PolygonZ", &PolygonZ{}, 0x80000003},
+		{"PolygonM", &PolygonM{}, 0x40000003},
+		{"PolygonZM", &PolygonZM{}, 0xC0000003},
+		{"PolygonS", &PolygonS{}, 0x20000003},
+		{"PolygonZS", &PolygonZS{}, 0xA0000003},
+		{"PolygonMS", &PolygonMS{}, 0x60000003},
+		{"PolygonZMS", &PolygonZMS{}, 0xE0000003},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.geometry.GetType(); got != test.expected {
+				t.Errorf("%s.GetType() = 0x%X, expected 0x%X", test.name, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestEmptyPolygon(t *testing.T) {
+	// Test empty Polygon
+	p := Polygon{}
+
+	// Test Value() method
+	value, err := p.Value()
+	if err != nil {
+		t.Errorf("Empty Polygon.Value() failed: %v", err)
+	}
+
+	// Test Scan() method
+	var p2 Polygon
+	err = p2.Scan(value)
+	if err != nil {
+		t.Errorf("Empty Polygon.Scan() failed: %v", err)
+	}
+
+	// Verify the data
+	if len(p2.Rings) != 0 {
+		t.Errorf("Expected 0 rings, got %d", len(p2.Rings))
+	}
+}
@@ -0,0 +1,106 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// PolyhedralSurfaceOf is a PolyhedralSurface whose coordinate type P sets
+// its dimensions: a surface made of polygonal faces sharing their edges, as
+// used for 3D building models
+type PolyhedralSurfaceOf[P PointLike] struct {
+	Polygons []PolygonOf[P]
+}
+
+// PolyhedralSurfaceSOf is a PolyhedralSurface with an SRID
+type PolyhedralSurfaceSOf[P PointLike] struct {
+	SRID     int32
+	Polygons []PolygonOf[P]
+}
+
+// Varying types of PolyhedralSurfaces
+type (
+	PolyhedralSurface   = PolyhedralSurfaceOf[Point]
+	PolyhedralSurfaceZ  = PolyhedralSurfaceOf[PointZ]
+	PolyhedralSurfaceM  = PolyhedralSurfaceOf[PointM]
+	PolyhedralSurfaceZM = PolyhedralSurfaceOf[PointZM]
+
+	PolyhedralSurfaceS   = PolyhedralSurfaceSOf[Point]
+	PolyhedralSurfaceZS  = PolyhedralSurfaceSOf[PointZ]
+	PolyhedralSurfaceMS  = PolyhedralSurfaceSOf[PointM]
+	PolyhedralSurfaceZMS = PolyhedralSurfaceSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (s *PolyhedralSurfaceSOf[P]) GetSRID() int32     { return s.SRID }
+func (s *PolyhedralSurfaceSOf[P]) SetSRID(srid int32) { s.SRID = srid }
+
+// Implement CollectionGeometry interface for all PolyhedralSurface types
+func (s *PolyhedralSurfaceOf[P]) GetElementCount() uint32  { return getElementCountHelper(s.Polygons) }
+func (s *PolyhedralSurfaceSOf[P]) GetElementCount() uint32 { return getElementCountHelper(s.Polygons) }
+
+func (s *PolyhedralSurfaceOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(s.Polygons, buffer)
+}
+
+func (s *PolyhedralSurfaceSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(s.Polygons, buffer)
+}
+
+func (s *PolyhedralSurfaceOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[PolygonOf[P]](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	s.Polygons = geometries
+	return nil
+}
+
+func (s *PolyhedralSurfaceSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[PolygonOf[P]](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	s.Polygons = geometries
+	return nil
+}
+
+/** PolyhedralSurfaceOf functions **/
+func (s *PolyhedralSurfaceOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(s, value)
+}
+
+func (s PolyhedralSurfaceOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&s)
+}
+
+func (s PolyhedralSurfaceOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, s.GetElementCount(), func(buf *bytes.Buffer) error {
+		return s.WriteElements(buf)
+	})
+}
+
+func (s PolyhedralSurfaceOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBPolyhedralSurface, coordinateType[P](), false)
+}
+
+/** PolyhedralSurfaceSOf functions **/
+func (s *PolyhedralSurfaceSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(s, value)
+}
+
+func (s PolyhedralSurfaceSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&s)
+}
+
+func (s PolyhedralSurfaceSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, s.GetElementCount(), func(buf *bytes.Buffer) error {
+		return s.WriteElements(buf)
+	})
+}
+
+func (s PolyhedralSurfaceSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBPolyhedralSurface, coordinateType[P](), true)
+}
@@ -0,0 +1,130 @@
+package postgis
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// unitCube returns the six faces of the unit cube, oriented outwards
+func unitCube() *PolyhedralSurfaceZ {
+	face := func(points ...PointZ) PolygonZ {
+		return PolygonZ{Rings: [][]PointZ{append(points, points[0])}}
+	}
+	return &PolyhedralSurfaceZ{Polygons: []PolygonZ{
+		face(PointZ{0, 0, 0}, PointZ{0, 1, 0}, PointZ{1, 1, 0}, PointZ{1, 0, 0}),
+		face(PointZ{0, 0, 1}, PointZ{1, 0, 1}, PointZ{1, 1, 1}, PointZ{0, 1, 1}),
+		face(PointZ{0, 0, 0}, PointZ{1, 0, 0}, PointZ{1, 0, 1}, PointZ{0, 0, 1}),
+		face(PointZ{0, 1, 0}, PointZ{0, 1, 1}, PointZ{1, 1, 1}, PointZ{1, 1, 0}),
+		face(PointZ{0, 0, 0}, PointZ{0, 0, 1}, PointZ{0, 1, 1}, PointZ{0, 1, 0}),
+		face(PointZ{1, 0, 0}, PointZ{1, 1, 0}, PointZ{1, 1, 1}, PointZ{1, 0, 1}),
+	}}
+}
+
+func TestPolyhedralSurfaceScan(t *testing.T) {
+	// POLYHEDRALSURFACE Z (((0 0 0,0 1 0,1 1 0,0 0 0))) with SRID 4326, as
+	// returned by PostGIS
+	var s PolyhedralSurfaceZS
+	if err := s.Scan("010F0000A0E610000001000000010300008001000000040000000000000000000000000000000000000000000000000000000000000000000000000000000000F03F0000000000000000000000000000F03F000000000000F03F0000000000000000000000000000000000000000000000000000000000000000"); err != nil {
+		t.Fatalf("Failed to scan: %v", err)
+	}
+	expected := PolyhedralSurfaceZS{SRID: 4326, Polygons: []PolygonZ{{Rings: [][]PointZ{{{0, 0, 0}, {0, 1, 0}, {1, 1, 0}, {0, 0, 0}}}}}}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("Expected %v, got %v", expected, s)
+	}
+}
+
+func TestTriangleRings(t *testing.T) {
+	// A Triangle is a polygon with at most one ring
+	polygon := &Polygon{Rings: [][]Point{
+		{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}},
+		{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}},
+	}}
+	data := mustWriteEWKB(t, polygon)
+	data[1] = byte(WKBTriangle)
+	var triangle Triangle
+	if err := ReadEWKBBytes(data, &triangle); !errors.Is(err, ErrCorruptEWKB) {
+		t.Errorf("Expected ErrCorruptEWKB, got %v", err)
+	}
+
+	// Other members than triangles are refused by TINs
+	var tin TIN
+	data = append(hostileEWKB(tin.GetType(), 1), mustWriteEWKB(t, &Polygon{})...)
+	if err := ReadEWKBBytes(data, &tin); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+}
+
+func TestSurfaceArea(t *testing.T) {
+	tests := []struct {
+		name     string
+		area     float64
+		expected float64
+	}{
+		{"cube", unitCube().Area(), 6},
+		{"empty surface", PolyhedralSurfaceS{}.Area(), 0},
+		{"planar surface with hole", PolyhedralSurface{Polygons: []Polygon{{Rings: [][]Point{
+			{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 0, Y: 0}},
+			{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 1}, {X: 1, Y: 1}},
+		}}}}.Area(), 15},
+		{"triangle", Triangle{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 4, Y: 0}, {X: 0, Y: 0}}}.Area(), 6},
+		{"vertical triangle", TriangleZS{Points: []PointZ{{0, 0, 0}, {0, 2, 0}, {0, 0, 2}, {0, 0, 0}}}.Area(), 2},
+		{"M is ignored", TriangleM{Points: []PointM{{0, 0, 5}, {0, 1, 9}, {1, 0, 1}, {0, 0, 5}}}.Area(), 0.5},
+		{"tin", TINZ{Triangles: []TriangleZ{
+			{Points: []PointZ{{0, 0, 0}, {1, 0, 0}, {0, 0, 1}, {0, 0, 0}}},
+			{Points: []PointZ{{0, 0, 0}, {1, 1, 1}, {2, 2, 0}, {0, 0, 0}}},
+			{},
+		}}.Area(), 0.5 + math.Sqrt2},
+	}
+	for _, test := range tests {
+		if math.Abs(test.area-test.expected) > 1e-12 {
+			t.Errorf("%s: expected area %v, got %v", test.name, test.expected, test.area)
+		}
+	}
+}
+
+func TestSurfaceValidate(t *testing.T) {
+	if detail := ValidateDetail(unitCube()); !detail.Valid {
+		t.Errorf("Expected the cube to be valid, got %v", detail)
+	}
+	for _, g := range []Geometry{
+		&Triangle{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}},
+		&TIN{Triangles: []Triangle{{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}}}}},
+	} {
+		if detail := ValidateDetail(g); detail.Valid || detail.Reason != ReasonTooFewPoints {
+			t.Errorf("Expected too few points for %v, got %v", g, detail)
+		}
+	}
+	open := &TriangleZ{Points: []PointZ{{0, 0, 0}, {0, 1, 0}, {1, 0, 0}, {1, 1, 0}}}
+	if detail := ValidateDetail(open); detail.Reason != ReasonRingNotClosed {
+		t.Errorf("Expected ring not closed, got %v", detail)
+	}
+}
+
+func TestSurfaceEnvelope(t *testing.T) {
+	e := EnvelopeOf(unitCube())
+	if e.MinX != 0 || e.MinY != 0 || e.MaxX != 1 || e.MaxY != 1 {
+		t.Errorf("Unexpected envelope %v", e)
+	}
+}
+
+func TestSurfaceConvert(t *testing.T) {
+	tin := &TINZS{SRID: 4326, Triangles: []TriangleZ{{Points: []PointZ{{0, 0, 1}, {0, 1, 2}, {1, 0, 3}, {0, 0, 1}}}}}
+	result, err := ForceXY(tin)
+	if err != nil {
+		t.Fatalf("ForceXY failed: %v", err)
+	}
+	expected := &TINS{SRID: 4326, Triangles: []Triangle{{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	result, err = StripSRID(&TriangleMS{SRID: 4326, Points: []PointM{{0, 0, 1}, {0, 1, 2}, {1, 0, 3}, {0, 0, 1}}})
+	if err != nil {
+		t.Fatalf("StripSRID failed: %v", err)
+	}
+	if _, ok := result.(*TriangleM); !ok {
+		t.Errorf("Expected *TriangleM, got %T", result)
+	}
+}
@@ -0,0 +1,263 @@
+// Package postgispb defines Protocol Buffers messages for geometries, in
+// postgis/v1/geometry.proto, and converts them to and from the geometries
+// of go-postgis. Services embed a postgis.v1.Geometry field, with this
+// directory on the import path of protoc:
+//
+//	import "postgis/v1/geometry.proto";
+//
+//	message Shop {
+//	  string name = 1;
+//	  postgis.v1.Geometry location = 2;
+//	}
+//
+// The messages follow the structure of EWKB: every type, dimension, SRID
+// and nested collection converts without loss. It is a module of its own so
+// that the postgis package does not depend on Protocol Buffers.
+package postgispb
+
+// Regenerate geometry.pb.go with protoc v29.3 and protoc-gen-go v1.36.6, the
+// version of google.golang.org/protobuf in the go.mod of this module, which
+// go generate builds:
+//
+//go:generate go build -o protoc-gen-go google.golang.org/protobuf/cmd/protoc-gen-go
+//go:generate protoc --plugin=protoc-gen-go=./protoc-gen-go --go_out=. --go_opt=module=github.com/cridenour/go-postgis/postgispb postgis/v1/geometry.proto
+//go:generate rm protoc-gen-go
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	postgis "github.com/cridenour/go-postgis"
+)
+
+// ErrInvalidMessage is returned by FromProto for messages that do not
+// describe a geometry
+var ErrInvalidMessage = errors.New("invalid geometry message")
+
+// ToProto converts g to a message, with the SRID of g when its type has one
+func ToProto(g postgis.Geometry) (*Geometry, error) {
+	data, err := postgis.AppendEWKB(nil, g)
+	if err != nil {
+		return nil, err
+	}
+	r := ewkbReader{data: data}
+	return r.geometry()
+}
+
+// FromProto converts a message to the geometry of the matching type, such
+// as a *postgis.LineStringZS for a LineString with Z dimension and an SRID.
+// It returns an error wrapping ErrInvalidMessage for messages whose fields
+// do not fit their type, such as the members of a MultiPoint that are not
+// points.
+func FromProto(msg *Geometry) (postgis.Geometry, error) {
+	data, err := appendGeometry(nil, msg)
+	if err != nil {
+		return nil, err
+	}
+	g, err := postgis.NewGeometry(binary.LittleEndian.Uint32(data[1:]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+	if err := postgis.ReadEWKBBytes(data, g); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMessage, err)
+	}
+	return g, nil
+}
+
+// ewkbReader walks the EWKB written by postgis.AppendEWKB
+type ewkbReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func (r *ewkbReader) next(n int) ([]byte, error) {
+	if len(r.data) < n {
+		return nil, fmt.Errorf("%w: truncated EWKB", postgis.ErrCorruptEWKB)
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b, nil
+}
+
+func (r *ewkbReader) uint32() (uint32, error) {
+	b, err := r.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return r.order.Uint32(b), nil
+}
+
+// floats appends n values to dst
+func (r *ewkbReader) floats(dst []float64, n int) ([]float64, error) {
+	b, err := r.next(8 * n)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		dst = append(dst, math.Float64frombits(r.order.Uint64(b[8*i:])))
+	}
+	return dst, nil
+}
+
+func (r *ewkbReader) geometry() (*Geometry, error) {
+	order, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	r.order = binary.LittleEndian
+	if order[0] == 0 {
+		r.order = binary.BigEndian
+	}
+	wkbType, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	info := postgis.GetGeometryInfo(wkbType)
+	msg := &Geometry{Type: GeometryType(info.BaseType), Dimensions: Dimensions(info.CoordType) + Dimensions_DIMENSIONS_XY}
+	if info.HasSRID {
+		value, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		srid := int32(value)
+		msg.Srid = &srid
+	}
+
+	stride := info.CoordType.Stride()
+	switch info.BaseType {
+	case postgis.WKBPoint:
+		values, err := r.floats(nil, stride)
+		if err != nil {
+			return nil, err
+		}
+		// An empty Point is written with NaN coordinates
+		for _, v := range values {
+			if !math.IsNaN(v) {
+				msg.Coordinates = values
+				break
+			}
+		}
+	case postgis.WKBLineString, postgis.WKBCircularString:
+		count, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		if msg.Coordinates, err = r.floats(nil, int(count)*stride); err != nil {
+			return nil, err
+		}
+	case postgis.WKBPolygon, postgis.WKBTriangle:
+		rings, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < rings; i++ {
+			count, err := r.uint32()
+			if err != nil {
+				return nil, err
+			}
+			if msg.Coordinates, err = r.floats(msg.Coordinates, int(count)*stride); err != nil {
+				return nil, err
+			}
+			msg.RingSizes = append(msg.RingSizes, count)
+		}
+	default:
+		count, err := r.uint32()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < count; i++ {
+			member, err := r.geometry()
+			if err != nil {
+				return nil, err
+			}
+			msg.Members = append(msg.Members, member)
+		}
+	}
+	return msg, nil
+}
+
+// appendGeometry writes msg as little endian EWKB, checking that its fields
+// fit its type
+func appendGeometry(dst []byte, msg *Geometry) ([]byte, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("%w: nil geometry", ErrInvalidMessage)
+	}
+	if _, ok := GeometryType_name[int32(msg.Type)]; !ok || msg.Type == GeometryType_GEOMETRY_TYPE_UNSPECIFIED {
+		return nil, fmt.Errorf("%w: type %v", ErrInvalidMessage, msg.Type)
+	}
+	if _, ok := Dimensions_name[int32(msg.Dimensions)]; !ok || msg.Dimensions == Dimensions_DIMENSIONS_UNSPECIFIED {
+		return nil, fmt.Errorf("%w: dimensions %v", ErrInvalidMessage, msg.Dimensions)
+	}
+	baseType, coordType := uint32(msg.Type), postgis.CoordinateType(msg.Dimensions-Dimensions_DIMENSIONS_XY)
+	stride := coordType.Stride()
+	dst = append(dst, 1)
+	dst = binary.LittleEndian.AppendUint32(dst, postgis.BuildWKBType(baseType, coordType, msg.Srid != nil))
+	if msg.Srid != nil {
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(*msg.Srid))
+	}
+
+	pointCount := len(msg.Coordinates) / stride
+	if len(msg.Coordinates)%stride != 0 {
+		return nil, fmt.Errorf("%w: %d coordinates for %v, not a multiple of %d", ErrInvalidMessage, len(msg.Coordinates), msg.Dimensions, stride)
+	}
+	var usesCoordinates, usesRings, usesMembers bool
+	switch baseType {
+	case postgis.WKBPoint:
+		usesCoordinates = true
+		if pointCount > 1 {
+			return nil, fmt.Errorf("%w: %d points for a Point", ErrInvalidMessage, pointCount)
+		}
+		if pointCount == 0 {
+			for i := 0; i < stride; i++ {
+				dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(math.NaN()))
+			}
+		}
+		dst = appendFloats(dst, msg.Coordinates)
+	case postgis.WKBLineString, postgis.WKBCircularString:
+		usesCoordinates = true
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(pointCount))
+		dst = appendFloats(dst, msg.Coordinates)
+	case postgis.WKBPolygon, postgis.WKBTriangle:
+		usesCoordinates, usesRings = true, true
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(len(msg.RingSizes)))
+		coordinates := msg.Coordinates
+		for _, count := range msg.RingSizes {
+			if uint64(count) > uint64(len(coordinates)/stride) {
+				return nil, fmt.Errorf("%w: ring sizes exceed the %d points", ErrInvalidMessage, pointCount)
+			}
+			dst = binary.LittleEndian.AppendUint32(dst, count)
+			dst = appendFloats(dst, coordinates[:int(count)*stride])
+			coordinates = coordinates[int(count)*stride:]
+		}
+		if len(coordinates) > 0 {
+			return nil, fmt.Errorf("%w: ring sizes leave out points", ErrInvalidMessage)
+		}
+	default:
+		usesMembers = true
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(len(msg.Members)))
+		for _, member := range msg.Members {
+			var err error
+			if dst, err = appendGeometry(dst, member); err != nil {
+				return nil, err
+			}
+		}
+	}
+	switch {
+	case !usesCoordinates && len(msg.Coordinates) > 0:
+		return nil, fmt.Errorf("%w: coordinates for %v", ErrInvalidMessage, msg.Type)
+	case !usesRings && len(msg.RingSizes) > 0:
+		return nil, fmt.Errorf("%w: ring sizes for %v", ErrInvalidMessage, msg.Type)
+	case !usesMembers && len(msg.Members) > 0:
+		return nil, fmt.Errorf("%w: members for %v", ErrInvalidMessage, msg.Type)
+	}
+	return dst, nil
+}
+
+func appendFloats(dst []byte, values []float64) []byte {
+	for _, v := range values {
+		dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(v))
+	}
+	return dst
+}
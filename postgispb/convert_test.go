@@ -0,0 +1,118 @@
+package postgispb
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	postgis "github.com/cridenour/go-postgis"
+	"google.golang.org/protobuf/proto"
+)
+
+func sampleGeometries() []postgis.Geometry {
+	ring := [][]postgis.Point{{{X: 0, Y: 1}, {X: 4, Y: 5}, {X: 8, Y: 9}, {X: 0, Y: 1}}, {}}
+	return []postgis.Geometry{
+		&postgis.Point{X: 1, Y: 2},
+		&postgis.PointZMS{SRID: 4326, X: 1, Y: 2, Z: 3, M: 4},
+		&postgis.PointS{SRID: 0, X: math.NaN(), Y: math.NaN()},
+		&postgis.LineStringM{Points: []postgis.PointM{{X: 1, Y: 2, M: 3}, {X: 5, Y: 6, M: 7}}},
+		&postgis.PolygonS{SRID: 4326, Rings: ring},
+		&postgis.MultiPointZ{Points: []postgis.PointZ{{X: 1, Y: 2, Z: 3}, {X: 5, Y: 6, Z: 7}}},
+		&postgis.MultiLineStringS{SRID: 3857, LineStrings: []postgis.LineString{{Points: []postgis.Point{{X: 1, Y: 2}, {X: 5, Y: 6}}}, {}}},
+		&postgis.MultiPolygon{Polygons: []postgis.Polygon{{Rings: ring}}},
+		&postgis.CircularStringZMS{SRID: 4326, Points: []postgis.PointZM{{X: 0, Y: 0, Z: 1, M: 2}, {X: 1, Y: 1, Z: 3, M: 4}, {X: 2, Y: 0, Z: 5, M: 6}}},
+		&postgis.CompoundCurve{Curves: []postgis.Curve[postgis.Point]{
+			&postgis.CircularString{Points: []postgis.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}},
+			&postgis.LineString{Points: []postgis.Point{{X: 2, Y: 0}, {X: 0, Y: 0}}},
+		}},
+		&postgis.CurvePolygon{Rings: []postgis.Curve[postgis.Point]{&postgis.CircularString{Points: []postgis.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0}}}}},
+		&postgis.MultiCurve{Curves: []postgis.Curve[postgis.Point]{&postgis.LineString{Points: []postgis.Point{{X: 1, Y: 2}, {X: 5, Y: 6}}}, &postgis.CircularString{}}},
+		&postgis.MultiSurface{Surfaces: []postgis.Surface[postgis.Point]{&postgis.Polygon{Rings: ring}, &postgis.CurvePolygon{}}},
+		&postgis.PolyhedralSurfaceZ{Polygons: []postgis.PolygonZ{{Rings: [][]postgis.PointZ{{{X: 0, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 1, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 0}}}}}},
+		&postgis.TINMS{SRID: 4326, Triangles: []postgis.TriangleM{{}}},
+		&postgis.Triangle{Points: []postgis.Point{{X: 0, Y: 0}, {X: 0, Y: 9}, {X: 9, Y: 0}, {X: 0, Y: 0}}},
+		&postgis.GeometryCollectionS{SRID: 4326, Geometries: []postgis.Geometry{
+			&postgis.PointS{SRID: 4326, X: 1, Y: 2},
+			&postgis.GeometryCollection{Geometries: []postgis.Geometry{&postgis.Point{X: 3, Y: 4}}},
+		}},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, g := range sampleGeometries() {
+		msg, err := ToProto(g)
+		if err != nil {
+			t.Fatalf("ToProto(%T) failed: %v", g, err)
+		}
+		wire, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatalf("Marshal(%T) failed: %v", g, err)
+		}
+		var decoded Geometry
+		if err := proto.Unmarshal(wire, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%T) failed: %v", g, err)
+		}
+		back, err := FromProto(&decoded)
+		if err != nil {
+			t.Fatalf("FromProto(%T) failed: %v", g, err)
+		}
+		expected, _ := postgis.AppendEWKB(nil, g)
+		if got, _ := postgis.AppendEWKB(nil, back); !bytes.Equal(got, expected) {
+			t.Errorf("Expected %x for %T, got %x (%T)", expected, g, got, back)
+		}
+	}
+}
+
+func TestToProto(t *testing.T) {
+	msg, err := ToProto(&postgis.PolygonZS{SRID: 4326, Rings: [][]postgis.PointZ{{{X: 0, Y: 0, Z: 1}, {X: 1, Y: 0, Z: 1}, {X: 0, Y: 1, Z: 1}, {X: 0, Y: 0, Z: 1}}}})
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+	expected := &Geometry{
+		Type:        GeometryType_GEOMETRY_TYPE_POLYGON,
+		Dimensions:  Dimensions_DIMENSIONS_XYZ,
+		Srid:        proto.Int32(4326),
+		Coordinates: []float64{0, 0, 1, 1, 0, 1, 0, 1, 1, 0, 0, 1},
+		RingSizes:   []uint32{4},
+	}
+	if !proto.Equal(msg, expected) {
+		t.Errorf("Expected %v, got %v", expected, msg)
+	}
+
+	// An empty point has no coordinates
+	msg, err = ToProto(&postgis.PointM{X: math.NaN(), Y: math.NaN(), M: math.NaN()})
+	if err != nil || len(msg.Coordinates) != 0 || msg.Dimensions != Dimensions_DIMENSIONS_XYM {
+		t.Errorf("Expected an empty XYM point, got %v (%v)", msg, err)
+	}
+}
+
+func TestFromProtoInvalid(t *testing.T) {
+	point := &Geometry{Type: GeometryType_GEOMETRY_TYPE_POINT, Dimensions: Dimensions_DIMENSIONS_XY, Coordinates: []float64{1, 2}}
+	for _, msg := range []*Geometry{
+		nil,
+		{},
+		{Type: 13},
+		{Type: GeometryType_GEOMETRY_TYPE_POINT, Dimensions: 5},
+		{Type: GeometryType_GEOMETRY_TYPE_POINT, Coordinates: []float64{1, 2}},
+		{Type: GeometryType_GEOMETRY_TYPE_POINT, Dimensions: Dimensions_DIMENSIONS_XY, Coordinates: []float64{1, 2, 3}},
+		{Type: GeometryType_GEOMETRY_TYPE_POINT, Dimensions: Dimensions_DIMENSIONS_XY, Coordinates: []float64{1, 2, 3, 4}},
+		{Type: GeometryType_GEOMETRY_TYPE_LINE_STRING, Dimensions: Dimensions_DIMENSIONS_XY, Coordinates: []float64{1, 2}, Members: []*Geometry{point}},
+		{Type: GeometryType_GEOMETRY_TYPE_POLYGON, Dimensions: Dimensions_DIMENSIONS_XY, Coordinates: []float64{1, 2, 3, 4}, RingSizes: []uint32{3}},
+		{Type: GeometryType_GEOMETRY_TYPE_POLYGON, Dimensions: Dimensions_DIMENSIONS_XY, Coordinates: []float64{1, 2, 3, 4}, RingSizes: []uint32{1}},
+		{Type: GeometryType_GEOMETRY_TYPE_MULTI_POINT, Dimensions: Dimensions_DIMENSIONS_XY, Coordinates: []float64{1, 2}},
+		{Type: GeometryType_GEOMETRY_TYPE_MULTI_POINT, Dimensions: Dimensions_DIMENSIONS_XY, Members: []*Geometry{{Type: GeometryType_GEOMETRY_TYPE_LINE_STRING}}},
+		{Type: GeometryType_GEOMETRY_TYPE_MULTI_POINT, Dimensions: Dimensions_DIMENSIONS_XY, Members: []*Geometry{nil}},
+	} {
+		if g, err := FromProto(msg); !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("Expected ErrInvalidMessage for %v, got %v (%v)", msg, g, err)
+		}
+	}
+}
+
+func TestDescriptorPath(t *testing.T) {
+	// A bare geometry.proto would conflict with other files of that name
+	if path := File_postgis_v1_geometry_proto.Path(); path != "postgis/v1/geometry.proto" {
+		t.Errorf("Expected postgis/v1/geometry.proto, got %s", path)
+	}
+}
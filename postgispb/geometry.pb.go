@@ -0,0 +1,362 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: postgis/v1/geometry.proto
+
+package postgispb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GeometryType is the type of a geometry, numbered as its WKB type code
+type GeometryType int32
+
+const (
+	GeometryType_GEOMETRY_TYPE_UNSPECIFIED         GeometryType = 0
+	GeometryType_GEOMETRY_TYPE_POINT               GeometryType = 1
+	GeometryType_GEOMETRY_TYPE_LINE_STRING         GeometryType = 2
+	GeometryType_GEOMETRY_TYPE_POLYGON             GeometryType = 3
+	GeometryType_GEOMETRY_TYPE_MULTI_POINT         GeometryType = 4
+	GeometryType_GEOMETRY_TYPE_MULTI_LINE_STRING   GeometryType = 5
+	GeometryType_GEOMETRY_TYPE_MULTI_POLYGON       GeometryType = 6
+	GeometryType_GEOMETRY_TYPE_GEOMETRY_COLLECTION GeometryType = 7
+	GeometryType_GEOMETRY_TYPE_CIRCULAR_STRING     GeometryType = 8
+	GeometryType_GEOMETRY_TYPE_COMPOUND_CURVE      GeometryType = 9
+	GeometryType_GEOMETRY_TYPE_CURVE_POLYGON       GeometryType = 10
+	GeometryType_GEOMETRY_TYPE_MULTI_CURVE         GeometryType = 11
+	GeometryType_GEOMETRY_TYPE_MULTI_SURFACE       GeometryType = 12
+	GeometryType_GEOMETRY_TYPE_POLYHEDRAL_SURFACE  GeometryType = 15
+	GeometryType_GEOMETRY_TYPE_TIN                 GeometryType = 16
+	GeometryType_GEOMETRY_TYPE_TRIANGLE            GeometryType = 17
+)
+
+// Enum value maps for GeometryType.
+var (
+	GeometryType_name = map[int32]string{
+		0:  "GEOMETRY_TYPE_UNSPECIFIED",
+		1:  "GEOMETRY_TYPE_POINT",
+		2:  "GEOMETRY_TYPE_LINE_STRING",
+		3:  "GEOMETRY_TYPE_POLYGON",
+		4:  "GEOMETRY_TYPE_MULTI_POINT",
+		5:  "GEOMETRY_TYPE_MULTI_LINE_STRING",
+		6:  "GEOMETRY_TYPE_MULTI_POLYGON",
+		7:  "GEOMETRY_TYPE_GEOMETRY_COLLECTION",
+		8:  "GEOMETRY_TYPE_CIRCULAR_STRING",
+		9:  "GEOMETRY_TYPE_COMPOUND_CURVE",
+		10: "GEOMETRY_TYPE_CURVE_POLYGON",
+		11: "GEOMETRY_TYPE_MULTI_CURVE",
+		12: "GEOMETRY_TYPE_MULTI_SURFACE",
+		15: "GEOMETRY_TYPE_POLYHEDRAL_SURFACE",
+		16: "GEOMETRY_TYPE_TIN",
+		17: "GEOMETRY_TYPE_TRIANGLE",
+	}
+	GeometryType_value = map[string]int32{
+		"GEOMETRY_TYPE_UNSPECIFIED":         0,
+		"GEOMETRY_TYPE_POINT":               1,
+		"GEOMETRY_TYPE_LINE_STRING":         2,
+		"GEOMETRY_TYPE_POLYGON":             3,
+		"GEOMETRY_TYPE_MULTI_POINT":         4,
+		"GEOMETRY_TYPE_MULTI_LINE_STRING":   5,
+		"GEOMETRY_TYPE_MULTI_POLYGON":       6,
+		"GEOMETRY_TYPE_GEOMETRY_COLLECTION": 7,
+		"GEOMETRY_TYPE_CIRCULAR_STRING":     8,
+		"GEOMETRY_TYPE_COMPOUND_CURVE":      9,
+		"GEOMETRY_TYPE_CURVE_POLYGON":       10,
+		"GEOMETRY_TYPE_MULTI_CURVE":         11,
+		"GEOMETRY_TYPE_MULTI_SURFACE":       12,
+		"GEOMETRY_TYPE_POLYHEDRAL_SURFACE":  15,
+		"GEOMETRY_TYPE_TIN":                 16,
+		"GEOMETRY_TYPE_TRIANGLE":            17,
+	}
+)
+
+func (x GeometryType) Enum() *GeometryType {
+	p := new(GeometryType)
+	*p = x
+	return p
+}
+
+func (x GeometryType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GeometryType) Descriptor() protoreflect.EnumDescriptor {
+	return file_postgis_v1_geometry_proto_enumTypes[0].Descriptor()
+}
+
+func (GeometryType) Type() protoreflect.EnumType {
+	return &file_postgis_v1_geometry_proto_enumTypes[0]
+}
+
+func (x GeometryType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GeometryType.Descriptor instead.
+func (GeometryType) EnumDescriptor() ([]byte, []int) {
+	return file_postgis_v1_geometry_proto_rawDescGZIP(), []int{0}
+}
+
+// Dimensions are the values of each coordinate. They must be set, even to
+// XY, so that a message missing them is not taken for a 2D geometry.
+type Dimensions int32
+
+const (
+	Dimensions_DIMENSIONS_UNSPECIFIED Dimensions = 0
+	Dimensions_DIMENSIONS_XY          Dimensions = 1
+	Dimensions_DIMENSIONS_XYZ         Dimensions = 2
+	Dimensions_DIMENSIONS_XYM         Dimensions = 3
+	Dimensions_DIMENSIONS_XYZM        Dimensions = 4
+)
+
+// Enum value maps for Dimensions.
+var (
+	Dimensions_name = map[int32]string{
+		0: "DIMENSIONS_UNSPECIFIED",
+		1: "DIMENSIONS_XY",
+		2: "DIMENSIONS_XYZ",
+		3: "DIMENSIONS_XYM",
+		4: "DIMENSIONS_XYZM",
+	}
+	Dimensions_value = map[string]int32{
+		"DIMENSIONS_UNSPECIFIED": 0,
+		"DIMENSIONS_XY":          1,
+		"DIMENSIONS_XYZ":         2,
+		"DIMENSIONS_XYM":         3,
+		"DIMENSIONS_XYZM":        4,
+	}
+)
+
+func (x Dimensions) Enum() *Dimensions {
+	p := new(Dimensions)
+	*p = x
+	return p
+}
+
+func (x Dimensions) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Dimensions) Descriptor() protoreflect.EnumDescriptor {
+	return file_postgis_v1_geometry_proto_enumTypes[1].Descriptor()
+}
+
+func (Dimensions) Type() protoreflect.EnumType {
+	return &file_postgis_v1_geometry_proto_enumTypes[1]
+}
+
+func (x Dimensions) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Dimensions.Descriptor instead.
+func (Dimensions) EnumDescriptor() ([]byte, []int) {
+	return file_postgis_v1_geometry_proto_rawDescGZIP(), []int{1}
+}
+
+// Geometry is a geometry of any type, following the structure of its EWKB
+// encoding so that conversions are lossless.
+type Geometry struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Type       GeometryType           `protobuf:"varint,1,opt,name=type,proto3,enum=postgis.v1.GeometryType" json:"type,omitempty"`
+	Dimensions Dimensions             `protobuf:"varint,2,opt,name=dimensions,proto3,enum=postgis.v1.Dimensions" json:"dimensions,omitempty"`
+	// srid is set for geometries with an SRID, even 0
+	Srid *int32 `protobuf:"varint,3,opt,name=srid,proto3,oneof" json:"srid,omitempty"`
+	// coordinates holds the values of the points of a Point, LineString,
+	// CircularString, Polygon or Triangle, 2, 3 or 4 per point following
+	// dimensions: X, Y, then Z and M. An empty Point has none.
+	Coordinates []float64 `protobuf:"fixed64,4,rep,packed,name=coordinates,proto3" json:"coordinates,omitempty"`
+	// ring_sizes holds the number of points of each ring of a Polygon or
+	// Triangle, whose points follow each other in coordinates
+	RingSizes []uint32 `protobuf:"varint,5,rep,packed,name=ring_sizes,json=ringSizes,proto3" json:"ring_sizes,omitempty"`
+	// members holds the geometries of the other types: the points of a
+	// MultiPoint, the polygons of a MultiPolygon or PolyhedralSurface, the
+	// curves of a CompoundCurve, the rings of a CurvePolygon and so on
+	Members       []*Geometry `protobuf:"bytes,6,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Geometry) Reset() {
+	*x = Geometry{}
+	mi := &file_postgis_v1_geometry_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Geometry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Geometry) ProtoMessage() {}
+
+func (x *Geometry) ProtoReflect() protoreflect.Message {
+	mi := &file_postgis_v1_geometry_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Geometry.ProtoReflect.Descriptor instead.
+func (*Geometry) Descriptor() ([]byte, []int) {
+	return file_postgis_v1_geometry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Geometry) GetType() GeometryType {
+	if x != nil {
+		return x.Type
+	}
+	return GeometryType_GEOMETRY_TYPE_UNSPECIFIED
+}
+
+func (x *Geometry) GetDimensions() Dimensions {
+	if x != nil {
+		return x.Dimensions
+	}
+	return Dimensions_DIMENSIONS_UNSPECIFIED
+}
+
+func (x *Geometry) GetSrid() int32 {
+	if x != nil && x.Srid != nil {
+		return *x.Srid
+	}
+	return 0
+}
+
+func (x *Geometry) GetCoordinates() []float64 {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+func (x *Geometry) GetRingSizes() []uint32 {
+	if x != nil {
+		return x.RingSizes
+	}
+	return nil
+}
+
+func (x *Geometry) GetMembers() []*Geometry {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+var File_postgis_v1_geometry_proto protoreflect.FileDescriptor
+
+const file_postgis_v1_geometry_proto_rawDesc = "" +
+	"\n" +
+	"\x19postgis/v1/geometry.proto\x12\n" +
+	"postgis.v1\"\x83\x02\n" +
+	"\bGeometry\x12,\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x18.postgis.v1.GeometryTypeR\x04type\x126\n" +
+	"\n" +
+	"dimensions\x18\x02 \x01(\x0e2\x16.postgis.v1.DimensionsR\n" +
+	"dimensions\x12\x17\n" +
+	"\x04srid\x18\x03 \x01(\x05H\x00R\x04srid\x88\x01\x01\x12 \n" +
+	"\vcoordinates\x18\x04 \x03(\x01R\vcoordinates\x12\x1d\n" +
+	"\n" +
+	"ring_sizes\x18\x05 \x03(\rR\tringSizes\x12.\n" +
+	"\amembers\x18\x06 \x03(\v2\x14.postgis.v1.GeometryR\amembersB\a\n" +
+	"\x05_srid*\x8b\x04\n" +
+	"\fGeometryType\x12\x1d\n" +
+	"\x19GEOMETRY_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13GEOMETRY_TYPE_POINT\x10\x01\x12\x1d\n" +
+	"\x19GEOMETRY_TYPE_LINE_STRING\x10\x02\x12\x19\n" +
+	"\x15GEOMETRY_TYPE_POLYGON\x10\x03\x12\x1d\n" +
+	"\x19GEOMETRY_TYPE_MULTI_POINT\x10\x04\x12#\n" +
+	"\x1fGEOMETRY_TYPE_MULTI_LINE_STRING\x10\x05\x12\x1f\n" +
+	"\x1bGEOMETRY_TYPE_MULTI_POLYGON\x10\x06\x12%\n" +
+	"!GEOMETRY_TYPE_GEOMETRY_COLLECTION\x10\a\x12!\n" +
+	"\x1dGEOMETRY_TYPE_CIRCULAR_STRING\x10\b\x12 \n" +
+	"\x1cGEOMETRY_TYPE_COMPOUND_CURVE\x10\t\x12\x1f\n" +
+	"\x1bGEOMETRY_TYPE_CURVE_POLYGON\x10\n" +
+	"\x12\x1d\n" +
+	"\x19GEOMETRY_TYPE_MULTI_CURVE\x10\v\x12\x1f\n" +
+	"\x1bGEOMETRY_TYPE_MULTI_SURFACE\x10\f\x12$\n" +
+	" GEOMETRY_TYPE_POLYHEDRAL_SURFACE\x10\x0f\x12\x15\n" +
+	"\x11GEOMETRY_TYPE_TIN\x10\x10\x12\x1a\n" +
+	"\x16GEOMETRY_TYPE_TRIANGLE\x10\x11*x\n" +
+	"\n" +
+	"Dimensions\x12\x1a\n" +
+	"\x16DIMENSIONS_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rDIMENSIONS_XY\x10\x01\x12\x12\n" +
+	"\x0eDIMENSIONS_XYZ\x10\x02\x12\x12\n" +
+	"\x0eDIMENSIONS_XYM\x10\x03\x12\x13\n" +
+	"\x0fDIMENSIONS_XYZM\x10\x04B+Z)github.com/cridenour/go-postgis/postgispbb\x06proto3"
+
+var (
+	file_postgis_v1_geometry_proto_rawDescOnce sync.Once
+	file_postgis_v1_geometry_proto_rawDescData []byte
+)
+
+func file_postgis_v1_geometry_proto_rawDescGZIP() []byte {
+	file_postgis_v1_geometry_proto_rawDescOnce.Do(func() {
+		file_postgis_v1_geometry_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_postgis_v1_geometry_proto_rawDesc), len(file_postgis_v1_geometry_proto_rawDesc)))
+	})
+	return file_postgis_v1_geometry_proto_rawDescData
+}
+
+var file_postgis_v1_geometry_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_postgis_v1_geometry_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_postgis_v1_geometry_proto_goTypes = []any{
+	(GeometryType)(0), // 0: postgis.v1.GeometryType
+	(Dimensions)(0),   // 1: postgis.v1.Dimensions
+	(*Geometry)(nil),  // 2: postgis.v1.Geometry
+}
+var file_postgis_v1_geometry_proto_depIdxs = []int32{
+	0, // 0: postgis.v1.Geometry.type:type_name -> postgis.v1.GeometryType
+	1, // 1: postgis.v1.Geometry.dimensions:type_name -> postgis.v1.Dimensions
+	2, // 2: postgis.v1.Geometry.members:type_name -> postgis.v1.Geometry
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_postgis_v1_geometry_proto_init() }
+func file_postgis_v1_geometry_proto_init() {
+	if File_postgis_v1_geometry_proto != nil {
+		return
+	}
+	file_postgis_v1_geometry_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_postgis_v1_geometry_proto_rawDesc), len(file_postgis_v1_geometry_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_postgis_v1_geometry_proto_goTypes,
+		DependencyIndexes: file_postgis_v1_geometry_proto_depIdxs,
+		EnumInfos:         file_postgis_v1_geometry_proto_enumTypes,
+		MessageInfos:      file_postgis_v1_geometry_proto_msgTypes,
+	}.Build()
+	File_postgis_v1_geometry_proto = out.File
+	file_postgis_v1_geometry_proto_goTypes = nil
+	file_postgis_v1_geometry_proto_depIdxs = nil
+}
@@ -0,0 +1,10 @@
+module github.com/cridenour/go-postgis/postgispb
+
+go 1.22
+
+require (
+	github.com/cridenour/go-postgis v0.0.0
+	google.golang.org/protobuf v1.36.6
+)
+
+replace github.com/cridenour/go-postgis => ..
@@ -0,0 +1,240 @@
+package postgis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidQuery is returned by Build for expressions made from a malformed
+// Format
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Expr is a fragment of SQL and the values of its parameters, such as a
+// spatial predicate for a WHERE clause. Exprs are combined by the functions
+// below, which number the parameters only when Build turns them into a
+// query for lib/pq or pgx:
+//
+//	shop := postgis.Column("shops", "location")
+//	here := postgis.Param(&postgis.PointS{SRID: 4326, X: -71.06, Y: 42.28})
+//	query, args, err := postgis.Format("SELECT id FROM shops WHERE %s ORDER BY %s LIMIT 10",
+//		postgis.DWithin(shop, here, 500), postgis.KNN(shop, here)).Build()
+//	rows, err := db.Query(query, args...)
+//
+// builds SELECT id FROM shops WHERE ST_DWithin("shops"."location", $1, $2)
+// ORDER BY "shops"."location" <-> $3 LIMIT 10.
+//
+// The functions apply to both geometry and geography columns, with the
+// meaning PostGIS gives them for each type, noted below. A geometry passed
+// as a parameter takes the type of the column it is compared to; it must
+// have the SRID of the column, as PostGIS refuses to mix SRIDs. When no
+// operand has a type, as with two parameters, use AsGeometry or
+// AsGeography.
+type Expr struct {
+	// parts holds the SQL around the parameters, one more than args
+	parts []string
+	args  []any
+	err   error
+}
+
+// Build returns the SQL of e, with its parameters written $1, $2 and so
+// on, and their values
+func (e Expr) Build() (string, []any, error) {
+	return e.BuildFrom(1)
+}
+
+// BuildFrom is like Build, numbering the parameters from first, to append
+// e to a query that already has first-1 parameters
+func (e Expr) BuildFrom(first int) (string, []any, error) {
+	if e.err != nil {
+		return "", nil, e.err
+	}
+	var sql strings.Builder
+	for i, part := range e.parts {
+		if i > 0 {
+			sql.WriteByte('$')
+			sql.WriteString(strconv.Itoa(first + i - 1))
+		}
+		sql.WriteString(part)
+	}
+	return sql.String(), append([]any(nil), e.args...), nil
+}
+
+// Parts returns the SQL of e around its parameters, one more string than
+// values, or the error of a malformed expression. It serves query builders
+// that write parameters themselves, such as the gormpostgis package.
+func (e Expr) Parts() ([]string, []any, error) {
+	if e.err != nil {
+		return nil, nil, e.err
+	}
+	return append([]string(nil), e.parts...), append([]any(nil), e.args...), nil
+}
+
+// String returns the SQL of e as Build does, or the error of a malformed
+// expression
+func (e Expr) String() string {
+	sql, _, err := e.Build()
+	if err != nil {
+		return err.Error()
+	}
+	return sql
+}
+
+// rawSQL returns an expression of trusted SQL text
+func rawSQL(sql string) Expr {
+	return Expr{parts: []string{sql}}
+}
+
+// concat joins expressions, merging the text around them
+func concat(exprs ...Expr) Expr {
+	result := Expr{parts: []string{""}}
+	for _, e := range exprs {
+		if e.err != nil && result.err == nil {
+			result.err = e.err
+		}
+		if len(e.parts) == 0 {
+			continue
+		}
+		result.parts[len(result.parts)-1] += e.parts[0]
+		result.parts = append(result.parts, e.parts[1:]...)
+		result.args = append(result.args, e.args...)
+	}
+	return result
+}
+
+// join joins expressions separated by sep
+func join(sep string, exprs []Expr) Expr {
+	joined := make([]Expr, 0, 2*len(exprs))
+	for i, e := range exprs {
+		if i > 0 {
+			joined = append(joined, rawSQL(sep))
+		}
+		joined = append(joined, e)
+	}
+	return concat(joined...)
+}
+
+// call returns a call of the SQL function name
+func call(name string, args ...Expr) Expr {
+	return concat(rawSQL(name+"("), join(", ", args), rawSQL(")"))
+}
+
+// Param returns a parameter of value v, such as a geometry of this package,
+// which is sent as its Value
+func Param(v any) Expr {
+	return Expr{parts: []string{"", ""}, args: []any{v}}
+}
+
+// Column returns a column, or any qualified name, as a quoted identifier:
+// Column("shops", "location") is "shops"."location". Quoted names are case
+// sensitive, so they must be given as they are stored, usually lower case.
+func Column(names ...string) Expr {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+	return rawSQL(strings.Join(quoted, "."))
+}
+
+// Format returns an expression of SQL text written by the program, in
+// which each %s is replaced by the next of exprs and %% is a percent sign.
+// The text is not escaped and must not contain values from users; pass
+// them with Param.
+func Format(format string, exprs ...Expr) Expr {
+	parts := make([]Expr, 0, 2*len(exprs)+1)
+	var text strings.Builder
+	next := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			text.WriteByte(format[i])
+			continue
+		}
+		if i++; i < len(format) && format[i] == '%' {
+			text.WriteByte('%')
+			continue
+		}
+		if i >= len(format) || format[i] != 's' {
+			return Expr{err: fmt.Errorf("%w: bad verb at offset %d of %q", ErrInvalidQuery, i-1, format)}
+		}
+		if next >= len(exprs) {
+			return Expr{err: fmt.Errorf("%w: missing expression %d for %q", ErrInvalidQuery, next+1, format)}
+		}
+		parts = append(parts, rawSQL(text.String()), exprs[next])
+		text.Reset()
+		next++
+	}
+	if next != len(exprs) {
+		return Expr{err: fmt.Errorf("%w: %d expressions for %d %%s in %q", ErrInvalidQuery, len(exprs), next, format)}
+	}
+	return concat(append(parts, rawSQL(text.String()))...)
+}
+
+// And returns the conjunction of conditions, TRUE when there are none so
+// that a list of filters built at run time may be empty
+func And(conditions ...Expr) Expr {
+	if len(conditions) == 0 {
+		return rawSQL("TRUE")
+	}
+	return concat(rawSQL("("), join(" AND ", conditions), rawSQL(")"))
+}
+
+// Or returns the disjunction of conditions, FALSE when there are none
+func Or(conditions ...Expr) Expr {
+	if len(conditions) == 0 {
+		return rawSQL("FALSE")
+	}
+	return concat(rawSQL("("), join(" OR ", conditions), rawSQL(")"))
+}
+
+// AsGeometry casts e to geometry, such as a parameter compared to another
+// parameter or a geography column to use with Transform
+func AsGeometry(e Expr) Expr {
+	return concat(rawSQL("("), e, rawSQL(")::geometry"))
+}
+
+// AsGeography casts e to geography. The geometry must have longitude and
+// latitude coordinates, usually SRID 4326.
+func AsGeography(e Expr) Expr {
+	return concat(rawSQL("("), e, rawSQL(")::geography"))
+}
+
+// Intersects returns ST_Intersects(a, b), true when the geometries share
+// any point. On geography it is computed on the sphere, with a tolerance of
+// 0.00001 meters. It uses a spatial index on either operand.
+func Intersects(a, b Expr) Expr {
+	return call("ST_Intersects", a, b)
+}
+
+// DWithin returns ST_DWithin(a, b, distance), true when the geometries are
+// within distance of each other, with the distance as a parameter. On
+// geometry the distance is in the units of the SRID, degrees for 4326, and
+// on geography in meters on the spheroid. It uses a spatial index on either
+// operand.
+func DWithin(a, b Expr, distance float64) Expr {
+	return call("ST_DWithin", a, b, Param(distance))
+}
+
+// BBoxIntersects returns a && b, true when the bounding boxes of the
+// geometries intersect, which is answered from a spatial index alone. On
+// geography the boxes are geodetic.
+func BBoxIntersects(a, b Expr) Expr {
+	return concat(a, rawSQL(" && "), b)
+}
+
+// Transform returns ST_Transform(e, srid), the geometry reprojected to
+// srid. It applies to geometry only; geography can be cast with AsGeometry
+// first. As its result is not indexed, transform the parameter rather than
+// the column in conditions.
+func Transform(e Expr, srid int32) Expr {
+	return call("ST_Transform", e, rawSQL(strconv.FormatInt(int64(srid), 10)))
+}
+
+// KNN returns a <-> b, the distance between the geometries for ORDER BY,
+// which PostGIS answers from a spatial index as a nearest neighbour search
+// when one operand is an indexed column and the other a constant. On
+// geometry the distance is in the units of the SRID; on geography it is in
+// meters on the sphere.
+func KNN(a, b Expr) Expr {
+	return concat(a, rawSQL(" <-> "), b)
+}
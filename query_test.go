@@ -0,0 +1,68 @@
+package postgis
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestQueryBuild(t *testing.T) {
+	here := &PointS{SRID: 4326, X: -71.06, Y: 42.28}
+	shop := Column("shops", "location")
+	tests := []struct {
+		name string
+		expr Expr
+		sql  string
+		args []any
+	}{
+		{"intersects", Intersects(Column("geom"), Param(here)), `ST_Intersects("geom", $1)`, []any{here}},
+		{"dwithin", DWithin(shop, Param(here), 500), `ST_DWithin("shops"."location", $1, $2)`, []any{here, 500.0}},
+		{"bbox", BBoxIntersects(Column("geom"), Transform(Param(here), 3857)), `"geom" && ST_Transform($1, 3857)`, []any{here}},
+		{"geography", DWithin(AsGeography(Param(here)), AsGeography(Param(here)), 10), `ST_DWithin(($1)::geography, ($2)::geography, $3)`, []any{here, here, 10.0}},
+		{"geometry", Transform(AsGeometry(Column("geog")), 3857), `ST_Transform(("geog")::geometry, 3857)`, nil},
+		{"quoting", Column(`my "geom"`), `"my ""geom"""`, nil},
+		{"and", And(BBoxIntersects(Column("geom"), Param(here)), Or(Intersects(Column("a"), Column("b")))), `("geom" && $1 AND (ST_Intersects("a", "b")))`, []any{here}},
+		{"empty", Format("SELECT id FROM shops WHERE %s AND NOT %s", And(), Or()), `SELECT id FROM shops WHERE TRUE AND NOT FALSE`, nil},
+		{
+			"query",
+			Format("SELECT id FROM shops WHERE kind = %s AND %s ORDER BY %s LIMIT 10 -- 100%%", Param("cafe"), DWithin(shop, Param(here), 500), KNN(shop, Param(here))),
+			`SELECT id FROM shops WHERE kind = $1 AND ST_DWithin("shops"."location", $2, $3) ORDER BY "shops"."location" <-> $4 LIMIT 10 -- 100%`,
+			[]any{"cafe", here, 500.0, here},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := test.expr.Build()
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+			if sql != test.sql {
+				t.Errorf("Expected %s, got %s", test.sql, sql)
+			}
+			if len(args) != len(test.args) || (len(args) > 0 && !reflect.DeepEqual(args, test.args)) {
+				t.Errorf("Expected %v, got %v", test.args, args)
+			}
+		})
+	}
+}
+
+func TestQueryBuildFrom(t *testing.T) {
+	sql, args, err := DWithin(Column("geom"), Param(&Point{X: 1, Y: 2}), 5).BuildFrom(3)
+	if err != nil || sql != `ST_DWithin("geom", $3, $4)` || len(args) != 2 {
+		t.Errorf("Unexpected %s %v (%v)", sql, args, err)
+	}
+}
+
+func TestQueryFormatInvalid(t *testing.T) {
+	for _, expr := range []Expr{
+		Format("%s"),
+		Format("%s", Column("a"), Column("b")),
+		Format("%d", Column("a")),
+		Format("100%"),
+		And(Column("a"), Format("%s")),
+	} {
+		if _, _, err := expr.Build(); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Expected ErrInvalidQuery, got %v", err)
+		}
+	}
+}
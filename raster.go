@@ -0,0 +1,490 @@
+package postgis
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Errors returned by Raster
+var (
+	// ErrInvalidRaster is returned when decoding or encoding raster WKB that
+	// is truncated or inconsistent
+	ErrInvalidRaster = errors.New("invalid raster")
+	// ErrPixelOutOfRange is returned by the pixel accessors for a band or
+	// pixel outside of the raster
+	ErrPixelOutOfRange = errors.New("pixel out of range")
+	// ErrOutDBBand is returned by the pixel accessors for bands whose pixels
+	// are stored outside of the database
+	ErrOutDBBand = errors.New("band stored outside the database")
+)
+
+// PixelType is the type of the pixels of a raster band, as named by
+// ST_BandPixelType
+type PixelType uint8
+
+// Pixel types of raster bands
+const (
+	Pixel1BB   PixelType = 0  // 1-bit boolean
+	Pixel2BUI  PixelType = 1  // 2-bit unsigned integer
+	Pixel4BUI  PixelType = 2  // 4-bit unsigned integer
+	Pixel8BSI  PixelType = 3  // 8-bit signed integer
+	Pixel8BUI  PixelType = 4  // 8-bit unsigned integer
+	Pixel16BSI PixelType = 5  // 16-bit signed integer
+	Pixel16BUI PixelType = 6  // 16-bit unsigned integer
+	Pixel32BSI PixelType = 7  // 32-bit signed integer
+	Pixel32BUI PixelType = 8  // 32-bit unsigned integer
+	Pixel32BF  PixelType = 10 // 32-bit float
+	Pixel64BF  PixelType = 11 // 64-bit float
+)
+
+// Flags stored with the pixel type of a band
+const (
+	bandFlagOutDB     = 0x80
+	bandFlagHasNoData = 0x40
+	bandFlagIsNoData  = 0x20
+	bandPixelTypeMask = 0x0F
+)
+
+var pixelTypeNames = map[PixelType]string{
+	Pixel1BB:   "1BB",
+	Pixel2BUI:  "2BUI",
+	Pixel4BUI:  "4BUI",
+	Pixel8BSI:  "8BSI",
+	Pixel8BUI:  "8BUI",
+	Pixel16BSI: "16BSI",
+	Pixel16BUI: "16BUI",
+	Pixel32BSI: "32BSI",
+	Pixel32BUI: "32BUI",
+	Pixel32BF:  "32BF",
+	Pixel64BF:  "64BF",
+}
+
+func (t PixelType) String() string {
+	if name, ok := pixelTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("PixelType(%d)", uint8(t))
+}
+
+// Size returns the number of bytes of a pixel in WKB, in which pixels of
+// less than 8 bits take a byte each. It is 0 for unknown types.
+func (t PixelType) Size() int {
+	switch t {
+	case Pixel1BB, Pixel2BUI, Pixel4BUI, Pixel8BSI, Pixel8BUI:
+		return 1
+	case Pixel16BSI, Pixel16BUI:
+		return 2
+	case Pixel32BSI, Pixel32BUI, Pixel32BF:
+		return 4
+	case Pixel64BF:
+		return 8
+	}
+	return 0
+}
+
+// clamp returns value converted to the range and precision of the pixel
+// type, like ST_SetValue: integers are truncated and clamped to their range
+func (t PixelType) clamp(value float64) float64 {
+	var low, high float64
+	switch t {
+	case Pixel1BB:
+		low, high = 0, 1
+	case Pixel2BUI:
+		low, high = 0, 3
+	case Pixel4BUI:
+		low, high = 0, 15
+	case Pixel8BSI:
+		low, high = math.MinInt8, math.MaxInt8
+	case Pixel8BUI:
+		low, high = 0, math.MaxUint8
+	case Pixel16BSI:
+		low, high = math.MinInt16, math.MaxInt16
+	case Pixel16BUI:
+		low, high = 0, math.MaxUint16
+	case Pixel32BSI:
+		low, high = math.MinInt32, math.MaxInt32
+	case Pixel32BUI:
+		low, high = 0, math.MaxUint32
+	case Pixel32BF:
+		return float64(float32(value))
+	default:
+		return value
+	}
+	if math.IsNaN(value) {
+		return low
+	}
+	return math.Max(low, math.Min(high, math.Trunc(value)))
+}
+
+// decode reads a pixel of the type from little endian bytes
+func (t PixelType) decode(data []byte) float64 {
+	switch t {
+	case Pixel8BSI:
+		return float64(int8(data[0]))
+	case Pixel16BSI:
+		return float64(int16(binary.LittleEndian.Uint16(data)))
+	case Pixel16BUI:
+		return float64(binary.LittleEndian.Uint16(data))
+	case Pixel32BSI:
+		return float64(int32(binary.LittleEndian.Uint32(data)))
+	case Pixel32BUI:
+		return float64(binary.LittleEndian.Uint32(data))
+	case Pixel32BF:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(data)))
+	case Pixel64BF:
+		return math.Float64frombits(binary.LittleEndian.Uint64(data))
+	}
+	return float64(data[0])
+}
+
+// encode writes a pixel of the type as little endian bytes, clamping the
+// value first
+func (t PixelType) encode(data []byte, value float64) {
+	value = t.clamp(value)
+	switch t {
+	case Pixel8BSI:
+		data[0] = byte(int8(value))
+	case Pixel16BSI:
+		binary.LittleEndian.PutUint16(data, uint16(int16(value)))
+	case Pixel16BUI:
+		binary.LittleEndian.PutUint16(data, uint16(value))
+	case Pixel32BSI:
+		binary.LittleEndian.PutUint32(data, uint32(int32(value)))
+	case Pixel32BUI:
+		binary.LittleEndian.PutUint32(data, uint32(value))
+	case Pixel32BF:
+		binary.LittleEndian.PutUint32(data, math.Float32bits(float32(value)))
+	case Pixel64BF:
+		binary.LittleEndian.PutUint64(data, math.Float64bits(value))
+	default:
+		data[0] = byte(value)
+	}
+}
+
+// Raster is a PostGIS raster, read from and written as the raster WKB of
+// ST_AsBinary(rast) or the hex text of a raster column.
+//
+//	var tile postgis.Raster
+//	err := db.QueryRow("SELECT rast FROM elevation WHERE rid = $1", id).Scan(&tile)
+//	height, ok, err := tile.Pixel(0, 10, 20)
+type Raster struct {
+	// Size of a pixel in world units; ScaleY is usually negative
+	ScaleX, ScaleY float64
+	// World coordinates of the upper left corner of the upper left pixel
+	UpperLeftX, UpperLeftY float64
+	// Rotation of the raster
+	SkewX, SkewY float64
+	SRID         int32
+	// Number of pixel columns and rows
+	Width, Height uint16
+	Bands         []RasterBand
+}
+
+// RasterBand is a band of a Raster
+type RasterBand struct {
+	PixelType PixelType
+	// HasNoData is set when pixels equal to NoData are NULL
+	HasNoData bool
+	NoData    float64
+	// IsNoData is set when all the pixels of the band are NULL
+	IsNoData bool
+	// OutDB is set for bands whose pixels are in band OutDBBand, counting
+	// from 0, of the file at OutDBPath on the database server. Data is then
+	// empty.
+	OutDB     bool
+	OutDBBand uint8
+	OutDBPath string
+	// Data holds the pixels row by row, PixelType.Size() little endian bytes
+	// each
+	Data []byte
+}
+
+// NewRasterBand returns an in-database band of width by height pixels of
+// type t, all zero
+func NewRasterBand(t PixelType, width, height uint16) RasterBand {
+	return RasterBand{PixelType: t, Data: make([]byte, int(width)*int(height)*t.Size())}
+}
+
+// Scan implements the sql.Scanner interface. It accepts raster WKB as bytes,
+// such as ST_AsBinary(rast) with lib/pq, or hex encoded as a string or
+// bytes, the text form of a raster column.
+func (r *Raster) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		decoded, err := hex.DecodeString(v)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRaster, err)
+		}
+		data = decoded
+	case []byte:
+		// Binary WKB starts with its byte order, 0 or 1, which is not a
+		// hex digit
+		data = v
+		if len(v) > 0 && v[0] != wkbXDR && v[0] != wkbNDR {
+			data = make([]byte, hex.DecodedLen(len(v)))
+			if _, err := hex.Decode(data, v); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidRaster, err)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedValue, value)
+	}
+	return ReadRasterBytes(data, r)
+}
+
+// Value implements the driver.Valuer interface, returning hex raster WKB
+func (r Raster) Value() (driver.Value, error) {
+	data, err := AppendRaster(nil, &r)
+	if err != nil {
+		return nil, err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// Pixel returns the value of the pixel at column x and row y of a band,
+// counting from 0 unlike PostGIS. ok is false for NULL pixels, those equal
+// to the nodata value of the band, as ST_Value returns NULL for them.
+func (r *Raster) Pixel(band, x, y int) (value float64, ok bool, err error) {
+	b, offset, err := r.pixel(band, x, y)
+	if err != nil {
+		return 0, false, err
+	}
+	value = b.PixelType.decode(b.Data[offset:])
+	if b.IsNoData || (b.HasNoData && value == b.PixelType.clamp(b.NoData)) {
+		return value, false, nil
+	}
+	return value, true, nil
+}
+
+// SetPixel sets the pixel at column x and row y of a band, counting from 0.
+// Like ST_SetValue, the value is truncated and clamped to the pixel type.
+func (r *Raster) SetPixel(band, x, y int, value float64) error {
+	b, offset, err := r.pixel(band, x, y)
+	if err != nil {
+		return err
+	}
+	b.PixelType.encode(b.Data[offset:], value)
+	b.IsNoData = false
+	return nil
+}
+
+// pixel returns a band and the offset of a pixel in its data
+func (r *Raster) pixel(band, x, y int) (*RasterBand, int, error) {
+	if band < 0 || band >= len(r.Bands) || x < 0 || x >= int(r.Width) || y < 0 || y >= int(r.Height) {
+		return nil, 0, fmt.Errorf("%w: band %d pixel (%d, %d) of %d bands of %dx%d", ErrPixelOutOfRange, band, x, y, len(r.Bands), r.Width, r.Height)
+	}
+	b := &r.Bands[band]
+	if b.OutDB {
+		return nil, 0, fmt.Errorf("%w: band %d in %s", ErrOutDBBand, band, b.OutDBPath)
+	}
+	size := b.PixelType.Size()
+	offset := (y*int(r.Width) + x) * size
+	if size == 0 || offset+size > len(b.Data) {
+		return nil, 0, fmt.Errorf("%w: band %d has %d bytes of %s pixels for %dx%d", ErrInvalidRaster, band, len(b.Data), b.PixelType, r.Width, r.Height)
+	}
+	return b, offset, nil
+}
+
+// WorldCoordinates returns the world coordinates of the upper left corner of
+// the pixel at column x and row y, counting from 0, like
+// ST_RasterToWorldCoord
+func (r *Raster) WorldCoordinates(x, y int) (float64, float64) {
+	return r.UpperLeftX + float64(x)*r.ScaleX + float64(y)*r.SkewX,
+		r.UpperLeftY + float64(x)*r.SkewY + float64(y)*r.ScaleY
+}
+
+// rasterReader reads raster WKB, remembering the first error
+type rasterReader struct {
+	data  []byte
+	off   int
+	order binary.ByteOrder
+	err   error
+}
+
+func (r *rasterReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.data)-r.off < n {
+		r.err = fmt.Errorf("%w: %w at byte %d", ErrInvalidRaster, io.ErrUnexpectedEOF, r.off)
+		return nil
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *rasterReader) uint8() uint8 {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *rasterReader) uint16() uint16 {
+	if b := r.next(2); b != nil {
+		return r.order.Uint16(b)
+	}
+	return 0
+}
+
+func (r *rasterReader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return r.order.Uint32(b)
+	}
+	return 0
+}
+
+func (r *rasterReader) float64() float64 {
+	if b := r.next(8); b != nil {
+		return math.Float64frombits(r.order.Uint64(b))
+	}
+	return 0
+}
+
+// pixels reads n pixels of a type, returning them as little endian bytes
+func (r *rasterReader) pixels(t PixelType, n int) []byte {
+	size := t.Size()
+	b := r.next(n * size)
+	if b == nil {
+		return nil
+	}
+	pixels := append([]byte(nil), b...)
+	if r.order == binary.BigEndian && size > 1 {
+		for i := 0; i < len(pixels); i += size {
+			for j, k := i, i+size-1; j < k; j, k = j+1, k-1 {
+				pixels[j], pixels[k] = pixels[k], pixels[j]
+			}
+		}
+	}
+	return pixels
+}
+
+// ReadRasterBytes decodes raster WKB into r
+func ReadRasterBytes(data []byte, r *Raster) error {
+	reader := rasterReader{data: data}
+	switch reader.uint8() {
+	case wkbNDR:
+		reader.order = binary.LittleEndian
+	case wkbXDR:
+		reader.order = binary.BigEndian
+	default:
+		if reader.err != nil {
+			return reader.err
+		}
+		return fmt.Errorf("%w: %w: %d", ErrInvalidRaster, ErrUnsupportedByteOrder, data[0])
+	}
+	if version := reader.uint16(); reader.err == nil && version != 0 {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidRaster, version)
+	}
+
+	count := reader.uint16()
+	raster := Raster{
+		ScaleX:     reader.float64(),
+		ScaleY:     reader.float64(),
+		UpperLeftX: reader.float64(),
+		UpperLeftY: reader.float64(),
+		SkewX:      reader.float64(),
+		SkewY:      reader.float64(),
+		SRID:       int32(reader.uint32()),
+		Width:      reader.uint16(),
+		Height:     reader.uint16(),
+	}
+	if reader.err != nil {
+		return reader.err
+	}
+
+	raster.Bands = make([]RasterBand, 0, min(int(count), len(data)))
+	for i := 0; i < int(count); i++ {
+		flags := reader.uint8()
+		band := RasterBand{
+			PixelType: PixelType(flags & bandPixelTypeMask),
+			HasNoData: flags&bandFlagHasNoData != 0,
+			IsNoData:  flags&bandFlagIsNoData != 0,
+			OutDB:     flags&bandFlagOutDB != 0,
+		}
+		if reader.err == nil && band.PixelType.Size() == 0 {
+			return fmt.Errorf("%w: band %d has unknown pixel type %d", ErrInvalidRaster, i, band.PixelType)
+		}
+		if noData := reader.pixels(band.PixelType, 1); noData != nil {
+			band.NoData = band.PixelType.decode(noData)
+		}
+		if band.OutDB {
+			band.OutDBBand = reader.uint8()
+			path := reader.data[min(reader.off, len(reader.data)):]
+			end := 0
+			for end < len(path) && path[end] != 0 {
+				end++
+			}
+			band.OutDBPath = string(reader.next(end))
+			reader.next(1)
+		} else {
+			band.Data = reader.pixels(band.PixelType, int(raster.Width)*int(raster.Height))
+		}
+		if reader.err != nil {
+			return reader.err
+		}
+		raster.Bands = append(raster.Bands, band)
+	}
+	if reader.off != len(data) {
+		return fmt.Errorf("%w: %d bytes after the last band", ErrInvalidRaster, len(data)-reader.off)
+	}
+	*r = raster
+	return nil
+}
+
+// AppendRaster appends the little endian raster WKB of r to dst
+func AppendRaster(dst []byte, r *Raster) ([]byte, error) {
+	if len(r.Bands) > math.MaxUint16 {
+		return dst, fmt.Errorf("%w: %d bands", ErrInvalidRaster, len(r.Bands))
+	}
+	dst = append(dst, wkbNDR)
+	dst = binary.LittleEndian.AppendUint16(dst, 0)
+	dst = binary.LittleEndian.AppendUint16(dst, uint16(len(r.Bands)))
+	for _, v := range []float64{r.ScaleX, r.ScaleY, r.UpperLeftX, r.UpperLeftY, r.SkewX, r.SkewY} {
+		dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(v))
+	}
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(r.SRID))
+	dst = binary.LittleEndian.AppendUint16(dst, r.Width)
+	dst = binary.LittleEndian.AppendUint16(dst, r.Height)
+
+	for i, band := range r.Bands {
+		size := band.PixelType.Size()
+		if size == 0 {
+			return dst, fmt.Errorf("%w: band %d has unknown pixel type %d", ErrInvalidRaster, i, band.PixelType)
+		}
+		flags := uint8(band.PixelType)
+		if band.OutDB {
+			flags |= bandFlagOutDB
+		}
+		if band.HasNoData {
+			flags |= bandFlagHasNoData
+		}
+		if band.IsNoData {
+			flags |= bandFlagIsNoData
+		}
+		dst = append(dst, flags)
+		dst = append(dst, make([]byte, size)...)
+		band.PixelType.encode(dst[len(dst)-size:], band.NoData)
+
+		if band.OutDB {
+			if strings.IndexByte(band.OutDBPath, 0) >= 0 {
+				return dst, fmt.Errorf("%w: band %d path contains a NUL byte", ErrInvalidRaster, i)
+			}
+			dst = append(append(append(dst, band.OutDBBand), band.OutDBPath...), 0)
+			continue
+		}
+		if expected := int(r.Width) * int(r.Height) * size; len(band.Data) != expected {
+			return dst, fmt.Errorf("%w: band %d has %d bytes of %s pixels, expected %d", ErrInvalidRaster, i, len(band.Data), band.PixelType, expected)
+		}
+		dst = append(dst, band.Data...)
+	}
+	return dst, nil
+}
@@ -0,0 +1,160 @@
+package postgis
+
+import (
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sampleRasterHex is a 3x2 raster in UTM zone 33N with an 8BUI band whose
+// nodata value is 0 and a 16BSI band without nodata
+const sampleRasterHex = "0100000200000000000000244000000000000024C00000000080841E410000000080844E4100000000000000000000000000000000797F00000300020044000001020304FF05FFFFD4FEFEFFFFFF000001002C01"
+
+func sampleRaster() Raster {
+	return Raster{
+		ScaleX: 10, ScaleY: -10,
+		UpperLeftX: 500000, UpperLeftY: 4000000,
+		SRID:  32633,
+		Width: 3, Height: 2,
+		Bands: []RasterBand{
+			{PixelType: Pixel8BUI, HasNoData: true, NoData: 0, Data: []byte{0, 1, 2, 3, 4, 255}},
+			{PixelType: Pixel16BSI, NoData: -1, Data: []byte{0xD4, 0xFE, 0xFE, 0xFF, 0xFF, 0xFF, 0x00, 0x00, 0x01, 0x00, 0x2C, 0x01}},
+		},
+	}
+}
+
+func TestRasterScan(t *testing.T) {
+	binary, _ := hex.DecodeString(sampleRasterHex)
+	big := "00000000024024000000000000C024000000000000411E848000000000414E8480000000000000000000000000000000000000000000007F790003000244000001020304FF05FFFFFED4FFFEFFFF00000001012C"
+	for name, value := range map[string]interface{}{
+		"hex string": sampleRasterHex,
+		"hex bytes":  []byte(strings.ToLower(sampleRasterHex)),
+		"binary":     binary,
+		"big endian": big,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var r Raster
+			if err := r.Scan(value); err != nil {
+				t.Fatalf("Failed to scan: %v", err)
+			}
+			if expected := sampleRaster(); !reflect.DeepEqual(r, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, r)
+			}
+		})
+	}
+
+	value, err := sampleRaster().Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	if !strings.EqualFold(value.(string), sampleRasterHex) {
+		t.Errorf("Expected %s, got %s", sampleRasterHex, value)
+	}
+}
+
+func TestRasterOutDB(t *testing.T) {
+	const outDB = "0100000100000000000000244000000000000024C00000000080841E410000000080844E4100000000000000000000000000000000797F000003000200CB000000008087C3C0022F646174612F64656D2E74696600"
+	var r Raster
+	if err := r.Scan(outDB); err != nil {
+		t.Fatalf("Failed to scan: %v", err)
+	}
+	expected := RasterBand{PixelType: Pixel64BF, HasNoData: true, NoData: -9999, OutDB: true, OutDBBand: 2, OutDBPath: "/data/dem.tif"}
+	if len(r.Bands) != 1 || !reflect.DeepEqual(r.Bands[0], expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, r.Bands)
+	}
+	if _, _, err := r.Pixel(0, 0, 0); !errors.Is(err, ErrOutDBBand) {
+		t.Errorf("Expected ErrOutDBBand, got %v", err)
+	}
+	if encoded, err := AppendRaster(nil, &r); err != nil || !strings.EqualFold(hex.EncodeToString(encoded), outDB) {
+		t.Errorf("Expected %s, got %x (%v)", outDB, encoded, err)
+	}
+}
+
+func TestRasterPixel(t *testing.T) {
+	r := sampleRaster()
+	tests := []struct {
+		band, x, y int
+		value      float64
+		ok         bool
+	}{
+		{0, 0, 0, 0, false},
+		{0, 1, 0, 1, true},
+		{0, 2, 1, 255, true},
+		{1, 0, 0, -300, true},
+		{1, 2, 0, -1, true},
+		{1, 2, 1, 300, true},
+	}
+	for _, test := range tests {
+		value, ok, err := r.Pixel(test.band, test.x, test.y)
+		if err != nil || value != test.value || ok != test.ok {
+			t.Errorf("Pixel(%d, %d, %d): expected %v %v, got %v %v (%v)", test.band, test.x, test.y, test.value, test.ok, value, ok, err)
+		}
+	}
+	for _, p := range [][3]int{{2, 0, 0}, {0, 3, 0}, {0, 0, 2}, {-1, 0, 0}} {
+		if _, _, err := r.Pixel(p[0], p[1], p[2]); !errors.Is(err, ErrPixelOutOfRange) {
+			t.Errorf("Expected ErrPixelOutOfRange for %v, got %v", p, err)
+		}
+	}
+
+	// Values are truncated and clamped to the pixel type
+	for _, test := range []struct{ set, expected float64 }{{7.9, 7}, {-5, 0}, {1000, 255}} {
+		if err := r.SetPixel(0, 1, 1, test.set); err != nil {
+			t.Fatalf("SetPixel failed: %v", err)
+		}
+		if value, _, _ := r.Pixel(0, 1, 1); value != test.expected {
+			t.Errorf("SetPixel(%v): expected %v, got %v", test.set, test.expected, value)
+		}
+	}
+
+	if x, y := r.WorldCoordinates(2, 1); x != 500020 || y != 3999990 {
+		t.Errorf("Expected world coordinates (500020, 3999990), got (%v, %v)", x, y)
+	}
+
+	band := NewRasterBand(Pixel32BF, 3, 2)
+	band.IsNoData = true
+	r.Bands = append(r.Bands, band)
+	if _, ok, _ := r.Pixel(2, 0, 0); ok {
+		t.Error("Expected the pixels of a nodata band to be NULL")
+	}
+	if err := r.SetPixel(2, 0, 0, 1.5); err != nil {
+		t.Fatalf("SetPixel failed: %v", err)
+	}
+	if value, ok, _ := r.Pixel(2, 0, 0); !ok || value != 1.5 {
+		t.Errorf("Expected 1.5, got %v %v", value, ok)
+	}
+}
+
+func TestRasterInvalid(t *testing.T) {
+	data, _ := hex.DecodeString(sampleRasterHex)
+	for i := 0; i < len(data); i++ {
+		var r Raster
+		if err := ReadRasterBytes(data[:i], &r); !errors.Is(err, ErrInvalidRaster) || (i > 0 && !errors.Is(err, io.ErrUnexpectedEOF)) {
+			t.Fatalf("Expected truncation error for %d bytes, got %v", i, err)
+		}
+	}
+
+	var r Raster
+	if err := r.Scan(append(append([]byte(nil), data...), 0)); !errors.Is(err, ErrInvalidRaster) {
+		t.Errorf("Expected ErrInvalidRaster for trailing data, got %v", err)
+	}
+	unknown := append([]byte(nil), data...)
+	unknown[61] = 9
+	if err := r.Scan(unknown); !errors.Is(err, ErrInvalidRaster) {
+		t.Errorf("Expected ErrInvalidRaster for pixel type 9, got %v", err)
+	}
+	if err := r.Scan("zz"); !errors.Is(err, ErrInvalidRaster) {
+		t.Errorf("Expected ErrInvalidRaster for invalid hex, got %v", err)
+	}
+	if err := r.Scan(42); !errors.Is(err, ErrUnsupportedValue) {
+		t.Errorf("Expected ErrUnsupportedValue, got %v", err)
+	}
+
+	short := sampleRaster()
+	short.Bands[1].Data = short.Bands[1].Data[:4]
+	if _, err := short.Value(); !errors.Is(err, ErrInvalidRaster) {
+		t.Errorf("Expected ErrInvalidRaster for short band data, got %v", err)
+	}
+}
@@ -0,0 +1,451 @@
+package postgis
+
+import (
+	"container/heap"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Node capacity of the R-trees. A node that drops below the minimum after a
+// removal is dissolved and its items are inserted again.
+const (
+	rtreeMaxEntries = 16
+	rtreeMinEntries = rtreeMaxEntries * 2 / 5
+)
+
+// RTree is an in-memory spatial index over geometries, keyed on their 2D
+// envelopes. The zero value is an empty tree ready to use. An RTree is safe
+// for concurrent use: searches run in parallel and modifications wait for
+// them to finish. It must not be copied after first use.
+//
+// Geometries are identified by ==, so the geometries of this package, which
+// are stored as pointers (e.g. *PolygonS), match by identity.
+type RTree struct {
+	mu   sync.RWMutex
+	tree rtree[Geometry]
+}
+
+// Load replaces the contents of the tree with the geometries, packed with
+// the Sort-Tile-Recursive algorithm. This is much faster than inserting them
+// one by one and gives a tree with less overlap between nodes.
+func (t *RTree) Load(geometries []Geometry) {
+	entries := make([]rtreeEntry[Geometry], len(geometries))
+	for i, g := range geometries {
+		entries[i] = rtreeEntry[Geometry]{env: EnvelopeOf(g), value: g}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tree.load(entries)
+}
+
+// Insert adds a geometry to the tree. Empty geometries are kept but never
+// returned by Search or Nearest.
+func (t *RTree) Insert(g Geometry) {
+	env := EnvelopeOf(g)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tree.insert(env, g)
+}
+
+// Delete removes one occurrence of the geometry and reports whether it was
+// found. The geometry must not have been modified since it was inserted, as
+// it is looked up by its envelope.
+func (t *RTree) Delete(g Geometry) bool {
+	env := EnvelopeOf(g)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.remove(env, func(other Geometry) bool { return sameGeometry(g, other) })
+}
+
+// Len returns the number of geometries in the tree
+func (t *RTree) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.size
+}
+
+// Search returns the geometries whose envelope intersects env, like the &&
+// operator, in no particular order
+func (t *RTree) Search(env Envelope) []Geometry {
+	var result []Geometry
+	t.SearchFunc(env, func(g Geometry) bool {
+		result = append(result, g)
+		return true
+	})
+	return result
+}
+
+// SearchFunc calls fn for every geometry whose envelope intersects env until
+// fn returns false. It does not allocate, which suits lookups in loops. fn
+// runs with the tree locked for reading and must not modify it.
+func (t *RTree) SearchFunc(env Envelope, fn func(g Geometry) bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	t.tree.search(env, func(_ Envelope, g Geometry) bool { return fn(g) })
+}
+
+// Nearest returns up to k geometries closest to p, nearest first, like an
+// ORDER BY with the <-> operator. Distances are planar and exact: zero inside
+// a polygon, otherwise the distance to the nearest vertex or segment.
+// Geometries implemented outside this package are ranked by the distance to
+// their envelope.
+func (t *RTree) Nearest(p Point, k int) []Geometry {
+	var result []Geometry
+	if k <= 0 {
+		return result
+	}
+	t.NearestFunc(p, func(g Geometry, _ float64) bool {
+		result = append(result, g)
+		return len(result) < k
+	})
+	return result
+}
+
+// NearestFunc calls fn with the geometries in order of increasing distance
+// to p until fn returns false. See Nearest for how the distance is measured.
+// fn runs with the tree locked for reading and must not modify it.
+func (t *RTree) NearestFunc(p Point, fn func(g Geometry, distance float64) bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	t.tree.nearest(
+		func(env Envelope) float64 { return env.Distance(p) },
+		func(env Envelope, g Geometry) float64 {
+			if s, ok := g.(shaper); ok {
+				return s.planarShape().distance(p)
+			}
+			return env.Distance(p)
+		},
+		math.Inf(1), fn)
+}
+
+// sameGeometry compares geometries with ==, falling back to a deep comparison
+// for geometry implementations stored by value that == cannot compare
+func sameGeometry(a, b Geometry) bool {
+	ta := reflect.TypeOf(a)
+	if ta != reflect.TypeOf(b) {
+		return false
+	}
+	if ta == nil || ta.Comparable() {
+		return a == b
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// rtree is the unsynchronised R-tree behind RTree and PointIndex. Insertion
+// picks the child needing the least enlargement and splits full nodes along
+// the axis and position giving the least overlap.
+type rtree[T any] struct {
+	root *rtreeNode[T]
+	size int
+}
+
+type rtreeNode[T any] struct {
+	leaf    bool
+	env     Envelope
+	entries []rtreeEntry[T]
+}
+
+// rtreeEntry is a child node, or an item in a leaf
+type rtreeEntry[T any] struct {
+	env   Envelope
+	child *rtreeNode[T]
+	value T
+}
+
+func (n *rtreeNode[T]) recalculate() {
+	n.env = EmptyEnvelope()
+	for _, e := range n.entries {
+		n.env = n.env.ExpandToInclude(e.env)
+	}
+}
+
+func (t *rtree[T]) insert(env Envelope, value T) {
+	t.size++
+	if t.root == nil {
+		t.root = &rtreeNode[T]{leaf: true, env: EmptyEnvelope()}
+	}
+
+	// Descend to a leaf, enlarging the nodes on the way
+	path := []*rtreeNode[T]{t.root}
+	n := t.root
+	for !n.leaf {
+		n.env = n.env.ExpandToInclude(env)
+		best, bestEnlargement, bestArea := 0, math.Inf(1), math.Inf(1)
+		for i, e := range n.entries {
+			area := e.env.Area()
+			enlargement := e.env.ExpandToInclude(env).Area() - area
+			if enlargement < bestEnlargement || (enlargement == bestEnlargement && area < bestArea) {
+				best, bestEnlargement, bestArea = i, enlargement, area
+			}
+		}
+		n.entries[best].env = n.entries[best].env.ExpandToInclude(env)
+		n = n.entries[best].child
+		path = append(path, n)
+	}
+	n.env = n.env.ExpandToInclude(env)
+	n.entries = append(n.entries, rtreeEntry[T]{env: env, value: value})
+
+	// Split overflowing nodes bottom-up
+	for i := len(path) - 1; i >= 0 && len(path[i].entries) > rtreeMaxEntries; i-- {
+		sibling := path[i].split()
+		if i == 0 {
+			old := path[0]
+			t.root = &rtreeNode[T]{entries: []rtreeEntry[T]{{env: old.env, child: old}, {env: sibling.env, child: sibling}}}
+			t.root.recalculate()
+			break
+		}
+		parent := path[i-1]
+		for j := range parent.entries {
+			if parent.entries[j].child == path[i] {
+				parent.entries[j].env = path[i].env
+			}
+		}
+		parent.entries = append(parent.entries, rtreeEntry[T]{env: sibling.env, child: sibling})
+	}
+}
+
+// split moves part of the entries of an overflowing node to a new sibling.
+// The entries are sorted along the axis whose splits have the smallest total
+// margin, then cut where the two halves overlap least.
+func (n *rtreeNode[T]) split() *rtreeNode[T] {
+	byX := func(i, j int) bool { return n.entries[i].env.MinX < n.entries[j].env.MinX }
+	byY := func(i, j int) bool { return n.entries[i].env.MinY < n.entries[j].env.MinY }
+	sort.Slice(n.entries, byX)
+	marginX := n.splitMargins()
+	sort.Slice(n.entries, byY)
+	if marginX < n.splitMargins() {
+		sort.Slice(n.entries, byX)
+	}
+
+	best, bestOverlap, bestArea := rtreeMinEntries, math.Inf(1), math.Inf(1)
+	for k := rtreeMinEntries; k <= len(n.entries)-rtreeMinEntries; k++ {
+		a, b := entriesEnvelope(n.entries[:k]), entriesEnvelope(n.entries[k:])
+		overlap := 0.0
+		if a.Intersects(b) {
+			overlap = Envelope{
+				MinX: math.Max(a.MinX, b.MinX), MinY: math.Max(a.MinY, b.MinY),
+				MaxX: math.Min(a.MaxX, b.MaxX), MaxY: math.Min(a.MaxY, b.MaxY),
+			}.Area()
+		}
+		area := a.Area() + b.Area()
+		if overlap < bestOverlap || (overlap == bestOverlap && area < bestArea) {
+			best, bestOverlap, bestArea = k, overlap, area
+		}
+	}
+
+	sibling := &rtreeNode[T]{leaf: n.leaf, entries: append([]rtreeEntry[T](nil), n.entries[best:]...)}
+	n.entries = n.entries[:best:best]
+	n.recalculate()
+	sibling.recalculate()
+	return sibling
+}
+
+// splitMargins sums the perimeters of every allowed split of the entries in
+// their current order
+func (n *rtreeNode[T]) splitMargins() float64 {
+	var sum float64
+	for k := rtreeMinEntries; k <= len(n.entries)-rtreeMinEntries; k++ {
+		for _, env := range []Envelope{entriesEnvelope(n.entries[:k]), entriesEnvelope(n.entries[k:])} {
+			if !env.IsEmpty() {
+				sum += env.MaxX - env.MinX + env.MaxY - env.MinY
+			}
+		}
+	}
+	return sum
+}
+
+func entriesEnvelope[T any](entries []rtreeEntry[T]) Envelope {
+	env := EmptyEnvelope()
+	for _, e := range entries {
+		env = env.ExpandToInclude(e.env)
+	}
+	return env
+}
+
+// remove deletes the first item within env accepted by match
+func (t *rtree[T]) remove(env Envelope, match func(T) bool) bool {
+	if t.root == nil {
+		return false
+	}
+	var orphans []rtreeEntry[T]
+	if !t.removeFrom(t.root, env, match, &orphans) {
+		return false
+	}
+	t.size--
+	for !t.root.leaf && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	if len(t.root.entries) == 0 {
+		t.root = nil
+	}
+	for _, o := range orphans {
+		t.size--
+		t.insert(o.env, o.value)
+	}
+	return true
+}
+
+// removeFrom removes the item from the subtree of n. Children left with too
+// few entries are dissolved and their items collected in orphans.
+func (t *rtree[T]) removeFrom(n *rtreeNode[T], env Envelope, match func(T) bool, orphans *[]rtreeEntry[T]) bool {
+	for i := range n.entries {
+		e := &n.entries[i]
+		if !env.IsEmpty() && !e.env.Contains(env) {
+			continue
+		}
+		if n.leaf {
+			if !match(e.value) {
+				continue
+			}
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			n.recalculate()
+			return true
+		}
+		if !t.removeFrom(e.child, env, match, orphans) {
+			continue
+		}
+		if len(e.child.entries) < rtreeMinEntries {
+			*orphans = e.child.appendItems(*orphans)
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+		} else {
+			e.env = e.child.env
+		}
+		n.recalculate()
+		return true
+	}
+	return false
+}
+
+func (n *rtreeNode[T]) appendItems(items []rtreeEntry[T]) []rtreeEntry[T] {
+	if n.leaf {
+		return append(items, n.entries...)
+	}
+	for _, e := range n.entries {
+		items = e.child.appendItems(items)
+	}
+	return items
+}
+
+// search calls fn for the items intersecting env and returns false when fn
+// stopped the search
+func (t *rtree[T]) search(env Envelope, fn func(Envelope, T) bool) bool {
+	if t.root == nil {
+		return true
+	}
+	return t.root.search(env, fn)
+}
+
+func (n *rtreeNode[T]) search(env Envelope, fn func(Envelope, T) bool) bool {
+	for _, e := range n.entries {
+		if !env.Intersects(e.env) {
+			continue
+		}
+		if n.leaf {
+			if !fn(e.env, e.value) {
+				return false
+			}
+		} else if !e.child.search(env, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// rtreeCandidate is a node or item waiting in the queue of a nearest
+// neighbour search
+type rtreeCandidate[T any] struct {
+	distance float64
+	node     *rtreeNode[T]
+	value    T
+}
+
+type rtreeQueue[T any] []rtreeCandidate[T]
+
+func (q rtreeQueue[T]) Len() int           { return len(q) }
+func (q rtreeQueue[T]) Less(i, j int) bool { return q[i].distance < q[j].distance }
+func (q rtreeQueue[T]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *rtreeQueue[T]) Push(x any)        { *q = append(*q, x.(rtreeCandidate[T])) }
+func (q *rtreeQueue[T]) Pop() any {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+// nearest calls fn with the items in order of increasing distance, up to
+// maxDistance, until fn returns false. boxDistance must never exceed the
+// itemDistance of any item inside the box, so that a node is opened before
+// any of its items could be due.
+func (t *rtree[T]) nearest(boxDistance func(Envelope) float64, itemDistance func(Envelope, T) float64, maxDistance float64, fn func(T, float64) bool) {
+	if t.root == nil {
+		return
+	}
+	queue := &rtreeQueue[T]{{distance: boxDistance(t.root.env), node: t.root}}
+	for queue.Len() > 0 {
+		c := heap.Pop(queue).(rtreeCandidate[T])
+		if c.distance > maxDistance {
+			return
+		}
+		if c.node == nil {
+			if !fn(c.value, c.distance) {
+				return
+			}
+			continue
+		}
+		for _, e := range c.node.entries {
+			if e.env.IsEmpty() {
+				continue
+			}
+			if c.node.leaf {
+				heap.Push(queue, rtreeCandidate[T]{distance: itemDistance(e.env, e.value), value: e.value})
+			} else {
+				heap.Push(queue, rtreeCandidate[T]{distance: boxDistance(e.env), node: e.child})
+			}
+		}
+	}
+}
+
+// load replaces the tree with one packed bottom-up by Sort-Tile-Recursive:
+// the entries of each level are sorted into vertical slices by X, each slice
+// is sorted by Y and cut into full nodes
+func (t *rtree[T]) load(items []rtreeEntry[T]) {
+	t.root, t.size = nil, len(items)
+	if len(items) == 0 {
+		return
+	}
+	entries := append([]rtreeEntry[T](nil), items...)
+	leaf := true
+	for {
+		var nodes []rtreeEntry[T]
+		for _, group := range strGroups(entries) {
+			n := &rtreeNode[T]{leaf: leaf, entries: group}
+			n.recalculate()
+			nodes = append(nodes, rtreeEntry[T]{env: n.env, child: n})
+		}
+		if len(nodes) == 1 {
+			t.root = nodes[0].child
+			return
+		}
+		entries, leaf = nodes, false
+	}
+}
+
+func strGroups[T any](entries []rtreeEntry[T]) [][]rtreeEntry[T] {
+	leaves := (len(entries) + rtreeMaxEntries - 1) / rtreeMaxEntries
+	slices := int(math.Ceil(math.Sqrt(float64(leaves))))
+	sliceSize := slices * rtreeMaxEntries
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].env.center().X < entries[j].env.center().X })
+	var groups [][]rtreeEntry[T]
+	for start := 0; start < len(entries); start += sliceSize {
+		column := entries[start:min(start+sliceSize, len(entries))]
+		sort.Slice(column, func(i, j int) bool { return column[i].env.center().Y < column[j].env.center().Y })
+		for i := 0; i < len(column); i += rtreeMaxEntries {
+			end := min(i+rtreeMaxEntries, len(column))
+			groups = append(groups, column[i:end:end])
+		}
+	}
+	return groups
+}
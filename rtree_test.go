@@ -0,0 +1,186 @@
+package postgis
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func randomGeometries(r *rand.Rand, n int) []Geometry {
+	geometries := make([]Geometry, n)
+	for i := range geometries {
+		x, y := r.Float64()*1000, r.Float64()*1000
+		switch i % 3 {
+		case 0:
+			geometries[i] = &PointS{SRID: 4326, X: x, Y: y}
+		case 1:
+			geometries[i] = &LineString{Points: []Point{{X: x, Y: y}, {X: x + r.Float64()*20, Y: y + r.Float64()*20}}}
+		default:
+			geometries[i] = &Polygon{Rings: [][]Point{square(x, y, r.Float64()*20)}}
+		}
+	}
+	return geometries
+}
+
+// bruteSearch returns the geometries whose envelope intersects env
+func bruteSearch(geometries []Geometry, env Envelope) map[Geometry]bool {
+	found := make(map[Geometry]bool)
+	for _, g := range geometries {
+		if EnvelopeOf(g).Intersects(env) {
+			found[g] = true
+		}
+	}
+	return found
+}
+
+func checkSearch(t *testing.T, tree *RTree, geometries []Geometry, r *rand.Rand) {
+	t.Helper()
+	for i := 0; i < 50; i++ {
+		x, y := r.Float64()*1000, r.Float64()*1000
+		env := Envelope{MinX: x, MinY: y, MaxX: x + r.Float64()*100, MaxY: y + r.Float64()*100}
+		expected := bruteSearch(geometries, env)
+		result := tree.Search(env)
+		if len(result) != len(expected) {
+			t.Fatalf("Expected %d results, got %d", len(expected), len(result))
+		}
+		for _, g := range result {
+			if !expected[g] {
+				t.Fatalf("Unexpected result %v", g)
+			}
+		}
+	}
+}
+
+func TestRTreeSearch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	geometries := randomGeometries(r, 2000)
+
+	var loaded RTree
+	loaded.Load(geometries)
+	if loaded.Len() != len(geometries) {
+		t.Fatalf("Expected %d geometries, got %d", len(geometries), loaded.Len())
+	}
+	checkSearch(t, &loaded, geometries, r)
+
+	var inserted RTree
+	for _, g := range geometries {
+		inserted.Insert(g)
+	}
+	checkSearch(t, &inserted, geometries, r)
+
+	// Delete half of the geometries, from both trees
+	remaining := geometries[:0:0]
+	for i, g := range geometries {
+		if i%2 == 0 {
+			remaining = append(remaining, g)
+			continue
+		}
+		if !loaded.Delete(g) || !inserted.Delete(g) {
+			t.Fatalf("Failed to delete %v", g)
+		}
+	}
+	if loaded.Len() != len(remaining) || inserted.Len() != len(remaining) {
+		t.Fatalf("Expected %d geometries, got %d and %d", len(remaining), loaded.Len(), inserted.Len())
+	}
+	checkSearch(t, &loaded, remaining, r)
+	checkSearch(t, &inserted, remaining, r)
+
+	if loaded.Delete(geometries[1]) {
+		t.Errorf("Expected deleting a missing geometry to fail")
+	}
+	for _, g := range remaining {
+		loaded.Delete(g)
+	}
+	if loaded.Len() != 0 || len(loaded.Search(Envelope{MinX: 0, MinY: 0, MaxX: 1000, MaxY: 1000})) != 0 {
+		t.Errorf("Expected an empty tree")
+	}
+}
+
+func TestRTreeDeleteIdentity(t *testing.T) {
+	var tree RTree
+	a := &LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+	b := &LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}
+	tree.Insert(a)
+
+	if tree.Delete(b) {
+		t.Errorf("Expected an equal but distinct line not to be deleted")
+	}
+	if !tree.Delete(a) {
+		t.Errorf("Expected the inserted line to be deleted")
+	}
+	if tree.Len() != 0 {
+		t.Errorf("Expected an empty tree, got %d geometries", tree.Len())
+	}
+}
+
+func TestRTreeNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	geometries := randomGeometries(r, 1000)
+	var tree RTree
+	tree.Load(geometries)
+
+	for i := 0; i < 20; i++ {
+		p := Point{X: r.Float64() * 1000, Y: r.Float64() * 1000}
+		distances := make([]float64, len(geometries))
+		for j, g := range geometries {
+			distances[j] = g.(shaper).planarShape().distance(p)
+		}
+		sort.Float64s(distances)
+
+		var got []float64
+		tree.NearestFunc(p, func(g Geometry, d float64) bool {
+			got = append(got, d)
+			return len(got) < 10
+		})
+		for j, d := range got {
+			if d != distances[j] {
+				t.Fatalf("Expected distance %f at rank %d, got %f", distances[j], j, d)
+			}
+		}
+	}
+
+	inside := &Polygon{Rings: [][]Point{square(0, 0, 100)}}
+	tree.Insert(inside)
+	if nearest := tree.Nearest(Point{X: 50, Y: 50}, 1); len(nearest) != 1 || nearest[0] != inside {
+		t.Errorf("Expected the polygon containing the point, got %v", nearest)
+	}
+}
+
+func TestRTreeConcurrent(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	geometries := randomGeometries(r, 500)
+	var tree RTree
+	tree.Load(geometries)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if i == 0 {
+					tree.Insert(&Point{X: float64(j), Y: float64(j)})
+					continue
+				}
+				tree.Search(Envelope{MinX: 0, MinY: 0, MaxX: 500, MaxY: 500})
+				tree.Nearest(Point{X: 250, Y: 250}, 5)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if tree.Len() != 600 {
+		t.Errorf("Expected 600 geometries, got %d", tree.Len())
+	}
+}
+
+func BenchmarkRTreeSearch(b *testing.B) {
+	r := rand.New(rand.NewSource(4))
+	var tree RTree
+	tree.Load(randomGeometries(r, 50000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x, y := r.Float64()*1000, r.Float64()*1000
+		tree.SearchFunc(Envelope{MinX: x, MinY: y, MaxX: x + 10, MaxY: y + 10}, func(Geometry) bool { return true })
+	}
+}
@@ -0,0 +1,106 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+)
+
+// TINOf is a triangulated irregular network whose coordinate type P sets its
+// dimensions: a PolyhedralSurface whose faces are Triangles, as used for
+// terrain meshes
+type TINOf[P PointLike] struct {
+	Triangles []TriangleOf[P]
+}
+
+// TINSOf is a TIN with an SRID
+type TINSOf[P PointLike] struct {
+	SRID      int32
+	Triangles []TriangleOf[P]
+}
+
+// Varying types of TINs
+type (
+	TIN   = TINOf[Point]
+	TINZ  = TINOf[PointZ]
+	TINM  = TINOf[PointM]
+	TINZM = TINOf[PointZM]
+
+	TINS   = TINSOf[Point]
+	TINZS  = TINSOf[PointZ]
+	TINMS  = TINSOf[PointM]
+	TINZMS = TINSOf[PointZM]
+)
+
+// Implement SRIDGeometry interface for SRID types
+func (m *TINSOf[P]) GetSRID() int32     { return m.SRID }
+func (m *TINSOf[P]) SetSRID(srid int32) { m.SRID = srid }
+
+// Implement CollectionGeometry interface for all TIN types
+func (m *TINOf[P]) GetElementCount() uint32  { return getElementCountHelper(m.Triangles) }
+func (m *TINSOf[P]) GetElementCount() uint32 { return getElementCountHelper(m.Triangles) }
+
+func (m *TINOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(m.Triangles, buffer)
+}
+
+func (m *TINSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeGeometriesHelper(m.Triangles, buffer)
+}
+
+func (m *TINOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[TriangleOf[P]](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	m.Triangles = geometries
+	return nil
+}
+
+func (m *TINSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	geometries, err := readGeometriesHelper[TriangleOf[P]](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	m.Triangles = geometries
+	return nil
+}
+
+/** TINOf functions **/
+func (m *TINOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m TINOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m TINOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m TINOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBTIN, coordinateType[P](), false)
+}
+
+/** TINSOf functions **/
+func (m *TINSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(m, value)
+}
+
+func (m TINSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&m)
+}
+
+func (m TINSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, m.GetElementCount(), func(buf *bytes.Buffer) error {
+		return m.WriteElements(buf)
+	})
+}
+
+func (m TINSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBTIN, coordinateType[P](), true)
+}
@@ -0,0 +1,132 @@
+package postgis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// TriangleOf is a Triangle whose coordinate type P sets its dimensions. Its
+// Points are a closed ring of four points, the last repeating the first, and
+// are encoded as a polygon with a single ring. An empty Triangle has no
+// points.
+type TriangleOf[P PointLike] struct {
+	Points []P
+}
+
+// TriangleSOf is a Triangle with an SRID
+type TriangleSOf[P PointLike] struct {
+	SRID   int32
+	Points []P
+}
+
+// Varying types of Triangles
+type (
+	Triangle   = TriangleOf[Point]
+	TriangleZ  = TriangleOf[PointZ]
+	TriangleM  = TriangleOf[PointM]
+	TriangleZM = TriangleOf[PointZM]
+
+	TriangleS   = TriangleSOf[Point]
+	TriangleZS  = TriangleSOf[PointZ]
+	TriangleMS  = TriangleSOf[PointM]
+	TriangleZMS = TriangleSOf[PointZM]
+)
+
+// triangleRings returns the ring of a triangle as the rings of a polygon
+func triangleRings[P PointLike](points []P) [][]P {
+	if len(points) == 0 {
+		return nil
+	}
+	return [][]P{points}
+}
+
+func readTriangleHelper[P PointLike](reader io.Reader, byteOrder binary.ByteOrder, count uint32) ([]P, error) {
+	if count > 1 {
+		return nil, fmt.Errorf("%w: triangle with %d rings", ErrCorruptEWKB, count)
+	}
+	rings, err := readRingsHelper[P](reader, byteOrder, count)
+	if err != nil || len(rings) == 0 {
+		return nil, err
+	}
+	return rings[0], nil
+}
+
+// Implement SRIDGeometry interface for SRID types
+func (t *TriangleSOf[P]) GetSRID() int32     { return t.SRID }
+func (t *TriangleSOf[P]) SetSRID(srid int32) { t.SRID = srid }
+
+// Implement CollectionGeometry interface for all Triangle types
+func (t *TriangleOf[P]) GetElementCount() uint32 {
+	return getElementCountHelper(triangleRings(t.Points))
+}
+
+func (t *TriangleSOf[P]) GetElementCount() uint32 {
+	return getElementCountHelper(triangleRings(t.Points))
+}
+
+func (t *TriangleOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeRingsHelper(triangleRings(t.Points), buffer)
+}
+
+func (t *TriangleSOf[P]) WriteElements(buffer *bytes.Buffer) error {
+	return writeRingsHelper(triangleRings(t.Points), buffer)
+}
+
+func (t *TriangleOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	points, err := readTriangleHelper[P](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	t.Points = points
+	return nil
+}
+
+func (t *TriangleSOf[P]) ReadElements(reader io.Reader, byteOrder binary.ByteOrder, count uint32) error {
+	points, err := readTriangleHelper[P](reader, byteOrder, count)
+	if err != nil {
+		return err
+	}
+	t.Points = points
+	return nil
+}
+
+/** TriangleOf functions **/
+func (t *TriangleOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(t, value)
+}
+
+func (t TriangleOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&t)
+}
+
+func (t TriangleOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, t.GetElementCount(), func(buf *bytes.Buffer) error {
+		return t.WriteElements(buf)
+	})
+}
+
+func (t TriangleOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBTriangle, coordinateType[P](), false)
+}
+
+/** TriangleSOf functions **/
+func (t *TriangleSOf[P]) Scan(value interface{}) error {
+	return scanGeometryHelper(t, value)
+}
+
+func (t TriangleSOf[P]) Value() (driver.Value, error) {
+	return valueGeometryHelper(&t)
+}
+
+func (t TriangleSOf[P]) Write(buffer *bytes.Buffer) error {
+	return WriteGeometryCollection(buffer, t.GetElementCount(), func(buf *bytes.Buffer) error {
+		return t.WriteElements(buf)
+	})
+}
+
+func (t TriangleSOf[P]) GetType() uint32 {
+	return BuildWKBType(WKBTriangle, coordinateType[P](), true)
+}
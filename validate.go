@@ -0,0 +1,605 @@
+package postgis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync/atomic"
+)
+
+// InvalidReason tells why a geometry is invalid. Its String form matches the
+// reason reported by ST_IsValidReason.
+type InvalidReason int
+
+const (
+	ReasonNone InvalidReason = iota
+	ReasonInvalidCoordinate
+	ReasonTooFewPoints
+	ReasonRingNotClosed
+	ReasonSelfIntersection
+	ReasonRingSelfIntersection
+	ReasonHoleOutsideShell
+	ReasonNestedHoles
+	ReasonDisconnectedInterior
+	ReasonNestedShells
+)
+
+func (r InvalidReason) String() string {
+	switch r {
+	case ReasonNone:
+		return "Valid Geometry"
+	case ReasonInvalidCoordinate:
+		return "Invalid Coordinate"
+	case ReasonTooFewPoints:
+		return "Too few points in geometry component"
+	case ReasonRingNotClosed:
+		return "Ring is not closed"
+	case ReasonSelfIntersection:
+		return "Self-intersection"
+	case ReasonRingSelfIntersection:
+		return "Ring Self-intersection"
+	case ReasonHoleOutsideShell:
+		return "Hole lies outside shell"
+	case ReasonNestedHoles:
+		return "Holes are nested"
+	case ReasonDisconnectedInterior:
+		return "Interior is disconnected"
+	case ReasonNestedShells:
+		return "Nested shells"
+	default:
+		return fmt.Sprintf("InvalidReason(%d)", int(r))
+	}
+}
+
+// ValidityDetail is the result of ValidateDetail, mirroring ST_IsValidDetail.
+// Location holds the X and Y of the first problem found.
+type ValidityDetail struct {
+	Valid    bool
+	Reason   InvalidReason
+	Location Point
+}
+
+// Err returns nil for a valid geometry and a *ValidationError otherwise
+func (d ValidityDetail) Err() error {
+	if d.Valid {
+		return nil
+	}
+	return &ValidationError{Reason: d.Reason, Location: d.Location}
+}
+
+// ValidationError is returned by Value() for invalid geometries when
+// validation is enabled with SetValidateOnValue
+type ValidationError struct {
+	Reason   InvalidReason
+	Location Point
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid geometry: %s at POINT(%g %g)", e.Reason, e.Location.X, e.Location.Y)
+}
+
+var valid = ValidityDetail{Valid: true}
+
+func invalid(reason InvalidReason, location Point) ValidityDetail {
+	return ValidityDetail{Reason: reason, Location: location}
+}
+
+var validateOnValue atomic.Bool
+
+// SetValidateOnValue makes Value() return a *ValidationError instead of
+// encoding geometries that fail ValidateDetail. It is off by default.
+func SetValidateOnValue(enabled bool) {
+	validateOnValue.Store(enabled)
+}
+
+// validator is implemented by every geometry type of this package
+type validator interface {
+	validateDetail() ValidityDetail
+}
+
+// IsValid reports whether a geometry is valid in the OGC sense, like ST_IsValid
+func IsValid(g Geometry) bool {
+	return ValidateDetail(g).Valid
+}
+
+// ValidateDetail checks a geometry like ST_IsValidDetail does: coordinates
+// must not be NaN or infinite, lines need two distinct points, polygon rings
+// must be closed with at least four points and must not cross, holes must lie
+// inside their shell without nesting or cutting the interior apart, and the
+// polygons of a MultiPolygon must not overlap. Geometry implementations from
+// outside this package are reported as valid.
+func ValidateDetail(g Geometry) ValidityDetail {
+	if v, ok := g.(validator); ok {
+		return v.validateDetail()
+	}
+	return valid
+}
+
+// Implement validator interface for all types
+func (p *Point) validateDetail() ValidityDetail    { return validatePoints([]Point{*p}) }
+func (p *PointZ) validateDetail() ValidityDetail   { return validatePoints([]PointZ{*p}) }
+func (p *PointM) validateDetail() ValidityDetail   { return validatePoints([]PointM{*p}) }
+func (p *PointZM) validateDetail() ValidityDetail  { return validatePoints([]PointZM{*p}) }
+func (p *PointS) validateDetail() ValidityDetail   { return validatePoints([]PointS{*p}) }
+func (p *PointZS) validateDetail() ValidityDetail  { return validatePoints([]PointZS{*p}) }
+func (p *PointMS) validateDetail() ValidityDetail  { return validatePoints([]PointMS{*p}) }
+func (p *PointZMS) validateDetail() ValidityDetail { return validatePoints([]PointZMS{*p}) }
+
+func (ls *LineStringOf[P]) validateDetail() ValidityDetail  { return validateLine(ls.Points) }
+func (ls *LineStringSOf[P]) validateDetail() ValidityDetail { return validateLine(ls.Points) }
+
+func (p *PolygonOf[P]) validateDetail() ValidityDetail  { return validatePolygons([][][]P{p.Rings}) }
+func (p *PolygonSOf[P]) validateDetail() ValidityDetail { return validatePolygons([][][]P{p.Rings}) }
+
+func (m *MultiPointOf[P]) validateDetail() ValidityDetail  { return validatePoints(m.Points) }
+func (m *MultiPointSOf[P]) validateDetail() ValidityDetail { return validatePoints(m.Points) }
+
+func (m *MultiLineStringOf[P]) validateDetail() ValidityDetail {
+	return validateLines(m.LineStrings, func(ls LineStringOf[P]) []P { return ls.Points })
+}
+
+func (m *MultiLineStringSOf[P]) validateDetail() ValidityDetail {
+	return validateLines(m.LineStrings, func(ls LineStringOf[P]) []P { return ls.Points })
+}
+
+func (m *MultiPolygonOf[P]) validateDetail() ValidityDetail {
+	return validatePolygons(polygonRings(m.Polygons, func(p PolygonOf[P]) [][]P { return p.Rings }))
+}
+
+func (m *MultiPolygonSOf[P]) validateDetail() ValidityDetail {
+	return validatePolygons(polygonRings(m.Polygons, func(p PolygonOf[P]) [][]P { return p.Rings }))
+}
+
+func (gc *GeometryCollection) validateDetail() ValidityDetail  { return validateMembers(gc.Geometries) }
+func (gc *GeometryCollectionS) validateDetail() ValidityDetail { return validateMembers(gc.Geometries) }
+
+func (cs *CircularStringOf[P]) validateDetail() ValidityDetail {
+	return validateCircularString(cs.Points)
+}
+
+func (cs *CircularStringSOf[P]) validateDetail() ValidityDetail {
+	return validateCircularString(cs.Points)
+}
+
+func (c *CompoundCurveOf[P]) validateDetail() ValidityDetail  { return validateMembers(c.Curves) }
+func (c *CompoundCurveSOf[P]) validateDetail() ValidityDetail { return validateMembers(c.Curves) }
+
+func (p *CurvePolygonOf[P]) validateDetail() ValidityDetail  { return validateCurvePolygon(p.Rings) }
+func (p *CurvePolygonSOf[P]) validateDetail() ValidityDetail { return validateCurvePolygon(p.Rings) }
+
+func (m *MultiCurveOf[P]) validateDetail() ValidityDetail  { return validateMembers(m.Curves) }
+func (m *MultiCurveSOf[P]) validateDetail() ValidityDetail { return validateMembers(m.Curves) }
+
+func (m *MultiSurfaceOf[P]) validateDetail() ValidityDetail  { return validateMembers(m.Surfaces) }
+func (m *MultiSurfaceSOf[P]) validateDetail() ValidityDetail { return validateMembers(m.Surfaces) }
+
+func (s *PolyhedralSurfaceOf[P]) validateDetail() ValidityDetail  { return validateFaces(s.Polygons) }
+func (s *PolyhedralSurfaceSOf[P]) validateDetail() ValidityDetail { return validateFaces(s.Polygons) }
+
+func (m *TINOf[P]) validateDetail() ValidityDetail  { return validateTriangles(m.Triangles) }
+func (m *TINSOf[P]) validateDetail() ValidityDetail { return validateTriangles(m.Triangles) }
+
+func (t *TriangleOf[P]) validateDetail() ValidityDetail  { return validateTriangle(t.Points) }
+func (t *TriangleSOf[P]) validateDetail() ValidityDetail { return validateTriangle(t.Points) }
+
+func validateMembers[G Geometry](geometries []G) ValidityDetail {
+	for _, g := range geometries {
+		if detail := ValidateDetail(g); !detail.Valid {
+			return detail
+		}
+	}
+	return valid
+}
+
+func validatePoints[P coordinate](points []P) ValidityDetail {
+	for _, p := range points {
+		if !p.finite() {
+			return invalid(ReasonInvalidCoordinate, p.xy())
+		}
+	}
+	return valid
+}
+
+func validateLine[P coordinate](points []P) ValidityDetail {
+	if detail := validatePoints(points); !detail.Valid {
+		return detail
+	}
+	if len(points) == 0 {
+		return valid
+	}
+	first := points[0].xy()
+	for _, p := range points[1:] {
+		if p.xy() != first {
+			return valid
+		}
+	}
+	return invalid(ReasonTooFewPoints, first)
+}
+
+// validateCircularString checks the coordinates and that the points form
+// whole arcs
+func validateCircularString[P coordinate](points []P) ValidityDetail {
+	if detail := validatePoints(points); !detail.Valid {
+		return detail
+	}
+	if n := len(points); n > 0 && (n < 3 || n%2 == 0) {
+		return invalid(ReasonTooFewPoints, points[0].xy())
+	}
+	return valid
+}
+
+// validateCurvePolygon checks the rings and then the polygon they form once
+// linearized
+func validateCurvePolygon[P PointLike](rings []Curve[P]) ValidityDetail {
+	if detail := validateMembers(rings); !detail.Valid {
+		return detail
+	}
+	linear, err := linearizeCurves(rings, DefaultCurveSegments)
+	if err != nil {
+		return valid
+	}
+	return validatePolygons([][][]P{linear})
+}
+
+// validateFaces validates the faces of a PolyhedralSurface one by one, as
+// faces share their edges
+func validateFaces[P PointLike](polygons []PolygonOf[P]) ValidityDetail {
+	for _, p := range polygons {
+		if detail := validateFace(p.Rings); !detail.Valid {
+			return detail
+		}
+	}
+	return valid
+}
+
+// validateFace validates a face of a surface. Faces with Z need not lie in
+// the XY plane, so only their rings are checked, in space; faces without Z
+// are validated as polygons.
+func validateFace[P PointLike](rings [][]P) ValidityDetail {
+	if layout := coordinateType[P](); layout != CoordXYZ && layout != CoordXYZM {
+		return validatePolygons([][][]P{rings})
+	}
+	for _, ring := range rings {
+		if detail := validatePoints(ring); !detail.Valid {
+			return detail
+		}
+		if len(ring) == 0 {
+			continue
+		}
+		x, y, z, _ := pointValues(ring[0])
+		if lx, ly, lz, _ := pointValues(ring[len(ring)-1]); lx != x || ly != y || lz != z {
+			return invalid(ReasonRingNotClosed, ring[0].xy())
+		}
+		// Repeated points do not count towards the minimum number of points
+		distinct := 1
+		for i := 1; i < len(ring); i++ {
+			x1, y1, z1, _ := pointValues(ring[i-1])
+			if x2, y2, z2, _ := pointValues(ring[i]); x1 != x2 || y1 != y2 || z1 != z2 {
+				distinct++
+			}
+		}
+		if distinct < 4 {
+			return invalid(ReasonTooFewPoints, ring[0].xy())
+		}
+	}
+	return valid
+}
+
+func validateTriangles[P PointLike](triangles []TriangleOf[P]) ValidityDetail {
+	for _, t := range triangles {
+		if detail := validateTriangle(t.Points); !detail.Valid {
+			return detail
+		}
+	}
+	return valid
+}
+
+// validateTriangle checks that a non-empty triangle is a valid ring of
+// exactly four points
+func validateTriangle[P PointLike](points []P) ValidityDetail {
+	if len(points) == 0 {
+		return valid
+	}
+	if detail := validateFace([][]P{points}); !detail.Valid {
+		return detail
+	}
+	if len(points) != 4 {
+		return invalid(ReasonTooFewPoints, points[0].xy())
+	}
+	return valid
+}
+
+func validateLines[L any, P coordinate](lines []L, points func(L) []P) ValidityDetail {
+	for _, line := range lines {
+		if detail := validateLine(points(line)); !detail.Valid {
+			return detail
+		}
+	}
+	return valid
+}
+
+func polygonRings[T any, P coordinate](polygons []T, rings func(T) [][]P) [][][]P {
+	result := make([][][]P, len(polygons))
+	for i, p := range polygons {
+		result[i] = rings(p)
+	}
+	return result
+}
+
+// validatePolygons validates the polygons of a MultiPolygon (or a single
+// polygon) together
+func validatePolygons[P coordinate](polygons [][][]P) ValidityDetail {
+	xy := make([][][]Point, 0, len(polygons))
+	for _, rings := range polygons {
+		if len(rings) == 0 {
+			continue
+		}
+		polygon := make([][]Point, len(rings))
+		for i, ring := range rings {
+			if detail := validatePoints(ring); !detail.Valid {
+				return detail
+			}
+			if len(ring) > 0 && ring[0].xy() != ring[len(ring)-1].xy() {
+				return invalid(ReasonRingNotClosed, ring[0].xy())
+			}
+			// Repeated points are allowed but do not count towards the
+			// minimum number of points
+			for _, p := range ring {
+				if n := len(polygon[i]); n == 0 || polygon[i][n-1] != p.xy() {
+					polygon[i] = append(polygon[i], p.xy())
+				}
+			}
+			if len(polygon[i]) < 4 {
+				location := Point{}
+				if len(ring) > 0 {
+					location = ring[0].xy()
+				}
+				return invalid(ReasonTooFewPoints, location)
+			}
+		}
+		xy = append(xy, polygon)
+	}
+
+	touches, detail := checkRingIntersections(xy)
+	if !detail.Valid {
+		return detail
+	}
+	for i, rings := range xy {
+		if detail := checkHoles(rings, touches[i]); !detail.Valid {
+			return detail
+		}
+	}
+	return checkNestedShells(xy)
+}
+
+type ringSegment struct {
+	a, b              Point
+	polygon, ring     int
+	index, ringLength int
+}
+
+// ringTouch records that two rings of a polygon meet at a single point
+type ringTouch struct {
+	a, b  int
+	point Point
+}
+
+// checkRingIntersections looks for crossing or overlapping segments anywhere
+// and for rings touching themselves, and returns the points where different
+// rings of the same polygon touch
+func checkRingIntersections(polygons [][][]Point) ([][]ringTouch, ValidityDetail) {
+	var segments []ringSegment
+	for pi, rings := range polygons {
+		for ri, ring := range rings {
+			for i := 0; i+1 < len(ring); i++ {
+				segments = append(segments, ringSegment{
+					a: ring[i], b: ring[i+1], polygon: pi, ring: ri, index: i, ringLength: len(ring) - 1,
+				})
+			}
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return math.Min(segments[i].a.X, segments[i].b.X) < math.Min(segments[j].a.X, segments[j].b.X)
+	})
+
+	type touchKey struct {
+		polygon int
+		touch   ringTouch
+	}
+	touches := make([][]ringTouch, len(polygons))
+	seen := make(map[touchKey]bool)
+	// Crossings are reported in preference to a ring touching itself
+	selfTouch := valid
+	for i := range segments {
+		s := &segments[i]
+		maxX := math.Max(s.a.X, s.b.X)
+		for j := i + 1; j < len(segments); j++ {
+			t := &segments[j]
+			if math.Min(t.a.X, t.b.X) > maxX {
+				break
+			}
+			relation, at := relateSegments(s.a, s.b, t.a, t.b)
+			switch relation {
+			case segmentsDisjoint:
+				continue
+			case segmentsCross, segmentsOverlap:
+				return nil, invalid(ReasonSelfIntersection, at)
+			}
+
+			switch {
+			case s.polygon != t.polygon:
+				// Separate polygons may touch at points
+			case s.ring == t.ring:
+				adjacent := s.index-t.index == 1 || t.index-s.index == 1 ||
+					(s.index == 0 && t.index == t.ringLength-1) || (t.index == 0 && s.index == s.ringLength-1)
+				if !adjacent && selfTouch.Valid {
+					selfTouch = invalid(ReasonRingSelfIntersection, at)
+				}
+			default:
+				touch := ringTouch{a: s.ring, b: t.ring, point: at}
+				if touch.a > touch.b {
+					touch.a, touch.b = touch.b, touch.a
+				}
+				key := touchKey{polygon: s.polygon, touch: touch}
+				if !seen[key] {
+					seen[key] = true
+					touches[s.polygon] = append(touches[s.polygon], touch)
+				}
+			}
+		}
+	}
+	return touches, selfTouch
+}
+
+// checkHoles verifies that holes lie inside the shell, are not nested and do
+// not touch each other or the shell in a way that splits the interior
+func checkHoles(rings [][]Point, touches []ringTouch) ValidityDetail {
+	shell := rings[0]
+	for i, hole := range rings[1:] {
+		if p, ok := firstOffBoundary(hole, shell); ok && pointInRing(p, shell) < 0 {
+			return invalid(ReasonHoleOutsideShell, p)
+		}
+		for j, other := range rings[1:] {
+			if i == j {
+				continue
+			}
+			if p, ok := firstOffBoundary(hole, other); ok && pointInRing(p, other) > 0 {
+				return invalid(ReasonNestedHoles, p)
+			}
+		}
+	}
+
+	// Rings touching in a cycle cut off part of the interior
+	parent := make([]int, len(rings))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, touch := range touches {
+		a, b := find(touch.a), find(touch.b)
+		if a == b {
+			return invalid(ReasonDisconnectedInterior, touch.point)
+		}
+		parent[a] = b
+	}
+	return valid
+}
+
+// checkNestedShells verifies that no polygon of a MultiPolygon lies inside
+// the area of another one
+func checkNestedShells(polygons [][][]Point) ValidityDetail {
+	for i, inner := range polygons {
+		for j, outer := range polygons {
+			if i == j {
+				continue
+			}
+			p, ok := firstOffBoundary(inner[0], outer...)
+			if !ok || pointInRing(p, outer[0]) < 0 {
+				continue
+			}
+			inHole := false
+			for _, hole := range outer[1:] {
+				if pointInRing(p, hole) > 0 {
+					inHole = true
+					break
+				}
+			}
+			if !inHole {
+				return invalid(ReasonNestedShells, p)
+			}
+		}
+	}
+	return valid
+}
+
+// firstOffBoundary returns the first point of ring that is not on the
+// boundary of any of the other rings
+func firstOffBoundary(ring []Point, others ...[]Point) (Point, bool) {
+	for _, p := range ring {
+		onBoundary := false
+		for _, other := range others {
+			if pointInRing(p, other) == 0 {
+				onBoundary = true
+				break
+			}
+		}
+		if !onBoundary {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+type segmentRelation int
+
+const (
+	segmentsDisjoint segmentRelation = iota
+	segmentsTouch                    // meet in a single point that is an end point of one of them
+	segmentsCross                    // meet in a single point interior to both
+	segmentsOverlap                  // share a stretch of positive length
+)
+
+// relateSegments classifies how the segments a-b and c-d meet and returns the
+// (first) point they have in common
+func relateSegments(a, b, c, d Point) (segmentRelation, Point) {
+	o1, o2 := orientation(a, b, c), orientation(a, b, d)
+	o3, o4 := orientation(c, d, a), orientation(c, d, b)
+
+	if o1 == 0 && o2 == 0 && o3 == 0 && o4 == 0 {
+		// Collinear: project on the dominant axis of a-b and compare ranges
+		key := func(p Point) float64 { return p.X }
+		if math.Abs(b.X-a.X) < math.Abs(b.Y-a.Y) {
+			key = func(p Point) float64 { return p.Y }
+		}
+		lo1, hi1 := a, b
+		if key(lo1) > key(hi1) {
+			lo1, hi1 = hi1, lo1
+		}
+		lo2, hi2 := c, d
+		if key(lo2) > key(hi2) {
+			lo2, hi2 = hi2, lo2
+		}
+		lo, hi := lo1, hi1
+		if key(lo2) > key(lo) {
+			lo = lo2
+		}
+		if key(hi2) < key(hi) {
+			hi = hi2
+		}
+		switch {
+		case key(lo) > key(hi):
+			return segmentsDisjoint, Point{}
+		case key(lo) == key(hi):
+			return segmentsTouch, lo
+		default:
+			return segmentsOverlap, lo
+		}
+	}
+
+	if ((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) && ((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0)) {
+		f := o3 / (o3 - o4)
+		return segmentsCross, Point{X: a.X + f*(b.X-a.X), Y: a.Y + f*(b.Y-a.Y)}
+	}
+
+	within := func(p, s, e Point) bool {
+		return p.X >= math.Min(s.X, e.X) && p.X <= math.Max(s.X, e.X) &&
+			p.Y >= math.Min(s.Y, e.Y) && p.Y <= math.Max(s.Y, e.Y)
+	}
+	switch {
+	case o1 == 0 && within(c, a, b):
+		return segmentsTouch, c
+	case o2 == 0 && within(d, a, b):
+		return segmentsTouch, d
+	case o3 == 0 && within(a, c, d):
+		return segmentsTouch, a
+	case o4 == 0 && within(b, c, d):
+		return segmentsTouch, b
+	}
+	return segmentsDisjoint, Point{}
+}
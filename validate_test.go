@@ -0,0 +1,78 @@
+package postgis
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidateDetail(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry Geometry
+		reason   InvalidReason
+		location Point
+	}{
+		{"Point", &PointS{SRID: 4326, X: 1, Y: 2}, ReasonNone, Point{}},
+		{"NaN Point", &PointZ{X: 1, Y: 2, Z: math.NaN()}, ReasonInvalidCoordinate, Point{X: 1, Y: 2}},
+		{"LineString", &LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}, ReasonNone, Point{}},
+		{"One point LineString", &LineString{Points: []Point{{X: 3, Y: 4}}}, ReasonTooFewPoints, Point{X: 3, Y: 4}},
+		{"Repeated point LineString", &LineStringZ{Points: []PointZ{{X: 3, Y: 4, Z: 1}, {X: 3, Y: 4, Z: 2}}}, ReasonTooFewPoints, Point{X: 3, Y: 4}},
+		{"Empty LineString", &LineString{}, ReasonNone, Point{}},
+		{"Polygon", &Polygon{Rings: [][]Point{square(0, 0, 10), square(2, 2, 2)}}, ReasonNone, Point{}},
+		{"Short ring", &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 0}}}}, ReasonTooFewPoints, Point{}},
+		{"Open ring", &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}}}, ReasonRingNotClosed, Point{}},
+		{"Bowtie", &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 0}}}}, ReasonSelfIntersection, Point{X: 1, Y: 1}},
+		{"Self touching ring", &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 2, Y: 2}, {X: 4, Y: 4}, {X: 0, Y: 4}, {X: 2, Y: 2}, {X: 0, Y: 0}}}}, ReasonRingSelfIntersection, Point{X: 2, Y: 2}},
+		{"Spike", &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 8, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 0}}}}, ReasonSelfIntersection, Point{X: 4, Y: 0}},
+		{"Hole outside", &Polygon{Rings: [][]Point{square(0, 0, 1), square(5, 5, 1)}}, ReasonHoleOutsideShell, Point{X: 5, Y: 5}},
+		{"Nested holes", &Polygon{Rings: [][]Point{square(0, 0, 10), square(1, 1, 8), square(2, 2, 2)}}, ReasonNestedHoles, Point{X: 2, Y: 2}},
+		{"Hole touching once", &Polygon{Rings: [][]Point{square(0, 0, 10), {{X: 0, Y: 5}, {X: 2, Y: 4}, {X: 2, Y: 6}, {X: 0, Y: 5}}}}, ReasonNone, Point{}},
+		{"Hole cutting interior", &Polygon{Rings: [][]Point{square(0, 0, 10), {{X: 0, Y: 5}, {X: 5, Y: 4}, {X: 10, Y: 5}, {X: 5, Y: 6}, {X: 0, Y: 5}}}}, ReasonDisconnectedInterior, Point{X: 10, Y: 5}},
+		{"MultiPolygon touching", &MultiPolygon{Polygons: []Polygon{{Rings: [][]Point{square(0, 0, 1)}}, {Rings: [][]Point{square(1, 1, 1)}}}}, ReasonNone, Point{}},
+		{"MultiPolygon overlapping", &MultiPolygon{Polygons: []Polygon{{Rings: [][]Point{square(0, 0, 2)}}, {Rings: [][]Point{square(1, 1, 2)}}}}, ReasonSelfIntersection, Point{X: 1, Y: 2}},
+		{"MultiPolygon nested", &MultiPolygonS{Polygons: []Polygon{{Rings: [][]Point{square(0, 0, 10)}}, {Rings: [][]Point{square(2, 2, 2)}}}}, ReasonNestedShells, Point{X: 2, Y: 2}},
+		{"Island in hole", &MultiPolygon{Polygons: []Polygon{{Rings: [][]Point{square(0, 0, 10), square(2, 2, 6)}}, {Rings: [][]Point{square(4, 4, 2)}}}}, ReasonNone, Point{}},
+		{"Collection", &GeometryCollection{Geometries: []Geometry{&Point{X: math.Inf(1), Y: 0}}}, ReasonInvalidCoordinate, Point{X: math.Inf(1)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detail := ValidateDetail(test.geometry)
+			if detail.Valid != (test.reason == ReasonNone) || detail.Reason != test.reason {
+				t.Fatalf("Expected %q, got %q (valid=%t)", test.reason, detail.Reason, detail.Valid)
+			}
+			if !detail.Valid && detail.Location != test.location {
+				t.Errorf("Expected location %v, got %v", test.location, detail.Location)
+			}
+			if IsValid(test.geometry) != detail.Valid {
+				t.Errorf("IsValid disagrees with ValidateDetail")
+			}
+		})
+	}
+}
+
+func TestValidateOnValue(t *testing.T) {
+	bowtie := PolygonS{SRID: 4326, Rings: [][]Point{{{X: 0, Y: 0}, {X: 2, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 0}}}}
+
+	// Off by default
+	if _, err := bowtie.Value(); err != nil {
+		t.Fatalf("PolygonS.Value() failed without validation: %v", err)
+	}
+
+	SetValidateOnValue(true)
+	defer SetValidateOnValue(false)
+
+	_, err := bowtie.Value()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if validationErr.Reason != ReasonSelfIntersection {
+		t.Errorf("Expected %q, got %q", ReasonSelfIntersection, validationErr.Reason)
+	}
+
+	if _, err := (Point{X: 1, Y: 2}).Value(); err != nil {
+		t.Errorf("Point.Value() failed for a valid point: %v", err)
+	}
+}
@@ -0,0 +1,600 @@
+package postgis
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidWKT is returned by ParseWKT for text that is not valid WKT or
+// EWKT
+var ErrInvalidWKT = errors.New("invalid WKT")
+
+// WKT returns the ISO WKT of g, like ST_AsText, e.g. "POINT Z (1 2 3)". The
+// SRID is left out.
+func WKT(g Geometry) (string, error) {
+	text, err := appendWKT(nil, g, false)
+	return string(text), err
+}
+
+// EWKT returns the PostGIS extended WKT of g, like ST_AsEWKT: the SRID comes
+// first when g has one and only M is marked, e.g. "SRID=4326;POINTM(1 2 3)".
+func EWKT(g Geometry) (string, error) {
+	text, err := appendWKT(nil, g, true)
+	return string(text), err
+}
+
+// ParseWKT parses WKT or EWKT, like ST_GeomFromEWKT, and returns a geometry
+// of the matching type of this package: "POINT Z (1 2 3)" and "POINT(1 2 3)"
+// both give a *PointZ, "SRID=4326;LINESTRINGM(1 2 3,4 5 6)" a *LineStringMS.
+// Keywords are not case sensitive.
+func ParseWKT(text string) (Geometry, error) {
+	wkbType, data, err := parseWKT(text, CoordXY)
+	if err != nil {
+		return nil, err
+	}
+	g, err := NewGeometry(wkbType)
+	if err != nil {
+		return nil, err
+	}
+	if err := ReadEWKBBytes(data, g); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidWKT, err)
+	}
+	return g, nil
+}
+
+// appendWKT writes the WKT of g by walking its EWKB encoding, which every
+// geometry has
+func appendWKT(dst []byte, g Geometry, extended bool) ([]byte, error) {
+	data, err := AppendEWKB(nil, g)
+	if err != nil {
+		return dst, err
+	}
+	if s, ok := g.(SRIDGeometry); ok && extended {
+		dst = append(strconv.AppendInt(append(dst, "SRID="...), int64(s.GetSRID()), 10), ';')
+	}
+	w := wktWriter{data: data, extended: extended}
+	return w.geometry(dst, true)
+}
+
+// wktWriter turns EWKB into WKT
+type wktWriter struct {
+	data     []byte
+	off      int
+	order    binary.ByteOrder
+	extended bool
+}
+
+func (w *wktWriter) uint32() (uint32, error) {
+	if len(w.data)-w.off < 4 {
+		return 0, ErrTruncatedEWKB
+	}
+	v := w.order.Uint32(w.data[w.off:])
+	w.off += 4
+	return v, nil
+}
+
+func (w *wktWriter) float() (float64, error) {
+	if len(w.data)-w.off < 8 {
+		return 0, ErrTruncatedEWKB
+	}
+	v := math.Float64frombits(w.order.Uint64(w.data[w.off:]))
+	w.off += 8
+	return v, nil
+}
+
+// geometry writes the geometry starting at the current offset, with its tag
+// unless it is a member whose type is implied by its container
+func (w *wktWriter) geometry(dst []byte, tagged bool) ([]byte, error) {
+	if w.off >= len(w.data) {
+		return dst, ErrTruncatedEWKB
+	}
+	switch w.data[w.off] {
+	case wkbNDR:
+		w.order = binary.LittleEndian
+	case wkbXDR:
+		w.order = binary.BigEndian
+	default:
+		return dst, fmt.Errorf("%w: %d", ErrUnsupportedByteOrder, w.data[w.off])
+	}
+	w.off++
+	wkbType, err := w.uint32()
+	if err != nil {
+		return dst, err
+	}
+	info := GetGeometryInfo(wkbType)
+	if info.HasSRID {
+		w.off += 4
+	}
+	if tagged {
+		dst = w.tag(dst, info)
+	}
+
+	stride := info.CoordType.Stride()
+	switch info.BaseType {
+	case WKBPoint:
+		return w.point(dst, stride)
+	case WKBLineString, WKBCircularString:
+		return w.points(dst, stride)
+	case WKBPolygon, WKBTriangle:
+		return w.rings(dst, stride)
+	case WKBMultiPoint, WKBMultiLineString, WKBMultiPolygon, WKBPolyhedralSurface, WKBTIN:
+		return w.members(dst, func(uint32) bool { return false })
+	case WKBCompoundCurve, WKBCurvePolygon, WKBMultiCurve:
+		return w.members(dst, func(member uint32) bool { return member != WKBLineString })
+	case WKBMultiSurface:
+		return w.members(dst, func(member uint32) bool { return member != WKBPolygon })
+	case WKBGeometryCollection:
+		return w.members(dst, func(uint32) bool { return true })
+	}
+	return dst, fmt.Errorf("%w: %d", ErrUnsupportedType, info.BaseType)
+}
+
+// tag writes the name of the type and its dimensions: "POINT Z " in ISO WKT
+// and "POINTM" in EWKT, where Z is implied by the number of coordinates
+func (w *wktWriter) tag(dst []byte, info GeometryInfo) []byte {
+	dst = append(dst, strings.ToUpper(baseTypeNames[info.BaseType])...)
+	if w.extended {
+		if info.CoordType == CoordXYM {
+			dst = append(dst, 'M')
+		}
+		return dst
+	}
+	if info.CoordType != CoordXY {
+		dst = append(append(append(dst, ' '), info.CoordType.String()[2:]...), ' ')
+	}
+	return dst
+}
+
+func (w *wktWriter) empty(dst []byte) []byte {
+	if n := len(dst); n > 0 && dst[n-1] != ' ' && dst[n-1] != '(' && dst[n-1] != ',' {
+		dst = append(dst, ' ')
+	}
+	return append(dst, "EMPTY"...)
+}
+
+// point writes a point, which is empty when all its values are NaN
+func (w *wktWriter) point(dst []byte, stride int) ([]byte, error) {
+	values := make([]float64, stride)
+	empty := true
+	for i := range values {
+		v, err := w.float()
+		if err != nil {
+			return dst, err
+		}
+		values[i] = v
+		empty = empty && math.IsNaN(v)
+	}
+	if empty {
+		return w.empty(dst), nil
+	}
+	return append(appendWKTCoordinate(append(dst, '('), values), ')'), nil
+}
+
+func (w *wktWriter) points(dst []byte, stride int) ([]byte, error) {
+	count, err := w.uint32()
+	if err != nil {
+		return dst, err
+	}
+	if count == 0 {
+		return w.empty(dst), nil
+	}
+	if uint64(len(w.data)-w.off) < uint64(count)*uint64(stride)*8 {
+		return dst, ErrTruncatedEWKB
+	}
+	values := make([]float64, stride)
+	dst = append(dst, '(')
+	for i := uint32(0); i < count; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		for j := range values {
+			values[j], _ = w.float()
+		}
+		dst = appendWKTCoordinate(dst, values)
+	}
+	return append(dst, ')'), nil
+}
+
+func (w *wktWriter) rings(dst []byte, stride int) ([]byte, error) {
+	count, err := w.uint32()
+	if err != nil {
+		return dst, err
+	}
+	if count == 0 {
+		return w.empty(dst), nil
+	}
+	dst = append(dst, '(')
+	for i := uint32(0); i < count; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		if dst, err = w.points(dst, stride); err != nil {
+			return dst, err
+		}
+	}
+	return append(dst, ')'), nil
+}
+
+// members writes the members of a collection, tagging the ones for which
+// tagged returns true
+func (w *wktWriter) members(dst []byte, tagged func(baseType uint32) bool) ([]byte, error) {
+	count, err := w.uint32()
+	if err != nil {
+		return dst, err
+	}
+	if count == 0 {
+		return w.empty(dst), nil
+	}
+	dst = append(dst, '(')
+	for i := uint32(0); i < count; i++ {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		// The member type follows its byte order
+		if len(w.data)-w.off < 5 {
+			return dst, ErrTruncatedEWKB
+		}
+		order := binary.ByteOrder(binary.LittleEndian)
+		if w.data[w.off] == wkbXDR {
+			order = binary.BigEndian
+		}
+		member := GetGeometryInfo(order.Uint32(w.data[w.off+1:])).BaseType
+		if dst, err = w.geometry(dst, tagged(member)); err != nil {
+			return dst, err
+		}
+		// A MultiPoint writes its points without parentheses
+		if n := len(dst); member == WKBPoint && !tagged(member) && dst[n-1] == ')' {
+			start := strings.LastIndexByte(string(dst), '(')
+			dst = append(dst[:start], dst[start+1:n-1]...)
+		}
+	}
+	return append(dst, ')'), nil
+}
+
+func appendWKTCoordinate(dst []byte, values []float64) []byte {
+	for i, v := range values {
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = appendWKTNumber(dst, v)
+	}
+	return dst
+}
+
+// appendWKTNumber writes the shortest decimal that reads back as v, without
+// an exponent unless the number is very large or very small
+func appendWKTNumber(dst []byte, v float64) []byte {
+	if a := math.Abs(v); a == 0 || (a >= 1e-6 && a < 1e21) {
+		return strconv.AppendFloat(dst, v, 'f', -1, 64)
+	}
+	return strconv.AppendFloat(dst, v, 'g', -1, 64)
+}
+
+// wktNode is a parsed geometry, kept until the number of values per
+// coordinate of the whole text is known
+type wktNode struct {
+	baseType uint32
+	// coordinates of points, lines and rings
+	coordinates [][]float64
+	// members of collections, and rings of polygons (with baseType 0)
+	members []*wktNode
+}
+
+// wktParser is a recursive descent parser for WKT and EWKT
+type wktParser struct {
+	text   string
+	pos    int
+	layout CoordinateType
+	stride int
+	depth  int
+}
+
+// wktTypes maps upper case type names to their base type
+var wktTypes = func() map[string]uint32 {
+	types := make(map[string]uint32, len(baseTypeNames))
+	for baseType, name := range baseTypeNames {
+		types[strings.ToUpper(name)] = baseType
+	}
+	return types
+}()
+
+// parseWKT parses text into EWKB, returning the type code of the geometry.
+// Text that has neither coordinates nor a dimension tag, such as "POINT
+// EMPTY", takes the coordinate type empty.
+func parseWKT(text string, empty CoordinateType) (uint32, []byte, error) {
+	p := wktParser{text: text, layout: keepLayout}
+	var srid *int32
+	if p.peekWord() == "SRID" {
+		p.word()
+		if err := p.expect('='); err != nil {
+			return 0, nil, err
+		}
+		value, err := p.number()
+		if err != nil {
+			return 0, nil, err
+		}
+		if value != math.Trunc(value) || value < math.MinInt32 || value > math.MaxInt32 {
+			return 0, nil, p.errorf("invalid SRID %v", value)
+		}
+		s := int32(value)
+		srid = &s
+		if err := p.expect(';'); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	node, err := p.geometry()
+	if err != nil {
+		return 0, nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return 0, nil, p.errorf("unexpected %q", p.text[p.pos:])
+	}
+
+	switch {
+	case p.layout == keepLayout && p.stride == 3:
+		p.layout = CoordXYZ
+	case p.layout == keepLayout && p.stride == 4:
+		p.layout = CoordXYZM
+	case p.layout == keepLayout && p.stride == 2:
+		p.layout = CoordXY
+	case p.layout == keepLayout:
+		p.layout = empty
+	}
+	wkbType := BuildWKBType(node.baseType, p.layout, srid != nil)
+	dst := appendHeader(nil, wkbType)
+	if srid != nil {
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(*srid))
+	}
+	return wkbType, p.encode(dst, node), nil
+}
+
+func (p *wktParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidWKT, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.text) && strings.IndexByte(" \t\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// peekWord returns the next word in upper case without consuming it
+func (p *wktParser) peekWord() string {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.text) && (p.text[end]|0x20 >= 'a' && p.text[end]|0x20 <= 'z') {
+		end++
+	}
+	return strings.ToUpper(p.text[p.pos:end])
+}
+
+func (p *wktParser) word() string {
+	word := p.peekWord()
+	p.pos += len(word)
+	return word
+}
+
+func (p *wktParser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.text) {
+		return p.text[p.pos]
+	}
+	return 0
+}
+
+func (p *wktParser) expect(c byte) error {
+	if p.peek() != c {
+		return p.errorf("expected %q", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.text) && strings.IndexByte("+-.0123456789eEaAnNiIfF", p.text[end]) >= 0 {
+		end++
+	}
+	v, err := strconv.ParseFloat(p.text[p.pos:end], 64)
+	if err != nil {
+		return 0, p.errorf("expected a number")
+	}
+	p.pos = end
+	return v, nil
+}
+
+// setLayout records the dimensions named by a tag, which must agree
+// throughout the text
+func (p *wktParser) setLayout(layout CoordinateType) error {
+	if p.layout != keepLayout && p.layout != layout {
+		return p.errorf("mixed dimensions %v and %v", p.layout, layout)
+	}
+	p.layout = layout
+	return nil
+}
+
+// geometry parses a tagged geometry
+func (p *wktParser) geometry() (*wktNode, error) {
+	if p.depth >= maxNestingDepth {
+		return nil, p.errorf("geometries nested deeper than %d levels", maxNestingDepth)
+	}
+	p.depth++
+	defer func() { p.depth-- }()
+
+	word := p.word()
+	baseType, ok := wktTypes[word]
+	if !ok && strings.HasSuffix(word, "M") {
+		// EWKT marks M by a suffix, as in POINTM
+		if baseType, ok = wktTypes[strings.TrimSuffix(word, "M")]; ok {
+			if err := p.setLayout(CoordXYM); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !ok {
+		return nil, p.errorf("unknown geometry type %q", word)
+	}
+
+	switch p.peekWord() {
+	case "Z":
+		p.word()
+		if err := p.setLayout(CoordXYZ); err != nil {
+			return nil, err
+		}
+	case "M":
+		p.word()
+		if err := p.setLayout(CoordXYM); err != nil {
+			return nil, err
+		}
+	case "ZM":
+		p.word()
+		if err := p.setLayout(CoordXYZM); err != nil {
+			return nil, err
+		}
+	}
+	return p.body(baseType)
+}
+
+// body parses the text following the tag of a geometry, or an untagged
+// member of a collection
+func (p *wktParser) body(baseType uint32) (*wktNode, error) {
+	node := &wktNode{baseType: baseType}
+	if p.peekWord() == "EMPTY" {
+		p.word()
+		return node, nil
+	}
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	switch baseType {
+	case WKBPoint:
+		c, err := p.coordinate()
+		if err != nil {
+			return nil, err
+		}
+		node.coordinates = [][]float64{c}
+	case WKBLineString, WKBCircularString, 0:
+		for {
+			c, err := p.coordinate()
+			if err != nil {
+				return nil, err
+			}
+			node.coordinates = append(node.coordinates, c)
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+	default:
+		for {
+			member, err := p.member(baseType)
+			if err != nil {
+				return nil, err
+			}
+			node.members = append(node.members, member)
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+	}
+	return node, p.expect(')')
+}
+
+// member parses a member of a collection, or a ring of a polygon. Members
+// whose type is implied by the collection may be untagged.
+func (p *wktParser) member(container uint32) (*wktNode, error) {
+	word := p.peekWord()
+	if word != "" && word != "EMPTY" {
+		return p.geometry()
+	}
+	switch container {
+	case WKBPolygon, WKBTriangle:
+		return p.body(0)
+	case WKBMultiPoint:
+		// The points of a MultiPoint may be written without parentheses
+		if p.peek() == '(' || word == "EMPTY" {
+			return p.body(WKBPoint)
+		}
+		c, err := p.coordinate()
+		if err != nil {
+			return nil, err
+		}
+		return &wktNode{baseType: WKBPoint, coordinates: [][]float64{c}}, nil
+	case WKBMultiLineString, WKBCompoundCurve, WKBCurvePolygon, WKBMultiCurve:
+		return p.body(WKBLineString)
+	case WKBMultiPolygon, WKBPolyhedralSurface, WKBMultiSurface:
+		return p.body(WKBPolygon)
+	case WKBTIN:
+		return p.body(WKBTriangle)
+	}
+	return nil, p.errorf("expected a geometry type")
+}
+
+// coordinate parses the values of one point, which must have as many values
+// as every other point of the text
+func (p *wktParser) coordinate() ([]float64, error) {
+	var values []float64
+	for len(values) < 4 {
+		if c := p.peek(); c == ',' || c == ')' || c == 0 {
+			break
+		}
+		v, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if len(values) < 2 {
+		return nil, p.errorf("expected at least 2 values per coordinate")
+	}
+	if p.stride == 0 {
+		p.stride = len(values)
+	}
+	if len(values) != p.stride {
+		return nil, p.errorf("coordinate with %d values after ones with %d", len(values), p.stride)
+	}
+	if p.layout != keepLayout && len(values) != p.layout.Stride() {
+		return nil, p.errorf("%d values for %v coordinates", len(values), p.layout)
+	}
+	return values, nil
+}
+
+// encode appends the EWKB body of a node, with a header for its members
+func (p *wktParser) encode(dst []byte, node *wktNode) []byte {
+	switch node.baseType {
+	case WKBPoint:
+		if len(node.coordinates) == 0 {
+			for i := 0; i < p.layout.Stride(); i++ {
+				dst = appendFloats(dst, math.NaN())
+			}
+			return dst
+		}
+		return appendFloats(dst, node.coordinates[0]...)
+	case WKBLineString, WKBCircularString, 0:
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(len(node.coordinates)))
+		for _, c := range node.coordinates {
+			dst = appendFloats(dst, c...)
+		}
+		return dst
+	case WKBPolygon, WKBTriangle:
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(len(node.members)))
+		for _, ring := range node.members {
+			dst = p.encode(dst, ring)
+		}
+		return dst
+	}
+	dst = binary.LittleEndian.AppendUint32(dst, uint32(len(node.members)))
+	for _, member := range node.members {
+		dst = p.encode(appendHeader(dst, BuildWKBType(member.baseType, p.layout, false)), member)
+	}
+	return dst
+}
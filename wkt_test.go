@@ -0,0 +1,144 @@
+package postgis
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWKT(t *testing.T) {
+	tests := []struct {
+		geometry Geometry
+		wkt      string
+		ewkt     string
+	}{
+		{&Point{X: 1, Y: 2}, "POINT(1 2)", "POINT(1 2)"},
+		{&PointMS{SRID: 4326, X: 1.5, Y: -2, M: 3}, "POINT M (1.5 -2 3)", "SRID=4326;POINTM(1.5 -2 3)"},
+		{&PointZM{X: math.NaN(), Y: math.NaN(), Z: math.NaN(), M: math.NaN()}, "POINT ZM EMPTY", "POINT EMPTY"},
+		{&LineStringZS{SRID: 3857, Points: []PointZ{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}}, "LINESTRING Z (1 2 3,4 5 6)", "SRID=3857;LINESTRING(1 2 3,4 5 6)"},
+		{&MultiPoint{Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}, "MULTIPOINT(1 2,3 4)", "MULTIPOINT(1 2,3 4)"},
+		{&Polygon{}, "POLYGON EMPTY", "POLYGON EMPTY"},
+		{&CompoundCurve{Curves: []Curve[Point]{&CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}, &LineString{Points: []Point{{X: 2, Y: 0}, {X: 3, Y: 0}}}}}, "COMPOUNDCURVE(CIRCULARSTRING(0 0,1 1,2 0),(2 0,3 0))", "COMPOUNDCURVE(CIRCULARSTRING(0 0,1 1,2 0),(2 0,3 0))"},
+		{&TINZ{Triangles: []TriangleZ{{Points: []PointZ{{X: 0, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 1, Y: 0, Z: 1}, {X: 0, Y: 0, Z: 0}}}}}, "TIN Z (((0 0 0,0 1 0,1 0 1,0 0 0)))", "TIN(((0 0 0,0 1 0,1 0 1,0 0 0)))"},
+		{&GeometryCollectionS{SRID: 4326, Geometries: []Geometry{&PointM{X: 1, Y: 2, M: 3}}}, "GEOMETRYCOLLECTION M (POINT M (1 2 3))", "SRID=4326;GEOMETRYCOLLECTIONM(POINTM(1 2 3))"},
+		{&Point{X: 1e-7, Y: 1e300}, "POINT(1e-07 1e+300)", "POINT(1e-07 1e+300)"},
+	}
+	for _, test := range tests {
+		t.Run(test.wkt, func(t *testing.T) {
+			if wkt, err := WKT(test.geometry); err != nil || wkt != test.wkt {
+				t.Errorf("Expected WKT %s, got %s (%v)", test.wkt, wkt, err)
+			}
+			if ewkt, err := EWKT(test.geometry); err != nil || ewkt != test.ewkt {
+				t.Errorf("Expected EWKT %s, got %s (%v)", test.ewkt, ewkt, err)
+			}
+		})
+	}
+}
+
+func TestParseWKT(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Geometry
+	}{
+		{"POINT(1 2)", &Point{X: 1, Y: 2}},
+		{"point z (1 2 3)", &PointZ{X: 1, Y: 2, Z: 3}},
+		{"POINT(1 2 3)", &PointZ{X: 1, Y: 2, Z: 3}},
+		{"POINTM(1 2 3)", &PointM{X: 1, Y: 2, M: 3}},
+		{"POINT(1 2 3 4)", &PointZM{X: 1, Y: 2, Z: 3, M: 4}},
+		{"SRID=4326;POINT(-71.06 42.28)", &PointS{SRID: 4326, X: -71.06, Y: 42.28}},
+		{" SRID = 4326 ; LINESTRING M ( 1 2 3 , 4 5 6 ) ", &LineStringMS{SRID: 4326, Points: []PointM{{X: 1, Y: 2, M: 3}, {X: 4, Y: 5, M: 6}}}},
+		{"LINESTRING EMPTY", &LineString{}},
+		{"MULTIPOINT((1 2),EMPTY,3 4)", &MultiPoint{Points: []Point{{X: 1, Y: 2}, {X: math.NaN(), Y: math.NaN()}, {X: 3, Y: 4}}}},
+		{"POLYGON((0 0,1 0,1 1,0 0))", &Polygon{Rings: [][]Point{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}}}},
+		{"MULTICURVE((0 0,1 1),CIRCULARSTRING(0 0,1 1,2 0))", &MultiCurve{Curves: []Curve[Point]{&LineString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}}}, &CircularString{Points: []Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}}}}}},
+		{"TRIANGLE((0 0,0 1,1 0,0 0))", &Triangle{Points: []Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 0}}}},
+		{"GEOMETRYCOLLECTION Z (POINT Z (1 2 3))", &GeometryCollection{Geometries: []Geometry{&PointZ{X: 1, Y: 2, Z: 3}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			g, err := ParseWKT(test.text)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if !reflect.DeepEqual(mustWriteEWKB(t, g), mustWriteEWKB(t, test.expected)) {
+				t.Errorf("Expected %v, got %v", test.expected, g)
+			}
+			if reflect.TypeOf(g) != reflect.TypeOf(test.expected) {
+				t.Errorf("Expected %T, got %T", test.expected, g)
+			}
+		})
+	}
+}
+
+func TestParseWKTRoundTrip(t *testing.T) {
+	for _, g := range sampleGeometries() {
+		t.Run(strings.TrimPrefix(geometryTypeName(g), "*postgis."), func(t *testing.T) {
+			for _, format := range []func(Geometry) (string, error){WKT, EWKT} {
+				text, err := format(g)
+				if err != nil {
+					t.Fatalf("Failed to format: %v", err)
+				}
+				parsed, err := ParseWKT(text)
+				if err != nil {
+					t.Fatalf("Failed to parse %s: %v", text, err)
+				}
+				if again, _ := format(parsed); again != text {
+					t.Errorf("Expected %s, got %s", text, again)
+				}
+			}
+		})
+	}
+}
+
+func TestParseWKTInvalid(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"POINT",
+		"POINT(1)",
+		"POINT(1 2",
+		"POINT(1 2) x",
+		"POINT(1 2 3 4 5)",
+		"POINT Z (1 2)",
+		"LINESTRING(1 2,3 4 5)",
+		"CIRCLE(1 2)",
+		"SRID=x;POINT(1 2)",
+		"SRID=4326 POINT(1 2)",
+		"GEOMETRYCOLLECTION((1 2))",
+		"GEOMETRYCOLLECTION Z (POINT M (1 2 3))",
+		"COMPOUNDCURVE(POINT(1 2))",
+		"TRIANGLE((0 0,0 1,1 0,0 0),(0 0,0 1,1 0,0 0))",
+	} {
+		if _, err := ParseWKT(text); !errors.Is(err, ErrInvalidWKT) {
+			t.Errorf("Expected ErrInvalidWKT for %q, got %v", text, err)
+		}
+	}
+}
+
+func TestParseWKTNestingDepth(t *testing.T) {
+	nested := func(depth int) string {
+		return strings.Repeat("GEOMETRYCOLLECTION(", depth-1) + "GEOMETRYCOLLECTION EMPTY" + strings.Repeat(")", depth-1)
+	}
+	if _, err := ParseWKT(nested(maxNestingDepth)); err != nil {
+		t.Fatalf("ParseWKT of %d levels failed: %v", maxNestingDepth, err)
+	}
+
+	for _, text := range []string{nested(maxNestingDepth + 1), strings.Repeat("GEOMETRYCOLLECTION(", 3_000_000)} {
+		if _, err := ParseWKT(text); !errors.Is(err, ErrInvalidWKT) {
+			t.Errorf("Expected ErrInvalidWKT from ParseWKT, got %v", err)
+		}
+		var gc GeometryCollection
+		if err := gc.UnmarshalText([]byte(text)); !errors.Is(err, ErrInvalidWKT) {
+			t.Errorf("Expected ErrInvalidWKT from UnmarshalText, got %v", err)
+		}
+		data, err := json.Marshal(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(data, &gc); !errors.Is(err, ErrInvalidWKT) {
+			t.Errorf("Expected ErrInvalidWKT from json.Unmarshal, got %v", err)
+		}
+	}
+}